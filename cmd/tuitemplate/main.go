@@ -0,0 +1,265 @@
+// Command tuitemplate is the TUITemplate toolkit's command-line helper.
+//
+// Usage:
+//
+//	tuitemplate launcher install [flags] program [args...]
+//	tuitemplate launcher remove [flags] name
+//	tuitemplate launcher list
+//
+// The launcher subcommand installs one-tap launch scripts for a built TUI
+// app on Termux: Termux:Widget shortcuts and background tasks, and
+// Termux:Boot scripts.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+const usage = `Usage:
+  tuitemplate launcher install [flags] program [args...]
+  tuitemplate launcher remove [flags] name
+  tuitemplate launcher list
+
+Run "tuitemplate launcher <command> -h" for the flags of a command.
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "tuitemplate:", err)
+		}
+		os.Exit(exitCode(err))
+	}
+}
+
+// usageError is an error in how tuitemplate was invoked, as opposed to a
+// failure carrying out the command.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+// exitCode is the exit status for an error returned by run: 2 for usage
+// errors, 1 for everything else.
+func exitCode(err error) int {
+	var usage usageError
+	if errors.As(err, &usage) {
+		return 2
+	}
+	return 1
+}
+
+func run(args []string) error {
+	if len(args) < 2 || args[0] != "launcher" {
+		fmt.Fprint(os.Stderr, usage)
+		return usageError{flag.ErrHelp}
+	}
+	switch args[1] {
+	case "install":
+		return installLaunchers(args[2:])
+	case "remove":
+		return removeLaunchers(args[2:])
+	case "list":
+		return listLaunchers(args[2:])
+	}
+	fmt.Fprint(os.Stderr, usage)
+	return usageError{fmt.Errorf("unknown launcher command %q", args[1])}
+}
+
+// installLaunchers implements "tuitemplate launcher install".
+func installLaunchers(args []string) error {
+	fs := flag.NewFlagSet("launcher install", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: tuitemplate launcher install [flags] program [args...]")
+		fs.PrintDefaults()
+	}
+	name := fs.String("name", "", "launcher name shown in the widget (default: the program's file name)")
+	kinds := fs.String("kind", "shortcut", "comma-separated launcher kinds: shortcut, task, boot")
+	icon := fs.String("icon", "", "PNG icon for shortcuts and tasks")
+	dir := fs.String("dir", "", "working directory (default: the home directory)")
+	logFile := fs.String("log", "", "append the program's output to this file")
+	wakeLock := fs.Bool("wake-lock", false, "hold the Termux wake lock while the program runs")
+	force := fs.Bool("force", false, "replace scripts that were not generated by tuitemplate")
+	env := map[string]string{}
+	fs.Func("env", "set an environment variable, as KEY=VALUE (repeatable)", func(s string) error {
+		k, v, ok := strings.Cut(s, "=")
+		if !ok {
+			return fmt.Errorf("want KEY=VALUE, got %q", s)
+		}
+		env[k] = v
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return usageError{err}
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return usageError{errors.New("launcher install needs a program")}
+	}
+
+	program, err := resolveProgram(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *name == "" {
+		*name = filepath.Base(program)
+	}
+	// Launchers start in the home directory, so relative paths would
+	// resolve against it rather than where the user installed from
+	for _, path := range []*string{icon, dir, logFile} {
+		if *path != "" {
+			if *path, err = filepath.Abs(*path); err != nil {
+				return err
+			}
+		}
+	}
+	script := termux.JobScript{
+		Command:  append([]string{program}, fs.Args()[1:]...),
+		Dir:      *dir,
+		Env:      env,
+		LogFile:  *logFile,
+		WakeLock: *wakeLock,
+	}
+
+	list, err := parseKinds(*kinds)
+	if err != nil {
+		return err
+	}
+	// Launchers this run creates, removed again if a later kind fails so
+	// a failed install doesn't leave only some of them behind. Ones it
+	// updated keep the new script.
+	var created []termux.Launcher
+	for _, kind := range list {
+		l := termux.Launcher{Kind: kind, Name: *name, Force: *force}
+		if kind != termux.LauncherBoot {
+			l.Icon = *icon
+		}
+		path, err := termux.LauncherPath(kind, *name)
+		if err != nil {
+			return errors.Join(err, undoInstall(created))
+		}
+		_, statErr := os.Stat(path)
+		changed, err := termux.InstallLauncher(l, script)
+		if err != nil {
+			return errors.Join(err, undoInstall(created))
+		}
+		if errors.Is(statErr, os.ErrNotExist) {
+			created = append(created, l)
+		}
+		status := "up to date"
+		if changed {
+			status = "installed"
+		}
+		fmt.Printf("%-8s %-10s %s\n", kind, status, path)
+	}
+	if !termux.IsTermux() {
+		fmt.Fprintln(os.Stderr, "note: not running on Termux; launchers only work with Termux:Widget or Termux:Boot")
+	}
+	return nil
+}
+
+// undoInstall removes the launchers in created, reporting each on stdout.
+func undoInstall(created []termux.Launcher) error {
+	var errs []error
+	for _, l := range created {
+		if err := termux.RemoveLauncher(l); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		path, _ := termux.LauncherPath(l.Kind, l.Name)
+		fmt.Printf("%-8s %-10s %s\n", l.Kind, "removed", path)
+	}
+	return errors.Join(errs...)
+}
+
+// removeLaunchers implements "tuitemplate launcher remove".
+func removeLaunchers(args []string) error {
+	fs := flag.NewFlagSet("launcher remove", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: tuitemplate launcher remove [flags] name")
+		fs.PrintDefaults()
+	}
+	kinds := fs.String("kind", "shortcut,task,boot", "comma-separated launcher kinds to remove")
+	force := fs.Bool("force", false, "remove scripts that were not generated by tuitemplate")
+	if err := fs.Parse(args); err != nil {
+		return usageError{err}
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return usageError{errors.New("launcher remove needs one name")}
+	}
+
+	list, err := parseKinds(*kinds)
+	if err != nil {
+		return err
+	}
+	for _, kind := range list {
+		path, err := termux.LauncherPath(kind, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := termux.RemoveLauncher(termux.Launcher{Kind: kind, Name: fs.Arg(0), Force: *force}); err != nil {
+			return err
+		}
+		fmt.Printf("%-8s %-10s %s\n", kind, "removed", path)
+	}
+	return nil
+}
+
+// listLaunchers implements "tuitemplate launcher list".
+func listLaunchers(args []string) error {
+	fs := flag.NewFlagSet("launcher list", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return usageError{err}
+	}
+	launchers, err := termux.ListLaunchers()
+	if err != nil {
+		return err
+	}
+	for _, l := range launchers {
+		origin := "generated"
+		if !l.Generated {
+			origin = "by hand"
+		}
+		fmt.Printf("%-8s %-10s %s\n", l.Kind, origin, l.Path)
+	}
+	return nil
+}
+
+// parseKinds parses a comma-separated list of launcher kinds.
+func parseKinds(s string) ([]termux.LauncherKind, error) {
+	var kinds []termux.LauncherKind
+	for _, field := range strings.Split(s, ",") {
+		kind := termux.LauncherKind(strings.TrimSpace(field))
+		if !slices.Contains(termux.LauncherKinds, kind) {
+			return nil, usageError{fmt.Errorf("unknown launcher kind %q (want shortcut, task or boot)", kind)}
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// resolveProgram returns the absolute path of program, looking it up in
+// $PATH if it has no directory. Launchers start in the home directory, so
+// a relative path would not resolve.
+func resolveProgram(program string) (string, error) {
+	path, err := exec.LookPath(program)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(path)
+}
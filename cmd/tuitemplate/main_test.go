@@ -0,0 +1,268 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// setup points HOME at a temporary directory and returns it with the path
+// of an executable to install launchers for.
+func setup(t *testing.T) (home, program string) {
+	t.Helper()
+	home = t.TempDir()
+	t.Setenv("HOME", home)
+	program = filepath.Join(t.TempDir(), "notes")
+	if err := os.WriteFile(program, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return home, program
+}
+
+// capture runs run(args) and returns what it printed to stdout. Usage
+// and notes printed to stderr are discarded.
+func capture(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer null.Close()
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, null
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+	err = run(args)
+	w.Close()
+	return <-out, err
+}
+
+func TestInstall(t *testing.T) {
+	home, program := setup(t)
+
+	out, err := capture(t, "launcher", "install",
+		"-kind", "shortcut, task",
+		"-env", "NOTES_THEME=dark",
+		"-env", "NOTES_QUERY=a=b",
+		"-wake-lock",
+		program, "--light")
+	if err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	if n := strings.Count(out, "installed"); n != 2 {
+		t.Errorf("output reports %d installs, want 2:\n%s", n, out)
+	}
+
+	// The name defaults to the program's file name
+	for _, path := range []string{
+		filepath.Join(home, ".shortcuts", "notes"),
+		filepath.Join(home, ".shortcuts", "tasks", "notes"),
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		script := string(data)
+		for _, want := range []string{
+			"export NOTES_QUERY=" + termux.ShellQuote("a=b") + "\n",
+			"export NOTES_THEME=" + termux.ShellQuote("dark") + "\n",
+			"termux-wake-lock\n",
+			termux.ShellCommand(program, "--light"),
+		} {
+			if !strings.Contains(script, want) {
+				t.Errorf("%s lacks %q:\n%s", path, want, script)
+			}
+		}
+	}
+	if _, err := os.Stat(filepath.Join(home, ".termux", "boot", "notes")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("boot script installed without -kind boot: %v", err)
+	}
+
+	// Installing again changes nothing
+	if out, err := capture(t, "launcher", "install", "-kind", "shortcut,task", "-env", "NOTES_THEME=dark",
+		"-env", "NOTES_QUERY=a=b", "-wake-lock", program, "--light"); err != nil || strings.Count(out, "up to date") != 2 {
+		t.Errorf("reinstall = %v:\n%s", err, out)
+	}
+
+	if _, err := capture(t, "launcher", "install", "-name", "Notes App", "-kind", "boot", program); err != nil {
+		t.Fatalf("install -name: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".termux", "boot", "Notes App")); err != nil {
+		t.Errorf("-name ignored: %v", err)
+	}
+}
+
+func TestInstallRelativePaths(t *testing.T) {
+	home, program := setup(t)
+	work := t.TempDir()
+	t.Chdir(work)
+
+	if _, err := capture(t, "launcher", "install", "-dir", ".", "-log", "out.log", program); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".shortcuts", "notes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := string(data)
+	for _, want := range []string{
+		"cd " + termux.ShellQuote(work) + " ",
+		">>" + termux.ShellQuote(filepath.Join(work, "out.log")) + " ",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script lacks %q:\n%s", want, script)
+		}
+	}
+}
+
+func TestInstallRollback(t *testing.T) {
+	home, program := setup(t)
+	if _, err := capture(t, "launcher", "install", "-kind", "shortcut", program); err != nil {
+		t.Fatal(err)
+	}
+	boot := filepath.Join(home, ".termux", "boot")
+	os.MkdirAll(boot, 0o700)
+	os.WriteFile(filepath.Join(boot, "notes"), []byte("#!/bin/sh\n"), 0o700)
+
+	// The boot script conflicts, so the task created before it is removed
+	// again; the shortcut that already existed stays
+	out, err := capture(t, "launcher", "install", "-kind", "shortcut,task,boot", program, "--light")
+	if !errors.Is(err, termux.ErrLauncherConflict) {
+		t.Fatalf("install = %v, want ErrLauncherConflict", err)
+	}
+	if got, want := columns(out), "shortcut installed,task installed,task removed"; got != want {
+		t.Errorf("output = %s, want %s:\n%s", got, want, out)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".shortcuts", "tasks", "notes")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("task left behind: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".shortcuts", "notes")); err != nil {
+		t.Errorf("existing shortcut removed: %v", err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	home, program := setup(t)
+	if _, err := capture(t, "launcher", "install", "-kind", "task,boot", program); err != nil {
+		t.Fatal(err)
+	}
+
+	// The default kinds skip the shortcut that was never installed
+	out, err := capture(t, "launcher", "remove", "notes")
+	if err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got := columns(out); got != "task removed,boot removed" {
+		t.Errorf("output = %s:\n%s", got, out)
+	}
+	for _, dir := range []string{".shortcuts/tasks", ".termux/boot"} {
+		if _, err := os.Stat(filepath.Join(home, dir, "notes")); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s/notes left behind: %v", dir, err)
+		}
+	}
+
+	// Only the listed kinds are removed
+	if _, err := capture(t, "launcher", "install", "-kind", "task,boot", program); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := capture(t, "launcher", "remove", "-kind", "boot", "notes"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".shortcuts", "tasks", "notes")); err != nil {
+		t.Errorf("task removed with -kind boot: %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	home, program := setup(t)
+	if _, err := capture(t, "launcher", "install", "-kind", "shortcut,boot", program); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(home, ".shortcuts", "backup"), []byte("#!/bin/sh\n"), 0o700)
+
+	out, err := capture(t, "launcher", "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := columns(out), "shortcut by hand,shortcut generated,boot generated"; got != want {
+		t.Errorf("list = %s, want %s:\n%s", got, want, out)
+	}
+}
+
+// columns returns the kind and status columns of each line of out, with
+// the lines separated by commas.
+func columns(out string) string {
+	var rows []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if len(line) > 20 {
+			line = line[:20]
+		}
+		rows = append(rows, strings.Join(strings.Fields(line), " "))
+	}
+	return strings.Join(rows, ",")
+}
+
+func TestRunErrors(t *testing.T) {
+	home, program := setup(t)
+
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{}, 2},
+		{[]string{"launcher"}, 2},
+		{[]string{"widget", "install"}, 2},
+		{[]string{"launcher", "update"}, 2},
+		{[]string{"launcher", "install"}, 2},
+		{[]string{"launcher", "install", "-kind", "widget", program}, 2},
+		{[]string{"launcher", "install", "-kind", "", program}, 2},
+		{[]string{"launcher", "install", "-env", "NOTES_THEME", program}, 2},
+		{[]string{"launcher", "install", "-no-such-flag", program}, 2},
+		{[]string{"launcher", "install", filepath.Join(t.TempDir(), "missing")}, 1},
+		{[]string{"launcher", "remove"}, 2},
+		{[]string{"launcher", "remove", "a", "b"}, 2},
+		{[]string{"launcher", "remove", "-kind", "task,widget", "notes"}, 2},
+	}
+	for _, tt := range tests {
+		_, err := capture(t, tt.args...)
+		if err == nil {
+			t.Errorf("run(%q) succeeded", tt.args)
+			continue
+		}
+		if code := exitCode(err); code != tt.code {
+			t.Errorf("run(%q): exit code %d, want %d (%v)", tt.args, code, tt.code, err)
+		}
+	}
+
+	// A script written by hand is a failure, not a usage error
+	shortcuts := filepath.Join(home, ".shortcuts")
+	if err := os.MkdirAll(shortcuts, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(shortcuts, "notes"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	_, err := capture(t, "launcher", "install", program)
+	if !errors.Is(err, termux.ErrLauncherConflict) || exitCode(err) != 1 {
+		t.Errorf("conflict: err = %v, exit code %d; want ErrLauncherConflict, 1", err, exitCode(err))
+	}
+
+	if _, err := capture(t, "launcher", "install", "-h"); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("-h: err = %v, want flag.ErrHelp", err)
+	}
+}
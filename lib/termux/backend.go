@@ -0,0 +1,126 @@
+package termux
+
+import (
+	"context"
+	"os"
+	"runtime"
+)
+
+// ToastLength selects how long a toast stays on screen.
+type ToastLength int
+
+const (
+	ToastLengthNormal ToastLength = iota // Approximately 2 seconds
+	ToastLengthLong                      // Approximately 4 seconds
+	ToastLengthShort                     // Very brief
+)
+
+// Backend delivers user-facing feedback: notifications, toasts, clipboard
+// access and speech. On Termux these map to the Termux:API commands; on a
+// Linux desktop they map to notify-send, an in-terminal toast queue,
+// xclip/wl-clipboard/OSC 52 and espeak.
+//
+// A Client picks its backend automatically (see DetectBackend). Override it
+// with SetBackend or the TUITEMPLATE_BACKEND environment variable.
+type Backend interface {
+	// Name identifies the backend ("termux", "linux", ...).
+	Name() string
+
+	Notify(ctx context.Context, title, content string, opts ...NotifyOption) error
+	Toast(ctx context.Context, message string, length ToastLength) error
+	ClipboardSet(ctx context.Context, text string) error
+	ClipboardGet(ctx context.Context) (string, error)
+	Speak(ctx context.Context, text string) error
+}
+
+// BackendEnv is the environment variable that forces a backend by name:
+// "termux" or "linux". Any other value is ignored.
+const BackendEnv = "TUITEMPLATE_BACKEND"
+
+// SetBackend replaces the default client's backend. See Client.SetBackend.
+func SetBackend(b Backend) {
+	Default().SetBackend(b)
+}
+
+// SetBackend replaces the client's backend. A nil backend restores
+// automatic selection.
+//
+// Example:
+//
+//	// Always use the desktop tools, even inside Termux
+//	client.SetBackend(termux.NewLinuxBackend(client))
+func (c *Client) SetBackend(b Backend) {
+	c.backendMu.Lock()
+	defer c.backendMu.Unlock()
+	c.backend = b
+}
+
+// Backend returns the client's backend, detecting it on first use.
+func (c *Client) Backend() Backend {
+	c.backendMu.Lock()
+	defer c.backendMu.Unlock()
+
+	if c.backend == nil {
+		c.backend = DetectBackend(c)
+	}
+	return c.backend
+}
+
+// TermuxBackend returns the backend that runs Termux:API commands on c.
+// Outside Termux it degrades like the rest of the package: calls are
+// no-ops, or return ErrNotTermux in strict mode.
+func TermuxBackend(c *Client) Backend {
+	return termuxBackend{c}
+}
+
+// DetectBackend chooses a backend for c from the environment:
+//
+//   - The backend named by TUITEMPLATE_BACKEND, if set
+//   - The Termux backend when running on Termux
+//   - The Linux backend on any other Linux host, with or without a
+//     desktop session; each call degrades on its own when its tool or
+//     the session it needs is missing
+//   - Otherwise the Termux backend, whose calls are no-ops
+func DetectBackend(c *Client) Backend {
+	switch os.Getenv(BackendEnv) {
+	case "termux":
+		return TermuxBackend(c)
+	case "linux":
+		return NewLinuxBackend(c)
+	}
+
+	if c.IsTermux() {
+		return TermuxBackend(c)
+	}
+	if runtime.GOOS == "linux" {
+		return NewLinuxBackend(c)
+	}
+	return TermuxBackend(c)
+}
+
+// termuxBackend runs Termux:API commands through its client.
+type termuxBackend struct {
+	c *Client
+}
+
+func (b termuxBackend) Name() string { return "termux" }
+
+func (b termuxBackend) Notify(ctx context.Context, title, content string, opts ...NotifyOption) error {
+	return b.c.termuxNotify(ctx, title, content, opts...)
+}
+
+func (b termuxBackend) Toast(ctx context.Context, message string, length ToastLength) error {
+	return b.c.termuxToast(ctx, message, length)
+}
+
+func (b termuxBackend) ClipboardSet(ctx context.Context, text string) error {
+	return b.c.termuxClipboardSet(ctx, text)
+}
+
+func (b termuxBackend) ClipboardGet(ctx context.Context) (string, error) {
+	return b.c.termuxClipboardGet(ctx)
+}
+
+func (b termuxBackend) Speak(ctx context.Context, text string) error {
+	return b.c.termuxSpeak(ctx, text)
+}
@@ -0,0 +1,319 @@
+package termux
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// BatteryEventKind identifies a change reported by a BatteryMonitor.
+type BatteryEventKind int
+
+const (
+	BatteryLow       BatteryEventKind = iota + 1 // Dropped below the low threshold while unplugged
+	BatteryCritical                              // Dropped below the critical threshold while unplugged
+	BatteryOK                                    // No longer low: recharged or plugged in
+	BatteryPlugged                               // Power connected
+	BatteryUnplugged                             // Power disconnected
+	BatteryHot                                   // Temperature rose above the limit
+	BatteryCooled                                // Temperature fell back to the limit
+)
+
+// String returns the event name (e.g., "low", "plugged").
+func (k BatteryEventKind) String() string {
+	switch k {
+	case BatteryLow:
+		return "low"
+	case BatteryCritical:
+		return "critical"
+	case BatteryOK:
+		return "ok"
+	case BatteryPlugged:
+		return "plugged"
+	case BatteryUnplugged:
+		return "unplugged"
+	case BatteryHot:
+		return "hot"
+	case BatteryCooled:
+		return "cooled"
+	default:
+		return "unknown"
+	}
+}
+
+// BatteryEvent is a battery state change seen by a BatteryMonitor.
+type BatteryEvent struct {
+	Kind   BatteryEventKind
+	Status BatteryStatus // The reading that triggered the event
+	Policy PowerPolicy   // The policy after the change
+	Time   time.Time
+}
+
+// PowerPolicy summarizes the battery state as advice for other subsystems.
+// The zero value means no restrictions.
+type PowerPolicy struct {
+	Plugged  bool // External power is connected
+	Low      bool // Below the low threshold and unplugged
+	Critical bool // Below the critical threshold and unplugged
+	Hot      bool // Above the temperature limit
+}
+
+// ReduceAnimation reports whether UI animation should be toned down.
+func (p PowerPolicy) ReduceAnimation() bool {
+	return p.Low || p.Hot
+}
+
+// DeferHeavyJobs reports whether CPU- or network-heavy work should wait.
+func (p PowerPolicy) DeferHeavyJobs() bool {
+	return p.Low || p.Hot
+}
+
+// FPS scales an animation frame rate: halved when animation should be
+// reduced, quartered when the battery is critical, and never below 1.
+//
+// Example:
+//
+//	tea.Tick(time.Second/time.Duration(monitor.Policy().FPS(60)), tick)
+func (p PowerPolicy) FPS(normal int) int {
+	switch {
+	case p.Critical:
+		normal /= 4
+	case p.ReduceAnimation():
+		normal /= 2
+	}
+	if normal < 1 {
+		return 1
+	}
+	return normal
+}
+
+// Interval stretches a polling or refresh interval the same way FPS
+// scales frame rates: doubled when reduced, quadrupled when critical.
+func (p PowerPolicy) Interval(normal time.Duration) time.Duration {
+	switch {
+	case p.Critical:
+		return normal * 4
+	case p.ReduceAnimation():
+		return normal * 2
+	}
+	return normal
+}
+
+// BatteryMonitorOptions configures a BatteryMonitor. Zero fields use the
+// defaults noted on each.
+type BatteryMonitorOptions struct {
+	Interval          time.Duration // Time between polls; default 1 minute
+	LowThreshold      int           // Percentage considered low; default 20
+	CriticalThreshold int           // Percentage considered critical; default 10
+	MaxTemperature    float64       // Temperature limit in Celsius; default 45
+}
+
+// ErrMonitorStopped is returned by BatteryMonitor.WaitForPower when the
+// monitor's Run has returned while jobs were deferred, so the policy can
+// no longer change.
+var ErrMonitorStopped = errors.New("termux: battery monitor stopped")
+
+// batteryEventBuffer is how many events Events holds for a slow reader.
+const batteryEventBuffer = 16
+
+// BatteryMonitor polls the battery and turns readings into events and a
+// PowerPolicy.
+//
+// Example:
+//
+//	monitor := termux.NewBatteryMonitor(termux.Default(), termux.BatteryMonitorOptions{})
+//	go monitor.Run(ctx)
+//
+//	// A long-running job backs off while the battery is low or hot
+//	for _, item := range work {
+//	    if err := monitor.WaitForPower(ctx); err != nil {
+//	        return err
+//	    }
+//	    process(item)
+//	}
+type BatteryMonitor struct {
+	c    *Client
+	opts BatteryMonitorOptions
+
+	events chan BatteryEvent
+	done   chan struct{} // closed when Run returns
+	ended  sync.Once
+
+	mu      sync.Mutex
+	status  *BatteryStatus
+	policy  PowerPolicy
+	err     error
+	changed chan struct{} // closed and replaced when policy changes
+}
+
+// NewBatteryMonitor creates a BatteryMonitor that polls through c. Call
+// Run to start polling.
+func NewBatteryMonitor(c *Client, opts BatteryMonitorOptions) *BatteryMonitor {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	if opts.LowThreshold <= 0 {
+		opts.LowThreshold = 20
+	}
+	if opts.CriticalThreshold <= 0 {
+		opts.CriticalThreshold = 10
+	}
+	if opts.MaxTemperature <= 0 {
+		opts.MaxTemperature = 45
+	}
+	return &BatteryMonitor{
+		c:       c,
+		opts:    opts,
+		events:  make(chan BatteryEvent, batteryEventBuffer),
+		done:    make(chan struct{}),
+		changed: make(chan struct{}),
+	}
+}
+
+// Run polls the battery every Interval until ctx is done, then closes
+// Done and returns ctx.Err(). A failed poll is recorded (see Err) and
+// retried at the next interval. Run a monitor only once.
+func (m *BatteryMonitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+	defer m.ended.Do(func() { close(m.done) })
+
+	for {
+		m.Check(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Check polls the battery once, updating the policy and emitting events
+// for any changes since the previous poll.
+func (m *BatteryMonitor) Check(ctx context.Context) error {
+	status, err := m.c.GetBatteryStatusContext(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.err = err
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	prev := m.policy
+	next := m.evaluate(status)
+	first := m.status == nil
+	m.status = status
+	m.policy = next
+
+	emit := func(kind BatteryEventKind) {
+		event := BatteryEvent{Kind: kind, Status: *status, Policy: next, Time: now}
+		// Never block polling if nobody is listening
+		select {
+		case m.events <- event:
+		default:
+		}
+	}
+
+	if !first && next.Plugged != prev.Plugged {
+		if next.Plugged {
+			emit(BatteryPlugged)
+		} else {
+			emit(BatteryUnplugged)
+		}
+	}
+	switch {
+	case next.Critical && !prev.Critical:
+		emit(BatteryCritical)
+	case next.Low && !prev.Low:
+		emit(BatteryLow)
+	case !next.Low && prev.Low:
+		emit(BatteryOK)
+	}
+	switch {
+	case next.Hot && !prev.Hot:
+		emit(BatteryHot)
+	case !next.Hot && prev.Hot:
+		emit(BatteryCooled)
+	}
+
+	if next != prev {
+		close(m.changed)
+		m.changed = make(chan struct{})
+	}
+	return nil
+}
+
+// evaluate derives the policy for status.
+func (m *BatteryMonitor) evaluate(status *BatteryStatus) PowerPolicy {
+	plugged := status.Plugged != "" && status.Plugged != "UNPLUGGED"
+	return PowerPolicy{
+		Plugged:  plugged,
+		Low:      !plugged && status.Percentage < m.opts.LowThreshold,
+		Critical: !plugged && status.Percentage < m.opts.CriticalThreshold,
+		Hot:      status.Temperature > m.opts.MaxTemperature,
+	}
+}
+
+// Events delivers battery events as they happen. Events emitted while the
+// channel's buffer is full are dropped; Policy always reflects the latest
+// state.
+func (m *BatteryMonitor) Events() <-chan BatteryEvent {
+	return m.events
+}
+
+// Done is closed when Run returns. No events are sent after that, though
+// buffered ones can still be read from Events.
+func (m *BatteryMonitor) Done() <-chan struct{} {
+	return m.done
+}
+
+// Policy returns the current power policy. Before the first poll it is
+// the zero PowerPolicy (no restrictions).
+func (m *BatteryMonitor) Policy() PowerPolicy {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.policy
+}
+
+// Status returns the most recent battery reading, if any.
+func (m *BatteryMonitor) Status() (BatteryStatus, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.status == nil {
+		return BatteryStatus{}, false
+	}
+	return *m.status, true
+}
+
+// Err returns the error from the most recent poll, or nil if it succeeded.
+func (m *BatteryMonitor) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+// WaitForPower blocks while the policy says to defer heavy jobs, returning
+// nil once they may run or ctx.Err() if ctx is done first. If Run returns
+// while jobs are deferred, it reports ErrMonitorStopped.
+func (m *BatteryMonitor) WaitForPower(ctx context.Context) error {
+	for {
+		m.mu.Lock()
+		deferJobs, changed := m.policy.DeferHeavyJobs(), m.changed
+		m.mu.Unlock()
+
+		if !deferJobs {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-m.done:
+			return ErrMonitorStopped
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package termux_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func batteryJSON(percentage int, plugged string, temperature float64) string {
+	return fmt.Sprintf(`{"percentage":%d,"plugged":%q,"temperature":%g}`, percentage, plugged, temperature)
+}
+
+func TestBatteryMonitorEvents(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-battery-status", batteryJSON(50, "UNPLUGGED", 30))
+	fake.Respond("termux-battery-status", batteryJSON(15, "UNPLUGGED", 47))
+	fake.Respond("termux-battery-status", batteryJSON(8, "UNPLUGGED", 47))
+	fake.Respond("termux-battery-status", batteryJSON(8, "PLUGGED_AC", 40))
+	monitor := termux.NewBatteryMonitor(termux.NewClient(fake), termux.BatteryMonitorOptions{})
+
+	var kinds []termux.BatteryEventKind
+	for i := 0; i < 4; i++ {
+		if err := monitor.Check(context.Background()); err != nil {
+			t.Fatalf("Check %d: %v", i, err)
+		}
+	drain:
+		for {
+			select {
+			case e := <-monitor.Events():
+				kinds = append(kinds, e.Kind)
+			default:
+				break drain
+			}
+		}
+	}
+
+	want := []termux.BatteryEventKind{
+		termux.BatteryLow, termux.BatteryHot, // 15%, 47°C
+		termux.BatteryCritical,                                        // 8%
+		termux.BatteryPlugged, termux.BatteryOK, termux.BatteryCooled, // charging, 40°C
+	}
+	if len(kinds) != len(want) {
+		t.Fatalf("events = %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("events = %v, want %v", kinds, want)
+			break
+		}
+	}
+
+	if p := monitor.Policy(); p.DeferHeavyJobs() || !p.Plugged {
+		t.Errorf("Policy = %+v after plugging in", p)
+	}
+}
+
+func TestBatteryMonitorWaitForPowerStopped(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-battery-status", batteryJSON(5, "UNPLUGGED", 30))
+	monitor := termux.NewBatteryMonitor(termux.NewClient(fake), termux.BatteryMonitorOptions{Interval: time.Hour})
+	ctx, stop := context.WithCancel(context.Background())
+	go monitor.Run(ctx)
+
+	done := make(chan error)
+	go func() {
+		// Wait for the first poll so the monitor is deferring jobs
+		for !monitor.Policy().DeferHeavyJobs() {
+			time.Sleep(time.Millisecond)
+		}
+		done <- monitor.WaitForPower(context.Background())
+	}()
+	time.Sleep(10 * time.Millisecond)
+	stop()
+	select {
+	case err := <-done:
+		if !errors.Is(err, termux.ErrMonitorStopped) {
+			t.Errorf("WaitForPower = %v, want ErrMonitorStopped", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitForPower still blocked after the monitor stopped")
+	}
+}
+
+func TestPowerPolicyScaling(t *testing.T) {
+	tests := []struct {
+		policy   termux.PowerPolicy
+		fps      int
+		interval time.Duration
+	}{
+		{termux.PowerPolicy{}, 60, time.Second},
+		{termux.PowerPolicy{Hot: true}, 30, 2 * time.Second},
+		{termux.PowerPolicy{Low: true, Critical: true}, 15, 4 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.FPS(60); got != tt.fps {
+			t.Errorf("%+v FPS(60) = %d, want %d", tt.policy, got, tt.fps)
+		}
+		if got := tt.policy.Interval(time.Second); got != tt.interval {
+			t.Errorf("%+v Interval(1s) = %v, want %v", tt.policy, got, tt.interval)
+		}
+	}
+}
+
+func TestBatteryMonitorWaitForPower(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-battery-status", batteryJSON(5, "UNPLUGGED", 30))
+	fake.Respond("termux-battery-status", batteryJSON(5, "PLUGGED_USB", 30))
+	monitor := termux.NewBatteryMonitor(termux.NewClient(fake), termux.BatteryMonitorOptions{})
+	monitor.Check(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := monitor.WaitForPower(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("WaitForPower while low = %v, want DeadlineExceeded", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- monitor.WaitForPower(context.Background()) }()
+	monitor.Check(context.Background())
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("WaitForPower = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitForPower did not return after plugging in")
+	}
+}
@@ -0,0 +1,229 @@
+package termux
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// NotificationAction reports a tapped notification button (or another
+// action built with NotificationCallbacks.Action).
+type NotificationAction struct {
+	ID     string `json:"id"`     // Notification ID given to Action
+	Button string `json:"button"` // Button token given to Action, usually its label
+}
+
+// notificationActionBuffer is how many actions Actions holds for a slow
+// reader before the button commands start to block.
+const notificationActionBuffer = 16
+
+// NotificationCallbacks routes notification buttons back into the running
+// app. It owns a named pipe (FIFO); each action built with Action writes
+// a line naming the notification and button to it, and the app receives
+// them on Actions.
+//
+// Named pipes need a Unix-like system; elsewhere NewNotificationCallbacks
+// returns an error wrapping errors.ErrUnsupported.
+//
+// An action whose app is no longer running does nothing: it writes only
+// to an existing pipe, and opens it without waiting for a reader. Close
+// the callbacks on exit all the same; pipes left by a process that exited
+// without closing them are removed by the next NewNotificationCallbacks
+// in the same directory.
+//
+// Example:
+//
+//	cb, err := termux.NewNotificationCallbacks("")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer cb.Close()
+//
+//	termux.Notify("PR #42", "Ready for review",
+//	    termux.WithID("pr-42"),
+//	    termux.WithButton("Approve", cb.Action("pr-42", "Approve")),
+//	    termux.WithButton("Reject", cb.Action("pr-42", "Reject")))
+//
+//	for action := range cb.Actions() {
+//	    fmt.Println(action.ID, action.Button) // "pr-42 Approve"
+//	}
+type NotificationCallbacks struct {
+	path    string
+	pipe    *os.File
+	actions chan NotificationAction
+	stop    chan struct{} // closed by Close
+	done    chan struct{} // closed when listen returns
+
+	mu       sync.Mutex
+	handlers map[string]*actionHandler // By notification ID
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// callbackPipePrefix starts the file name of every callback pipe, which
+// continues with the owning process's PID and a sequence number.
+const callbackPipePrefix = "tuitemplate-actions-"
+
+// callbackPipes numbers the callback pipes of this process.
+var callbackPipes atomic.Int64
+
+// NewNotificationCallbacks creates the callback pipe in dir (the temp
+// directory if empty) and starts listening on it. Pipes in dir left by
+// processes that are no longer running are removed.
+func NewNotificationCallbacks(dir string) (*NotificationCallbacks, error) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	sweepCallbackPipes(dir)
+	path := filepath.Join(dir, callbackPipePrefix+formatInt(os.Getpid())+"-"+formatInt(int(callbackPipes.Add(1))))
+
+	os.Remove(path) // left over from a previous process with our PID
+	if err := makeFIFO(path); err != nil {
+		return nil, err
+	}
+
+	// Opening read-write keeps a writer attached, so the read never sees
+	// EOF between button taps and the open doesn't wait for a writer.
+	pipe, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	cb := &NotificationCallbacks{
+		path:     path,
+		pipe:     pipe,
+		actions:  make(chan NotificationAction, notificationActionBuffer),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		handlers: map[string]*actionHandler{},
+	}
+	go cb.listen()
+	return cb, nil
+}
+
+// Path returns the location of the callback pipe.
+func (cb *NotificationCallbacks) Path() string {
+	return cb.path
+}
+
+// Action returns a command that reports a tap on button of the
+// notification id. Use it as a button, tap or dismiss action.
+//
+// The command does nothing once the pipe is gone. It opens the pipe
+// read-write, which never waits for a reader, so a tap after the app
+// exited neither hangs nor leaves a regular file in the pipe's place.
+func (cb *NotificationCallbacks) Action(id, button string) []string {
+	line, _ := json.Marshal(NotificationAction{ID: id, Button: button})
+	pipe := ShellQuote(cb.path)
+	script := "[ -p " + pipe + " ] && " + ShellCommand("printf", `%s\n`, string(line)) + " 1<>" + pipe
+	return []string{"sh", "-c", script}
+}
+
+// Button returns a notification button labeled label that reports taps
+// as NotificationAction{ID: id, Button: label}.
+func (cb *NotificationCallbacks) Button(id, label string) NotificationButton {
+	return NotificationButton{Label: label, Action: cb.Action(id, label)}
+}
+
+// Handle calls fn for each action reported for the notification id,
+// instead of delivering it on Actions. fn runs on the listening goroutine
+// and must not block. A later Handle for the same id replaces fn. Call the
+// returned function to stop handling id.
+//
+// Example:
+//
+//	stop := cb.Handle("sync", func(a termux.NotificationAction) {
+//	    if a.Button == "Pause" {
+//	        pause()
+//	    }
+//	})
+//	defer stop()
+func (cb *NotificationCallbacks) Handle(id string, fn func(NotificationAction)) (stop func()) {
+	h := &actionHandler{fn: fn}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.handlers[id] = h
+
+	return func() {
+		cb.mu.Lock()
+		defer cb.mu.Unlock()
+		if cb.handlers[id] == h {
+			delete(cb.handlers, id)
+		}
+	}
+}
+
+// actionHandler is a function registered with Handle. It is referenced by
+// pointer so a stop function only removes its own registration.
+type actionHandler struct {
+	fn func(NotificationAction)
+}
+
+// Actions delivers reported actions. It is closed by Close.
+func (cb *NotificationCallbacks) Actions() <-chan NotificationAction {
+	return cb.actions
+}
+
+// Close stops listening and removes the pipe.
+func (cb *NotificationCallbacks) Close() error {
+	cb.closeOnce.Do(func() {
+		close(cb.stop)
+		cb.closeErr = cb.pipe.Close()
+		<-cb.done
+		if err := os.Remove(cb.path); err != nil && !errors.Is(err, os.ErrNotExist) && cb.closeErr == nil {
+			cb.closeErr = err
+		}
+	})
+	return cb.closeErr
+}
+
+// sweepCallbackPipes removes the callback pipes in dir whose process is
+// no longer running, left by apps that exited without Close.
+func sweepCallbackPipes(dir string) {
+	paths, _ := filepath.Glob(filepath.Join(dir, callbackPipePrefix+"*"))
+	for _, path := range paths {
+		pidText, _, _ := strings.Cut(strings.TrimPrefix(filepath.Base(path), callbackPipePrefix), "-")
+		pid, err := strconv.Atoi(pidText)
+		if err != nil || pid <= 0 || processAlive(pid) {
+			continue
+		}
+		if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeNamedPipe != 0 {
+			os.Remove(path)
+		}
+	}
+}
+
+// listen reads action lines from the pipe until it is closed, passing
+// each to its handler or Actions. Lines that aren't actions are ignored.
+func (cb *NotificationCallbacks) listen() {
+	defer close(cb.done)
+	defer close(cb.actions)
+
+	scanner := bufio.NewScanner(cb.pipe)
+	for scanner.Scan() {
+		var action NotificationAction
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			continue
+		}
+		cb.mu.Lock()
+		h := cb.handlers[action.ID]
+		cb.mu.Unlock()
+		if h != nil {
+			h.fn(action)
+			continue
+		}
+		select {
+		case cb.actions <- action:
+		case <-cb.stop:
+			return
+		}
+	}
+}
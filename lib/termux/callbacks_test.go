@@ -0,0 +1,121 @@
+//go:build unix
+
+package termux_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+func TestNotificationCallbacks(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell")
+	}
+	cb, err := termux.NewNotificationCallbacks(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewNotificationCallbacks: %v", err)
+	}
+	defer cb.Close()
+
+	// Tap two buttons the way Termux would run their actions
+	for _, b := range []termux.NotificationButton{
+		cb.Button("pr-42", "Approve"),
+		cb.Button("it's \"quoted\"", "Re;ject $HOME"),
+	} {
+		if out, err := exec.Command("sh", "-c", termux.ShellCommand(b.Action[0], b.Action[1:]...)).CombinedOutput(); err != nil {
+			t.Fatalf("action %q: %v: %s", b.Action, err, out)
+		}
+	}
+
+	want := []termux.NotificationAction{
+		{ID: "pr-42", Button: "Approve"},
+		{ID: `it's "quoted"`, Button: "Re;ject $HOME"},
+	}
+	for _, w := range want {
+		select {
+		case got := <-cb.Actions():
+			if got != w {
+				t.Errorf("action = %+v, want %+v", got, w)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no action received, want %+v", w)
+		}
+	}
+
+	if err := cb.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if _, ok := <-cb.Actions(); ok {
+		t.Error("Actions still open after Close")
+	}
+}
+
+func TestNotificationCallbacksAfterExit(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell")
+	}
+	dir := t.TempDir()
+
+	// A pipe and a regular file named for a process that has exited
+	dead := exec.Command("sh", "-c", "exit 0")
+	if err := dead.Run(); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(dir, "tuitemplate-actions-"+strconv.Itoa(dead.Process.Pid))
+	if err := syscall.Mkfifo(stale, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	other := filepath.Join(dir, "tuitemplate-actions-notes.txt")
+	os.WriteFile(other, nil, 0o600)
+
+	cb, err := termux.NewNotificationCallbacks(dir)
+	if err != nil {
+		t.Fatalf("NewNotificationCallbacks: %v", err)
+	}
+	if _, err := os.Lstat(stale); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("stale pipe not swept: %v", err)
+	}
+	if _, err := os.Lstat(other); err != nil {
+		t.Errorf("non-pipe file swept: %v", err)
+	}
+	action := cb.Action("pr-42", "Approve")
+	cb.Close()
+
+	// Tapping after Close returns at once and leaves no file behind
+	tap := exec.Command(action[0], action[1:]...)
+	done := make(chan error, 1)
+	go func() { done <- tap.Run() }()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		tap.Process.Kill()
+		t.Fatal("action blocked after Close")
+	}
+	if _, err := os.Lstat(cb.Path()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("action after Close created %s: %v", cb.Path(), err)
+	}
+
+	// A pipe with no reader, left by an app killed before the sweep
+	if err := syscall.Mkfifo(cb.Path(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tap = exec.Command(action[0], action[1:]...)
+	go func() { done <- tap.Run() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("action on an unread pipe: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		tap.Process.Kill()
+		t.Fatal("action blocked on a pipe with no reader")
+	}
+}
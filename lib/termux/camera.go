@@ -0,0 +1,132 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CameraSize is an image size in pixels.
+type CameraSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// CameraInfo describes a camera as reported by termux-camera-info.
+type CameraInfo struct {
+	ID                string       `json:"id"`                  // Pass to TakePhoto
+	Facing            string       `json:"facing"`              // "back", "front" or "external"
+	JPEGSizes         []CameraSize `json:"jpeg_output_sizes"`   // Supported photo sizes, largest first
+	FocalLengths      []float64    `json:"focal_lengths"`       // In millimeters
+	AutoExposureModes []string     `json:"auto_exposure_modes"` // e.g. "CONTROL_AE_MODE_ON"
+	Capabilities      []string     `json:"capabilities"`        // e.g. "backward_compatible", "raw"
+}
+
+// ListCameras returns the device's cameras.
+//
+// If not running on Termux, returns an empty list.
+//
+// Example:
+//
+//	cameras, _ := termux.ListCameras()
+//	for _, cam := range cameras {
+//	    fmt.Println(cam.ID, cam.Facing, cam.JPEGSizes[0])
+//	}
+func ListCameras() ([]CameraInfo, error) {
+	return Default().ListCameras()
+}
+
+// ListCamerasContext is like ListCameras but takes a context. The command
+// is killed if ctx is done before it completes.
+func ListCamerasContext(ctx context.Context) ([]CameraInfo, error) {
+	return Default().ListCamerasContext(ctx)
+}
+
+// ListCameras returns the device's cameras.
+func (c *Client) ListCameras() ([]CameraInfo, error) {
+	return c.ListCamerasContext(context.Background())
+}
+
+// ListCamerasContext is like ListCameras but takes a context.
+func (c *Client) ListCamerasContext(ctx context.Context) ([]CameraInfo, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return []CameraInfo{}, nil
+	}
+
+	output, err := c.run(ctx, "termux-camera-info")
+	if err != nil {
+		return nil, err
+	}
+
+	var cameras []CameraInfo
+	if err := json.Unmarshal(output, &cameras); err != nil {
+		return nil, err
+	}
+	return cameras, nil
+}
+
+// TakePhoto takes a JPEG photo with the camera cameraID ("" for the
+// default, usually the back camera "0") and saves it to path. A relative
+// path is resolved against the working directory.
+//
+// No preview is shown; the photo is taken as soon as the camera is ready.
+//
+// If not running on Termux, this is a no-op and no file is written.
+//
+// Example:
+//
+//	path := filepath.Join(entryDir, "photo.jpg")
+//	if err := termux.TakePhoto(path, ""); err != nil {
+//	    return err
+//	}
+//	entry.Attachments = append(entry.Attachments, path)
+func TakePhoto(path, cameraID string) error {
+	return Default().TakePhoto(path, cameraID)
+}
+
+// TakePhotoContext is like TakePhoto but takes a context. The command is
+// killed if ctx is done before it completes.
+func TakePhotoContext(ctx context.Context, path, cameraID string) error {
+	return Default().TakePhotoContext(ctx, path, cameraID)
+}
+
+// TakePhoto takes a photo with the camera cameraID and saves it to path.
+func (c *Client) TakePhoto(path, cameraID string) error {
+	return c.TakePhotoContext(context.Background(), path, cameraID)
+}
+
+// TakePhotoContext is like TakePhoto but takes a context.
+func (c *Client) TakePhotoContext(ctx context.Context, path, cameraID string) error {
+	if path == "" {
+		return errors.New("termux: TakePhoto needs an output path")
+	}
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	var args []string
+	if cameraID != "" {
+		args = append(args, "-c", cameraID)
+	}
+	args = append(args, abs)
+
+	output, err := c.run(ctx, "termux-camera-photo", args...)
+	if err != nil {
+		return err
+	}
+	// Success is silent; anything printed is an error from the camera
+	if msg := strings.TrimSpace(string(output)); msg != "" {
+		return fmt.Errorf("termux: termux-camera-photo: %s", msg)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package termux
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// apiAppPackage is the Android package name of the Termux:API app.
+const apiAppPackage = "com.termux.api"
+
+// capabilityProbeTimeout bounds each command run while probing, so a slow
+// package manager or a missing app cannot hang Capabilities.
+const capabilityProbeTimeout = 5 * time.Second
+
+// Capabilities reports which Termux:API features are available on the
+// device. A feature is available when the Termux:API app is installed and
+// every command it relies on is too. The termux-api package installs all
+// the commands whether or not the app is, so they alone prove nothing.
+//
+// Capabilities only probes for the presence of commands. Android
+// permissions (location, microphone, ...) are requested on first use and
+// cannot be checked ahead of time; a denied permission surfaces as
+// ErrPermissionDenied from the call itself.
+type Capabilities struct {
+	Termux     bool   // Running on Termux (same as IsTermux)
+	APIApp     bool   // Termux:API app installed
+	APIVersion string // Termux:API app version code, "" if unknown
+
+	Vibrate      bool // termux-vibrate
+	Toast        bool // termux-toast
+	Notification bool // termux-notification, -remove, -list
+	Battery      bool // termux-battery-status
+	Location     bool // termux-location
+	Sensor       bool // termux-sensor
+	WiFi         bool // termux-wifi-connectioninfo, -scaninfo, -enable
+	Clipboard    bool // termux-clipboard-get, -set
+	WakeLock     bool // termux-wake-lock, -unlock
+	SpeechToText bool // termux-speech-to-text
+	TextToSpeech bool // termux-tts-speak
+	Dialog       bool // termux-dialog
+	Camera       bool // termux-camera-info, -photo
+	Microphone   bool // termux-microphone-record
+	MediaPlayer  bool // termux-media-player
+	Share        bool // termux-share
+	Open         bool // termux-open, termux-open-url
+	Storage      bool // termux-storage-get
+	Volume       bool // termux-volume
+	Brightness   bool // termux-brightness
+	Torch        bool // termux-torch
+	AudioInfo    bool // termux-audio-info
+	SMS          bool // termux-sms-list, -send
+	Contacts     bool // termux-contact-list
+	CallLog      bool // termux-call-log
+	Telephony    bool // termux-telephony-deviceinfo, -cellinfo
+	Fingerprint  bool // termux-fingerprint
+	Keystore     bool // termux-keystore
+	JobScheduler bool // termux-job-scheduler
+
+	// Commands maps every probed command to whether it was found, even
+	// when the app is missing.
+	Commands map[string]bool
+}
+
+// capabilityProbes lists the commands behind each Capabilities field.
+var capabilityProbes = []struct {
+	commands []string
+	field    func(*Capabilities) *bool
+}{
+	{[]string{"termux-vibrate"}, func(c *Capabilities) *bool { return &c.Vibrate }},
+	{[]string{"termux-toast"}, func(c *Capabilities) *bool { return &c.Toast }},
+	{[]string{"termux-notification", "termux-notification-remove", "termux-notification-list"}, func(c *Capabilities) *bool { return &c.Notification }},
+	{[]string{"termux-battery-status"}, func(c *Capabilities) *bool { return &c.Battery }},
+	{[]string{"termux-location"}, func(c *Capabilities) *bool { return &c.Location }},
+	{[]string{"termux-sensor"}, func(c *Capabilities) *bool { return &c.Sensor }},
+	{[]string{"termux-wifi-connectioninfo", "termux-wifi-scaninfo", "termux-wifi-enable"}, func(c *Capabilities) *bool { return &c.WiFi }},
+	{[]string{"termux-clipboard-get", "termux-clipboard-set"}, func(c *Capabilities) *bool { return &c.Clipboard }},
+	{[]string{"termux-wake-lock", "termux-wake-unlock"}, func(c *Capabilities) *bool { return &c.WakeLock }},
+	{[]string{"termux-speech-to-text"}, func(c *Capabilities) *bool { return &c.SpeechToText }},
+	{[]string{"termux-tts-speak"}, func(c *Capabilities) *bool { return &c.TextToSpeech }},
+	{[]string{"termux-dialog"}, func(c *Capabilities) *bool { return &c.Dialog }},
+	{[]string{"termux-camera-info", "termux-camera-photo"}, func(c *Capabilities) *bool { return &c.Camera }},
+	{[]string{"termux-microphone-record"}, func(c *Capabilities) *bool { return &c.Microphone }},
+	{[]string{"termux-media-player"}, func(c *Capabilities) *bool { return &c.MediaPlayer }},
+	{[]string{"termux-share"}, func(c *Capabilities) *bool { return &c.Share }},
+	{[]string{"termux-open", "termux-open-url"}, func(c *Capabilities) *bool { return &c.Open }},
+	{[]string{"termux-storage-get"}, func(c *Capabilities) *bool { return &c.Storage }},
+	{[]string{"termux-volume"}, func(c *Capabilities) *bool { return &c.Volume }},
+	{[]string{"termux-brightness"}, func(c *Capabilities) *bool { return &c.Brightness }},
+	{[]string{"termux-torch"}, func(c *Capabilities) *bool { return &c.Torch }},
+	{[]string{"termux-audio-info"}, func(c *Capabilities) *bool { return &c.AudioInfo }},
+	{[]string{"termux-sms-list", "termux-sms-send"}, func(c *Capabilities) *bool { return &c.SMS }},
+	{[]string{"termux-contact-list"}, func(c *Capabilities) *bool { return &c.Contacts }},
+	{[]string{"termux-call-log"}, func(c *Capabilities) *bool { return &c.CallLog }},
+	{[]string{"termux-telephony-deviceinfo", "termux-telephony-cellinfo"}, func(c *Capabilities) *bool { return &c.Telephony }},
+	{[]string{"termux-fingerprint"}, func(c *Capabilities) *bool { return &c.Fingerprint }},
+	{[]string{"termux-keystore"}, func(c *Capabilities) *bool { return &c.Keystore }},
+	{[]string{"termux-job-scheduler"}, func(c *Capabilities) *bool { return &c.JobScheduler }},
+}
+
+// GetCapabilities probes the default client's Termux:API commands.
+// See Client.Capabilities.
+//
+// Example:
+//
+//	caps := termux.GetCapabilities()
+//	m.showVoiceButton = caps.SpeechToText
+//	m.showGPSPanel = caps.Location
+func GetCapabilities() Capabilities {
+	return Default().Capabilities()
+}
+
+// RefreshCapabilities discards the default client's cached capabilities
+// and probes again. See Client.RefreshCapabilities.
+func RefreshCapabilities() Capabilities {
+	return Default().RefreshCapabilities()
+}
+
+// Capabilities probes each Termux:API command the package wraps and
+// reports which features are available. The result is cached; call
+// RefreshCapabilities after the user installs packages or apps.
+func (c *Client) Capabilities() Capabilities {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+
+	if c.caps == nil {
+		caps := c.probeCapabilities()
+		c.caps = &caps
+	}
+	return c.caps.clone()
+}
+
+// RefreshCapabilities discards cached capabilities and probes again.
+func (c *Client) RefreshCapabilities() Capabilities {
+	c.capsMu.Lock()
+	c.caps = nil
+	c.capsMu.Unlock()
+	return c.Capabilities()
+}
+
+// probeCapabilities looks for the Termux:API app and every wrapped command.
+func (c *Client) probeCapabilities() Capabilities {
+	caps := Capabilities{
+		Termux:   c.IsTermux(),
+		Commands: make(map[string]bool),
+	}
+	if caps.Termux {
+		caps.APIApp, caps.APIVersion = c.probeAPIApp()
+	}
+
+	for _, probe := range capabilityProbes {
+		available := true
+		for _, name := range probe.commands {
+			found, seen := caps.Commands[name]
+			if !seen {
+				found = c.commandAvailable(name)
+				caps.Commands[name] = found
+			}
+			available = available && found
+		}
+		*probe.field(&caps) = caps.APIApp && available
+	}
+	return caps
+}
+
+// probeAPIApp reports whether the Termux:API app is installed and its
+// version code. It asks the package manager first. When that fails, or
+// lists nothing because Android 11+ package visibility hides the app, it
+// makes a cheap API call instead, which only succeeds when the app
+// responds; the version is then unknown.
+func (c *Client) probeAPIApp() (installed bool, version string) {
+	ctx, cancel := context.WithTimeout(context.Background(), capabilityProbeTimeout)
+	defer cancel()
+
+	output, err := c.run(ctx, "pm", "list", "packages", "--show-versioncode", apiAppPackage)
+	if err == nil {
+		// One line per match, such as "package:com.termux.api versionCode:51";
+		// the filter is a substring match, so compare the name exactly.
+		for _, line := range strings.Split(string(output), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 || fields[0] != "package:"+apiAppPackage {
+				continue
+			}
+			for _, field := range fields[1:] {
+				if code, ok := strings.CutPrefix(field, "versionCode:"); ok {
+					version = code
+				}
+			}
+			return true, version
+		}
+	}
+
+	if !c.commandAvailable("termux-battery-status") {
+		return false, ""
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), capabilityProbeTimeout)
+	defer cancel()
+	_, err = c.run(ctx, "termux-battery-status")
+	return err == nil, ""
+}
+
+// clone returns a copy whose Commands map can be modified safely.
+func (c Capabilities) clone() Capabilities {
+	commands := make(map[string]bool, len(c.Commands))
+	for name, found := range c.Commands {
+		commands[name] = found
+	}
+	c.Commands = commands
+	return c
+}
@@ -0,0 +1,110 @@
+package termux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestCapabilities(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("pm", "package:com.termux.api.extra versionCode:3\npackage:com.termux.api versionCode:51\n")
+	fake.Uninstall("termux-speech-to-text", "termux-wifi-enable")
+	client := termux.NewClient(fake)
+
+	caps := client.Capabilities()
+	if !caps.Termux || !caps.APIApp || !caps.Location || !caps.Dialog {
+		t.Errorf("expected Termux, the app, Location and Dialog to be available: %+v", caps)
+	}
+	if caps.SpeechToText {
+		t.Error("SpeechToText = true with termux-speech-to-text uninstalled")
+	}
+	if caps.WiFi {
+		t.Error("WiFi = true with termux-wifi-enable uninstalled")
+	}
+	if caps.Commands["termux-wifi-scaninfo"] != true {
+		t.Error("termux-wifi-scaninfo should still be reported as installed")
+	}
+	if caps.APIVersion != "51" {
+		t.Errorf("APIVersion = %q, want 51", caps.APIVersion)
+	}
+	if call := fake.CallsTo("pm")[0]; call.Args[len(call.Args)-1] != "com.termux.api" {
+		t.Errorf("pm args = %v, want a query for com.termux.api", call.Args)
+	}
+
+	// Cached: no further probing
+	client.Capabilities()
+	if n := len(fake.CallsTo("pm")); n != 1 {
+		t.Errorf("pm ran %d times, want 1 (cached)", n)
+	}
+
+	client.RefreshCapabilities()
+	if n := len(fake.CallsTo("pm")); n != 2 {
+		t.Errorf("pm ran %d times after refresh, want 2", n)
+	}
+}
+
+func TestCapabilitiesAppMissing(t *testing.T) {
+	// The termux-api package is installed, the app is not
+	fake := termuxtest.NewRunner()
+	fake.Respond("pm", "package:com.termux.api.extra versionCode:3\n")
+	fake.Exit("termux-battery-status", 1, "")
+	client := termux.NewClient(fake)
+
+	caps := client.Capabilities()
+	if !caps.Termux || caps.APIApp || caps.APIVersion != "" {
+		t.Errorf("Capabilities = %+v, want Termux without the app", caps)
+	}
+	if caps.Vibrate || caps.Location || caps.Clipboard {
+		t.Errorf("features reported without the app: %+v", caps)
+	}
+	if !caps.Commands["termux-location"] {
+		t.Error("termux-location should still be reported as installed")
+	}
+}
+
+func TestCapabilitiesAppFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		pm      termuxtest.Response
+		battery termuxtest.Response
+		wantApp bool
+	}{
+		{"pm fails, app answers", termuxtest.Response{Err: errors.New("pm: not found")}, termuxtest.Response{Output: "{}"}, true},
+		{"pm fails, app missing", termuxtest.Response{Err: errors.New("pm: not found")}, termuxtest.Response{ExitCode: 1}, false},
+		// Android 11+ package visibility: pm succeeds but lists nothing
+		{"pm hides the app", termuxtest.Response{}, termuxtest.Response{Output: "{}"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := termuxtest.NewRunner()
+			fake.RespondWith("pm", tt.pm)
+			fake.RespondWith("termux-battery-status", tt.battery)
+			client := termux.NewClient(fake)
+
+			caps := client.Capabilities()
+			if caps.APIApp != tt.wantApp || caps.Vibrate != tt.wantApp {
+				t.Errorf("APIApp = %v, Vibrate = %v, want %v", caps.APIApp, caps.Vibrate, tt.wantApp)
+			}
+			if caps.APIVersion != "" {
+				t.Errorf("APIVersion = %q, want unknown", caps.APIVersion)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesNotTermux(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate")
+	client := termux.NewClient(fake)
+
+	caps := client.Capabilities()
+	if caps.Termux || caps.Vibrate || caps.APIVersion != "" {
+		t.Errorf("Capabilities = %+v, want no Termux", caps)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("ran %v, want no commands outside Termux", fake.Calls())
+	}
+}
@@ -0,0 +1,203 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Client runs Termux API commands through a Runner.
+//
+// The package-level functions (Vibrate, Notify, GetBatteryStatus, ...)
+// delegate to a default Client backed by ExecRunner. Create your own Client
+// with NewClient to inject a fake Runner in tests, or install it as the
+// default with SetDefault so existing call sites pick it up unchanged.
+type Client struct {
+	runner Runner
+	strict atomic.Bool
+
+	// isTermux caches the result of Termux environment detection
+	isTermux     bool
+	isTermuxOnce sync.Once
+
+	// backend delivers notifications, toasts, clipboard and speech
+	backend   Backend
+	backendMu sync.Mutex
+
+	// caps caches the result of capability probing
+	caps   *Capabilities
+	capsMu sync.Mutex
+
+	// wakeLocks counts the holds on the device wake lock
+	wakeLocks     *WakeLocks
+	wakeLocksOnce sync.Once
+
+	// device remembers settings Android cannot report back (device.go)
+	device deviceState
+}
+
+// NewClient creates a Client that executes commands with the given Runner.
+// A nil runner is replaced with ExecRunner.
+//
+// Example:
+//
+//	fake := termuxtest.NewRunner()
+//	fake.Respond("termux-battery-status", `{"percentage": 15, "status": "DISCHARGING"}`)
+//	client := termux.NewClient(fake)
+//	low, _ := client.IsBatteryLow(20) // true
+func NewClient(runner Runner) *Client {
+	if runner == nil {
+		runner = ExecRunner{}
+	}
+	return &Client{runner: runner}
+}
+
+// Runner returns the Runner used by the client.
+func (c *Client) Runner() Runner {
+	return c.runner
+}
+
+// defaultClient is used by all package-level functions.
+var defaultClient atomic.Pointer[Client]
+
+func init() {
+	defaultClient.Store(NewClient(ExecRunner{}))
+}
+
+// Default returns the Client used by the package-level functions.
+func Default() *Client {
+	return defaultClient.Load()
+}
+
+// SetDefault replaces the Client used by the package-level functions and
+// returns the previous one, so tests can restore it when they finish.
+// A nil client resets the default to one backed by ExecRunner.
+//
+// Example:
+//
+//	prev := termux.SetDefault(termux.NewClient(fake))
+//	defer termux.SetDefault(prev)
+func SetDefault(c *Client) *Client {
+	if c == nil {
+		c = NewClient(ExecRunner{})
+	}
+	return defaultClient.Swap(c)
+}
+
+// IsTermux reports whether the client's runner can see the Termux API
+// commands. The result is cached for the lifetime of the client.
+func (c *Client) IsTermux() bool {
+	c.isTermuxOnce.Do(func() {
+		c.isTermux = c.commandAvailable("termux-vibrate")
+	})
+	return c.isTermux
+}
+
+// SetStrict enables or disables strict mode on the default client.
+// See Client.SetStrict.
+func SetStrict(strict bool) {
+	Default().SetStrict(strict)
+}
+
+// SetStrict enables or disables strict mode.
+//
+// By default, functions called outside Termux degrade gracefully: actions
+// are no-ops and queries return placeholder values (100% battery, a
+// Location at (0, 0), "0" from CounterDialog). In strict mode they return
+// ErrNotTermux instead, so callers can tell real data from fallbacks.
+//
+// Example:
+//
+//	termux.SetStrict(true)
+//	battery, err := termux.GetBatteryStatus()
+//	if errors.Is(err, termux.ErrNotTermux) {
+//	    // Hide the battery widget on desktop
+//	}
+func (c *Client) SetStrict(strict bool) {
+	c.strict.Store(strict)
+}
+
+// Strict reports whether the client is in strict mode.
+func (c *Client) Strict() bool {
+	return c.strict.Load()
+}
+
+// fallbackErr is returned by functions called outside Termux:
+// ErrNotTermux in strict mode, nil otherwise.
+func (c *Client) fallbackErr() error {
+	if c.Strict() {
+		return ErrNotTermux
+	}
+	return nil
+}
+
+// commandAvailable checks if a command is available to the client's runner.
+func (c *Client) commandAvailable(name string) bool {
+	_, err := c.runner.LookPath(name)
+	return err == nil
+}
+
+// run executes a command through the client's runner and returns its stdout.
+// If ctx is done before the command completes, run reports a *TimeoutError;
+// other failures are reported as a classified *CommandError.
+func (c *Client) run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return c.runInput(ctx, nil, name, args...)
+}
+
+// runInput is like run but pipes stdin to the command.
+func (c *Client) runInput(ctx context.Context, stdin []byte, name string, args ...string) ([]byte, error) {
+	return c.runCommand(ctx, Command{Name: name, Args: args, Stdin: stdin})
+}
+
+// runStream is like run but streams the command's output to stdout as it
+// is produced. It blocks until the command exits.
+func (c *Client) runStream(ctx context.Context, stdout io.Writer, name string, args ...string) error {
+	_, err := c.runCommand(ctx, Command{Name: name, Args: args, Stdout: stdout})
+	return err
+}
+
+// streamJSON runs a long-running command that prints a sequence of JSON
+// values, calling next to decode each one until the output ends, next
+// fails, or ctx is done. If next fails first, the process is stopped.
+// killed reports whether the process had to be killed rather than exiting
+// on its own; err is the first decoding or command failure.
+func (c *Client) streamJSON(ctx context.Context, next func(*json.Decoder) error, name string, args ...string) (killed bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	runErr := make(chan error, 1)
+	go func() {
+		err := c.runStream(ctx, pw, name, args...)
+		pw.CloseWithError(err) // a failing command fails the decoder too
+		runErr <- err
+	}()
+
+	dec := json.NewDecoder(pr)
+	for err == nil {
+		err = next(dec)
+	}
+	if err == io.EOF {
+		err = nil
+	}
+
+	pr.CloseWithError(io.ErrClosedPipe)
+	cancel()
+	return errors.Is(<-runErr, ErrTimeout), err
+}
+
+// runCommand executes cmd through the client's runner, reporting failures
+// as described for run.
+func (c *Client) runCommand(ctx context.Context, cmd Command) ([]byte, error) {
+	output, err := c.runner.Run(ctx, cmd)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, &TimeoutError{Command: cmd.Name, Err: ctxErr}
+		}
+		return nil, classifyError(cmd.Name, err)
+	}
+	return output, nil
+}
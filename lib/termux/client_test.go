@@ -0,0 +1,171 @@
+package termux_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestClientBatteryStatus(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-battery-status", `{"health":"GOOD","percentage":15,"plugged":"UNPLUGGED","status":"DISCHARGING","temperature":31.5}`)
+	client := termux.NewClient(fake)
+
+	low, err := client.IsBatteryLow(20)
+	if err != nil {
+		t.Fatalf("IsBatteryLow: %v", err)
+	}
+	if !low {
+		t.Error("IsBatteryLow(20) = false, want true at 15%")
+	}
+
+	status, err := client.GetBatteryStatus()
+	if err != nil {
+		t.Fatalf("GetBatteryStatus: %v", err)
+	}
+	if status.Temperature != 31.5 || status.Status != "DISCHARGING" {
+		t.Errorf("GetBatteryStatus = %+v", status)
+	}
+
+	if got := len(fake.CallsTo("termux-battery-status")); got != 2 {
+		t.Errorf("termux-battery-status ran %d times, want 2", got)
+	}
+}
+
+func TestClientNotifyArgs(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	err := client.Notify("Build", "Done",
+		termux.WithID("build-1"),
+		termux.WithOngoing(),
+		termux.WithButton("Open", []string{"termux-open-url", "https://example.com/?q=a b"}),
+	)
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	calls := fake.CallsTo("termux-notification")
+	if len(calls) != 1 {
+		t.Fatalf("termux-notification ran %d times, want 1", len(calls))
+	}
+	want := []string{
+		"--title", "Build",
+		"--content", "Done",
+		"--id", "build-1",
+		"--ongoing",
+		"--button1", "Open",
+		"--button1-action", "termux-open-url 'https://example.com/?q=a b'",
+	}
+	if !reflect.DeepEqual(calls[0].Args, want) {
+		t.Errorf("args = %q, want %q", calls[0].Args, want)
+	}
+}
+
+func TestClientDialogHelpers(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-dialog", `{"code":-1,"text":"yes"}`)
+	fake.Respond("termux-dialog", `{"code":-1,"text":"Reject"}`)
+	client := termux.NewClient(fake)
+
+	confirmed, err := client.ConfirmDialog("Approve?", "Merge PR #1?")
+	if err != nil || !confirmed {
+		t.Fatalf("ConfirmDialog = %v, %v; want true, nil", confirmed, err)
+	}
+
+	choice, err := client.RadioDialog("Action", "Approve,Reject")
+	if err != nil || choice != "Reject" {
+		t.Fatalf("RadioDialog = %q, %v; want Reject, nil", choice, err)
+	}
+
+	calls := fake.CallsTo("termux-dialog")
+	if calls[1].Args[0] != "radio" {
+		t.Errorf("second dialog type = %q, want radio", calls[1].Args[0])
+	}
+}
+
+func TestClientRunnerError(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	boom := errors.New("boom")
+	fake.Fail("termux-wifi-scaninfo", boom)
+	client := termux.NewClient(fake)
+
+	if _, err := client.ScanWiFi(); !errors.Is(err, boom) {
+		t.Errorf("ScanWiFi error = %v, want %v", err, boom)
+	}
+}
+
+func TestClientNotTermux(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate")
+	client := termux.NewClient(fake)
+
+	if client.IsTermux() {
+		t.Fatal("IsTermux = true with termux-vibrate uninstalled")
+	}
+	if err := client.Vibrate(50); err != nil {
+		t.Errorf("Vibrate: %v", err)
+	}
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("ran %v outside Termux, want no commands", calls)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	prev := termux.SetDefault(termux.NewClient(fake))
+	defer termux.SetDefault(prev)
+
+	if err := termux.Toast("hello"); err != nil {
+		t.Fatalf("Toast: %v", err)
+	}
+
+	calls := fake.CallsTo("termux-toast")
+	if len(calls) != 1 || calls[0].Args[0] != "hello" {
+		t.Errorf("termux-toast calls = %v", calls)
+	}
+}
+
+func TestDialogContextTimeout(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.RespondWith("termux-dialog", termuxtest.Response{
+		Output: `{"code":-1,"text":"too late"}`,
+		Delay:  time.Hour,
+	})
+	client := termux.NewClient(fake)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.TextDialogContext(ctx, "Name", "")
+	if !errors.Is(err, termux.ErrTimeout) {
+		t.Fatalf("TextDialogContext error = %v, want ErrTimeout", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("TextDialogContext error = %v, want it to wrap DeadlineExceeded", err)
+	}
+}
+
+func TestExecRunnerKillsOnCancel(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep not available")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := termux.ExecRunner{}.Run(ctx, termux.Command{Name: "sh", Args: []string{"-c", "sleep 30; echo done"}})
+	if err == nil {
+		t.Fatal("Run returned nil error after cancellation")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Run took %v after cancellation, want it killed promptly", elapsed)
+	}
+}
@@ -0,0 +1,179 @@
+package termux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Selection identifies an X11/Wayland selection buffer.
+type Selection int
+
+const (
+	SelectionClipboard Selection = iota // The regular copy/paste clipboard
+	SelectionPrimary                    // The middle-click primary selection
+)
+
+// SelectionBackend is implemented by backends that can address the
+// primary selection as well as the clipboard. LinuxBackend implements it;
+// Android has no primary selection.
+type SelectionBackend interface {
+	ClipboardSetSelection(ctx context.Context, sel Selection, text string) error
+	ClipboardGetSelection(ctx context.Context, sel Selection) (string, error)
+}
+
+// DefaultClipboardHistory is the history size used by NewClipboardManager
+// when given a non-positive size.
+const DefaultClipboardHistory = 20
+
+// ClipboardManager copies and pastes through a Client's backend while
+// keeping a history of copied text. It can also copy through the terminal
+// with OSC 52, which reaches the clipboard of the machine running the
+// terminal emulator even over SSH.
+//
+// Example:
+//
+//	clip := termux.NewClipboardManager(termux.Default(), 50)
+//	clip.Copy(ctx, pr.URL)
+//
+//	// Later, show a picker over the history
+//	for i, text := range clip.History() {
+//	    fmt.Println(i, text)
+//	}
+//	clip.Restore(ctx, 2)
+type ClipboardManager struct {
+	c *Client
+
+	// OSC52 copies through the terminal with OSC 52 instead of the
+	// backend. NewClipboardManager enables it for SSH sessions.
+	OSC52 bool
+
+	// Terminal receives OSC 52 sequences. When nil, each copy opens the
+	// controlling terminal (/dev/tty).
+	// Without one the copy is a no-op (ErrNotTermux in strict mode).
+	Terminal io.Writer
+
+	mu      sync.Mutex
+	history []string
+	size    int
+}
+
+// NewClipboardManager creates a ClipboardManager for c that remembers up
+// to historySize copies.
+func NewClipboardManager(c *Client, historySize int) *ClipboardManager {
+	if historySize <= 0 {
+		historySize = DefaultClipboardHistory
+	}
+	return &ClipboardManager{
+		c:     c,
+		OSC52: os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "",
+		size:  historySize,
+	}
+}
+
+// Copy copies text to the clipboard and records it in the history.
+func (m *ClipboardManager) Copy(ctx context.Context, text string) error {
+	return m.CopyTo(ctx, SelectionClipboard, text)
+}
+
+// CopyTo copies text to the given selection and records it in the
+// history. Copying to the primary selection fails with
+// errors.ErrUnsupported unless SupportsPrimary reports true.
+func (m *ClipboardManager) CopyTo(ctx context.Context, sel Selection, text string) error {
+	var err error
+	switch {
+	case m.OSC52:
+		err = writeTerminal(m.c, m.Terminal, sel, text)
+	case sel == SelectionClipboard:
+		err = m.c.Backend().ClipboardSet(ctx, text)
+	default:
+		sb, ok := m.c.Backend().(SelectionBackend)
+		if !ok {
+			return errPrimaryUnsupported
+		}
+		err = sb.ClipboardSetSelection(ctx, sel, text)
+	}
+	if err != nil {
+		return err
+	}
+
+	m.remember(text)
+	return nil
+}
+
+// Paste reads the clipboard.
+func (m *ClipboardManager) Paste(ctx context.Context) (string, error) {
+	return m.PasteFrom(ctx, SelectionClipboard)
+}
+
+// PasteFrom reads the given selection. Reading always goes through the
+// backend, because terminals don't reliably answer OSC 52 queries.
+func (m *ClipboardManager) PasteFrom(ctx context.Context, sel Selection) (string, error) {
+	if sel == SelectionClipboard {
+		return m.c.Backend().ClipboardGet(ctx)
+	}
+	sb, ok := m.c.Backend().(SelectionBackend)
+	if !ok {
+		return "", errPrimaryUnsupported
+	}
+	return sb.ClipboardGetSelection(ctx, sel)
+}
+
+// SupportsPrimary reports whether the primary selection is available.
+// OSC 52 can always target it, though not every terminal honors that.
+func (m *ClipboardManager) SupportsPrimary() bool {
+	if m.OSC52 {
+		return true
+	}
+	_, ok := m.c.Backend().(SelectionBackend)
+	return ok
+}
+
+// History returns copied text, most recent first.
+func (m *ClipboardManager) History() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.history...)
+}
+
+// Restore copies the history entry at index i (0 is the most recent) back
+// to the clipboard, moving it to the front of the history.
+func (m *ClipboardManager) Restore(ctx context.Context, i int) error {
+	m.mu.Lock()
+	if i < 0 || i >= len(m.history) {
+		m.mu.Unlock()
+		return errors.New("termux: clipboard history index out of range")
+	}
+	text := m.history[i]
+	m.mu.Unlock()
+
+	return m.Copy(ctx, text)
+}
+
+// ClearHistory forgets all copied text.
+func (m *ClipboardManager) ClearHistory() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.history = nil
+}
+
+// remember moves text to the front of the history, dropping duplicates
+// and the oldest entries beyond the size limit.
+func (m *ClipboardManager) remember(text string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	history := []string{text}
+	for _, old := range m.history {
+		if old != text && len(history) < m.size {
+			history = append(history, old)
+		}
+	}
+	m.history = history
+}
+
+// errPrimaryUnsupported is returned when the backend has no primary selection.
+var errPrimaryUnsupported = fmt.Errorf("termux: primary selection: %w", errors.ErrUnsupported)
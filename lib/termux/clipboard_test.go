@@ -0,0 +1,112 @@
+package termux_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestClipboardSetPipesStdin(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	// A heredoc delimiter on its own line must not end the text early
+	text := "line one\nCLIPBOARD_EOF\n$(rm -rf ~)"
+	if err := client.ClipboardSet(text); err != nil {
+		t.Fatalf("ClipboardSet: %v", err)
+	}
+
+	calls := fake.CallsTo("termux-clipboard-set")
+	if len(calls) != 1 {
+		t.Fatalf("termux-clipboard-set ran %d times, want 1", len(calls))
+	}
+	if len(calls[0].Args) != 0 || string(calls[0].Stdin) != text {
+		t.Errorf("call = %v with stdin %q, want no args and stdin %q", calls[0], calls[0].Stdin, text)
+	}
+	if len(fake.CallsTo("bash")) != 0 {
+		t.Error("ClipboardSet should not run a shell")
+	}
+}
+
+func TestClipboardManagerHistory(t *testing.T) {
+	t.Setenv("SSH_TTY", "")
+	t.Setenv("SSH_CONNECTION", "")
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+	clip := termux.NewClipboardManager(client, 3)
+	ctx := context.Background()
+
+	for _, text := range []string{"a", "b", "c", "a", "d"} {
+		if err := clip.Copy(ctx, text); err != nil {
+			t.Fatalf("Copy(%q): %v", text, err)
+		}
+	}
+	if got, want := clip.History(), []string{"d", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("History = %v, want %v", got, want)
+	}
+
+	if err := clip.Restore(ctx, 2); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	calls := fake.CallsTo("termux-clipboard-set")
+	if last := calls[len(calls)-1]; string(last.Stdin) != "c" {
+		t.Errorf("Restore copied %q, want c", last.Stdin)
+	}
+	if got := clip.History()[0]; got != "c" {
+		t.Errorf("History[0] = %q after Restore, want c", got)
+	}
+}
+
+func TestClipboardManagerPrimary(t *testing.T) {
+	t.Setenv("SSH_TTY", "")
+	t.Setenv("SSH_CONNECTION", "")
+	ctx := context.Background()
+
+	// Termux has no primary selection
+	termuxClip := termux.NewClipboardManager(termux.NewClient(termuxtest.NewRunner()), 0)
+	if termuxClip.SupportsPrimary() {
+		t.Error("SupportsPrimary = true on Termux")
+	}
+	if err := termuxClip.CopyTo(ctx, termux.SelectionPrimary, "x"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("CopyTo(primary) error = %v, want ErrUnsupported", err)
+	}
+
+	// X11 desktop with xclip
+	fake, client, _ := newDesktop(t)
+	clip := termux.NewClipboardManager(client, 0)
+	if err := clip.CopyTo(ctx, termux.SelectionPrimary, "middle click"); err != nil {
+		t.Fatalf("CopyTo(primary): %v", err)
+	}
+	calls := fake.CallsTo("xclip")
+	if len(calls) != 1 || calls[0].Args[1] != "primary" {
+		t.Errorf("xclip calls = %v, want -selection primary", calls)
+	}
+}
+
+func TestClipboardManagerOSC52(t *testing.T) {
+	t.Setenv("SSH_TTY", "/dev/pts/3")
+	t.Setenv("TMUX", "")
+	fake := termuxtest.NewRunner()
+	clip := termux.NewClipboardManager(termux.NewClient(fake), 0)
+	var term bytes.Buffer
+	clip.Terminal = &term
+
+	if !clip.OSC52 {
+		t.Fatal("OSC52 should default to on in SSH sessions")
+	}
+	if err := clip.Copy(context.Background(), "remote"); err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	if !strings.HasPrefix(term.String(), "\x1b]52;c;") {
+		t.Errorf("terminal got %q, want an OSC 52 sequence", term.String())
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("ran %v, want the copy to go through the terminal only", fake.Calls())
+	}
+}
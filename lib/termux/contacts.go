@@ -0,0 +1,61 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Contact is an entry of the device's address book, as reported by
+// termux-contact-list. A contact with several numbers is listed once per
+// number.
+type Contact struct {
+	Name   string `json:"name"`
+	Number string `json:"number"`
+}
+
+// ListContacts returns the device's contacts. Termux:API needs the
+// contacts permission; the first call asks for it.
+//
+// If not running on Termux, returns an empty list.
+//
+// Example:
+//
+//	contacts, _ := termux.ListContacts()
+//	for _, c := range contacts {
+//	    fmt.Printf("%-24s %s\n", c.Name, c.Number)
+//	}
+func ListContacts() ([]Contact, error) {
+	return Default().ListContacts()
+}
+
+// ListContactsContext is like ListContacts but takes a context. The
+// command is killed if ctx is done before it completes.
+func ListContactsContext(ctx context.Context) ([]Contact, error) {
+	return Default().ListContactsContext(ctx)
+}
+
+// ListContacts returns the device's contacts.
+func (c *Client) ListContacts() ([]Contact, error) {
+	return c.ListContactsContext(context.Background())
+}
+
+// ListContactsContext is like ListContacts but takes a context.
+func (c *Client) ListContactsContext(ctx context.Context) ([]Contact, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return []Contact{}, nil
+	}
+
+	output, err := c.run(ctx, "termux-contact-list")
+	if err != nil {
+		return nil, err
+	}
+
+	var contacts []Contact
+	if err := json.Unmarshal(output, &contacts); err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
@@ -0,0 +1,215 @@
+package termux
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LinuxBackend gives Linux hosts real feedback for the calls that are
+// no-ops elsewhere, with or without a desktop session:
+//
+//   - Notify uses notify-send (which talks to the desktop over D-Bus)
+//     when there is a desktop session or session bus
+//   - Toast pushes to an in-terminal ToastQueue
+//   - ClipboardSet/Get use wl-clipboard, xclip or xsel, and ClipboardSet
+//     falls back to an OSC 52 escape sequence (works over SSH)
+//   - Speak uses espeak-ng, espeak or spd-say
+//   - Open and OpenURL use xdg-open
+//
+// Tools are looked up through the client's Runner, so a missing tool, or
+// a session a tool needs, is treated like running outside Termux: a
+// no-op, or ErrNotTermux in strict mode. Over SSH that leaves Toast,
+// Speak and the OSC 52 clipboard working.
+type LinuxBackend struct {
+	c *Client
+
+	// Toasts receives toasts for the TUI to draw.
+	Toasts *ToastQueue
+
+	// Terminal receives OSC 52 clipboard sequences. When nil, each write
+	// opens the controlling terminal (/dev/tty).
+	// Without one, such as under cron, the copy is a no-op
+	// (ErrNotTermux in strict mode).
+	Terminal io.Writer
+
+	// AppName is shown as the notification source. Defaults to the
+	// executable name.
+	AppName string
+}
+
+// NewLinuxBackend creates a LinuxBackend that runs tools through c.
+func NewLinuxBackend(c *Client) *LinuxBackend {
+	return &LinuxBackend{
+		c:       c,
+		Toasts:  NewToastQueue(),
+		AppName: filepath.Base(os.Args[0]),
+	}
+}
+
+// Name returns "linux".
+func (b *LinuxBackend) Name() string { return "linux" }
+
+// Notify shows a desktop notification with notify-send.
+//
+// Priority maps to urgency ("high"/"max" are critical, "low"/"min" are
+// low) and the notification ID becomes a stack tag, so posting again with
+// the same WithID replaces the notification on servers that support it.
+// Buttons, vibration and ongoing state have no desktop equivalent and
+// are ignored. Without a display or session bus, such as over SSH, there
+// is nothing to show the notification and Notify does nothing.
+func (b *LinuxBackend) Notify(ctx context.Context, title, content string, opts ...NotifyOption) error {
+	if !desktopSession() || !b.c.commandAvailable("notify-send") {
+		return b.c.fallbackErr()
+	}
+
+	n := &Notification{Title: title, Content: content}
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	args := []string{"--app-name=" + b.AppName}
+	switch n.Priority {
+	case "high", "max":
+		args = append(args, "--urgency=critical")
+	case "low", "min":
+		args = append(args, "--urgency=low")
+	}
+	if n.Icon != "" {
+		args = append(args, "--icon="+n.Icon)
+	}
+	if n.ID != "" {
+		args = append(args,
+			"--hint=string:x-dunst-stack-tag:"+n.ID,
+			"--hint=string:x-canonical-private-synchronous:"+n.ID,
+		)
+	}
+	args = append(args, "--", n.Title, n.Content)
+
+	_, err := b.c.run(ctx, "notify-send", args...)
+	return err
+}
+
+// desktopSession reports whether the process can reach a desktop: an X11
+// or Wayland display, or a D-Bus session bus.
+func desktopSession() bool {
+	for _, name := range []string{"DISPLAY", "WAYLAND_DISPLAY", "DBUS_SESSION_BUS_ADDRESS"} {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Toast queues the message on Toasts for in-terminal display.
+func (b *LinuxBackend) Toast(ctx context.Context, message string, length ToastLength) error {
+	b.Toasts.Push(message, toastDuration(length))
+	return nil
+}
+
+// ClipboardSet copies text with wl-copy, xclip or xsel, whichever matches
+// the session, and otherwise with an OSC 52 escape sequence.
+func (b *LinuxBackend) ClipboardSet(ctx context.Context, text string) error {
+	return b.ClipboardSetSelection(ctx, SelectionClipboard, text)
+}
+
+// ClipboardGet reads text with wl-paste, xclip or xsel. Terminals don't
+// reliably answer OSC 52 queries, so without one of those tools this is a
+// no-op (ErrNotTermux in strict mode).
+func (b *LinuxBackend) ClipboardGet(ctx context.Context) (string, error) {
+	return b.ClipboardGetSelection(ctx, SelectionClipboard)
+}
+
+// ClipboardSetSelection copies text to the clipboard or the primary
+// selection. See ClipboardSet.
+func (b *LinuxBackend) ClipboardSetSelection(ctx context.Context, sel Selection, text string) error {
+	if tool, args := b.clipboardTool(sel, true); tool != "" {
+		// The tools fork to keep serving the selection, so their output
+		// must not be captured
+		_, err := b.c.runCommand(ctx, Command{Name: tool, Args: args, Stdin: []byte(text), DiscardOutput: true})
+		return err
+	}
+	return writeTerminal(b.c, b.Terminal, sel, text)
+}
+
+// ClipboardGetSelection reads the clipboard or the primary selection.
+// See ClipboardGet.
+func (b *LinuxBackend) ClipboardGetSelection(ctx context.Context, sel Selection) (string, error) {
+	tool, args := b.clipboardTool(sel, false)
+	if tool == "" {
+		return "", b.c.fallbackErr()
+	}
+
+	output, err := b.c.run(ctx, tool, args...)
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// clipboardTool picks the clipboard command for the current session.
+func (b *LinuxBackend) clipboardTool(sel Selection, write bool) (string, []string) {
+	wayland := os.Getenv("WAYLAND_DISPLAY") != ""
+	x11 := os.Getenv("DISPLAY") != ""
+	primary := sel == SelectionPrimary
+
+	switch {
+	case wayland && write && b.c.commandAvailable("wl-copy"):
+		if primary {
+			return "wl-copy", []string{"--primary"}
+		}
+		return "wl-copy", nil
+	case wayland && !write && b.c.commandAvailable("wl-paste"):
+		if primary {
+			return "wl-paste", []string{"--no-newline", "--primary"}
+		}
+		return "wl-paste", []string{"--no-newline"}
+	case x11 && b.c.commandAvailable("xclip"):
+		target := "clipboard"
+		if primary {
+			target = "primary"
+		}
+		if write {
+			return "xclip", []string{"-selection", target, "-in"}
+		}
+		return "xclip", []string{"-selection", target, "-out"}
+	case x11 && b.c.commandAvailable("xsel"):
+		target := "--clipboard"
+		if primary {
+			target = "--primary"
+		}
+		if write {
+			return "xsel", []string{target, "--input"}
+		}
+		return "xsel", []string{target, "--output"}
+	}
+	return "", nil
+}
+
+// Open opens a file or URL in the desktop's default application with
+// xdg-open.
+func (b *LinuxBackend) Open(ctx context.Context, target string) error {
+	if !b.c.commandAvailable("xdg-open") {
+		return b.c.fallbackErr()
+	}
+	_, err := b.c.run(ctx, "xdg-open", target)
+	return err
+}
+
+// Speak speaks text with espeak-ng, espeak or spd-say. The text is piped
+// through stdin where possible so it can never be mistaken for a flag.
+func (b *LinuxBackend) Speak(ctx context.Context, text string) error {
+	for _, tool := range []string{"espeak-ng", "espeak"} {
+		if b.c.commandAvailable(tool) {
+			_, err := b.c.runInput(ctx, []byte(text), tool, "--stdin")
+			return err
+		}
+	}
+	if b.c.commandAvailable("spd-say") {
+		_, err := b.c.run(ctx, "spd-say", "--wait", "--", strings.TrimSpace(text))
+		return err
+	}
+	return b.c.fallbackErr()
+}
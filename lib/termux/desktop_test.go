@@ -0,0 +1,193 @@
+package termux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+// newDesktop returns a fake desktop without Termux and an X11 session.
+func newDesktop(t *testing.T) (*termuxtest.Runner, *termux.Client, *termux.LinuxBackend) {
+	t.Helper()
+	t.Setenv(termux.BackendEnv, "")
+	t.Setenv("DISPLAY", ":0")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("TMUX", "")
+
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate")
+	client := termux.NewClient(fake)
+
+	backend, ok := client.Backend().(*termux.LinuxBackend)
+	if !ok {
+		t.Fatalf("Backend = %T, want *termux.LinuxBackend", client.Backend())
+	}
+	backend.AppName = "demo"
+	return fake, client, backend
+}
+
+func TestLinuxBackendNotify(t *testing.T) {
+	fake, client, _ := newDesktop(t)
+
+	err := client.Notify("Build", "-done-", termux.WithID("build"), termux.WithPriority("high"))
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	calls := fake.CallsTo("notify-send")
+	if len(calls) != 1 {
+		t.Fatalf("notify-send ran %d times, want 1", len(calls))
+	}
+	want := []string{
+		"--app-name=demo",
+		"--urgency=critical",
+		"--hint=string:x-dunst-stack-tag:build",
+		"--hint=string:x-canonical-private-synchronous:build",
+		"--", "Build", "-done-",
+	}
+	if !reflect.DeepEqual(calls[0].Args, want) {
+		t.Errorf("args = %q, want %q", calls[0].Args, want)
+	}
+}
+
+func TestLinuxBackendClipboard(t *testing.T) {
+	fake, client, _ := newDesktop(t)
+	fake.Respond("xclip", "from clipboard")
+
+	if err := client.ClipboardSet("copied"); err != nil {
+		t.Fatalf("ClipboardSet: %v", err)
+	}
+	text, err := client.ClipboardGet()
+	if err != nil || text != "from clipboard" {
+		t.Fatalf("ClipboardGet = %q, %v", text, err)
+	}
+
+	calls := fake.CallsTo("xclip")
+	if string(calls[0].Stdin) != "copied" {
+		t.Errorf("xclip stdin = %q, want copied", calls[0].Stdin)
+	}
+	if calls[1].Args[len(calls[1].Args)-1] != "-out" {
+		t.Errorf("second xclip call = %v, want a read", calls[1])
+	}
+}
+
+func TestLinuxBackendClipboardForkingTool(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	// Like the real xclip, the stub leaves a process serving the
+	// selection that inherits its stdout and stderr
+	dir := t.TempDir()
+	stub := "#!/bin/sh\ncat >/dev/null\nsleep 3 &\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(dir, "xclip"), []byte(stub), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(termux.BackendEnv, "linux")
+	t.Setenv("DISPLAY", ":0")
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	client := termux.NewClient(termux.ExecRunner{})
+	start := time.Now()
+	if err := client.ClipboardSet("copied"); err != nil {
+		t.Fatalf("ClipboardSet: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ClipboardSet took %v, want it to return when xclip exits", elapsed)
+	}
+}
+
+func TestLinuxBackendOSC52Fallback(t *testing.T) {
+	fake, client, backend := newDesktop(t)
+	fake.Uninstall("xclip", "xsel")
+	var term bytes.Buffer
+	backend.Terminal = &term
+
+	if err := client.ClipboardSet("over ssh"); err != nil {
+		t.Fatalf("ClipboardSet: %v", err)
+	}
+
+	want := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("over ssh")) + "\a"
+	if term.String() != want {
+		t.Errorf("terminal got %q, want %q", term.String(), want)
+	}
+}
+
+func TestLinuxBackendToastAndSpeak(t *testing.T) {
+	fake, client, backend := newDesktop(t)
+
+	if err := client.ToastLong("Saved"); err != nil {
+		t.Fatalf("ToastLong: %v", err)
+	}
+	active := backend.Toasts.Active(time.Now())
+	if len(active) != 1 || active[0].Message != "Saved" {
+		t.Errorf("active toasts = %v", active)
+	}
+
+	if err := client.SpeakContext(context.Background(), "-hello"); err != nil {
+		t.Fatalf("Speak: %v", err)
+	}
+	calls := fake.CallsTo("espeak-ng")
+	if len(calls) != 1 || string(calls[0].Stdin) != "-hello" {
+		t.Errorf("espeak-ng calls = %v", calls)
+	}
+}
+
+func TestLinuxBackendHeadless(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the Linux backend is only detected on Linux")
+	}
+	// An SSH session: no display, no session bus
+	t.Setenv("TMUX", "")
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate")
+	client := termux.NewClient(fake)
+
+	backend, ok := client.Backend().(*termux.LinuxBackend)
+	if !ok {
+		t.Fatalf("Backend = %T, want *termux.LinuxBackend", client.Backend())
+	}
+	var term bytes.Buffer
+	backend.Terminal = &term
+
+	if err := client.ClipboardSet("over ssh"); err != nil {
+		t.Fatalf("ClipboardSet: %v", err)
+	}
+	if term.Len() == 0 || len(fake.CallsTo("xclip")) != 0 {
+		t.Errorf("ClipboardSet used xclip %d times and wrote %q, want OSC 52 only", len(fake.CallsTo("xclip")), term.String())
+	}
+	if err := client.SpeakContext(context.Background(), "hello"); err != nil {
+		t.Fatalf("Speak: %v", err)
+	}
+	if len(fake.CallsTo("espeak-ng")) != 1 {
+		t.Error("Speak did not use espeak-ng")
+	}
+	if err := client.Notify("Build", "done"); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if n := len(fake.CallsTo("notify-send")); n != 0 {
+		t.Errorf("notify-send ran %d times without a desktop session", n)
+	}
+}
+
+func TestBackendOverride(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "linux")
+	client := termux.NewClient(termuxtest.NewRunner())
+	if name := client.Backend().Name(); name != "linux" {
+		t.Errorf("Backend().Name() = %q with %s=linux", name, termux.BackendEnv)
+	}
+
+	client.SetBackend(termux.TermuxBackend(client))
+	if name := client.Backend().Name(); name != "termux" {
+		t.Errorf("Backend().Name() = %q after SetBackend", name)
+	}
+}
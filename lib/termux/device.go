@@ -0,0 +1,514 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// VolumeStream is an Android audio stream with its own volume level.
+type VolumeStream string
+
+const (
+	VolumeAlarm        VolumeStream = "alarm"
+	VolumeMusic        VolumeStream = "music" // Media playback, including termux-media-player and TTS
+	VolumeNotification VolumeStream = "notification"
+	VolumeRing         VolumeStream = "ring"
+	VolumeSystem       VolumeStream = "system"
+	VolumeCall         VolumeStream = "call" // In-call voice
+)
+
+// StreamVolume is the volume of one audio stream.
+type StreamVolume struct {
+	Stream VolumeStream `json:"stream"`
+	Volume int          `json:"volume"`
+	Max    int          `json:"max_volume"` // Highest level the stream accepts
+}
+
+// Fraction returns the volume as a fraction of the maximum, from 0 to 1.
+func (v StreamVolume) Fraction() float64 {
+	if v.Max <= 0 {
+		return 0
+	}
+	return float64(v.Volume) / float64(v.Max)
+}
+
+// Brightness is the screen brightness setting.
+type Brightness struct {
+	Level int  // 0 (darkest) to 255 (brightest)
+	Auto  bool // Adaptive brightness is on; Level is its current base
+	Known bool // False if the setting could not be read
+}
+
+// MaxBrightness is the highest level accepted by SetBrightness.
+const MaxBrightness = 255
+
+// TorchState reports whether the flashlight is on. Android offers no way
+// to read it, so it is the state last set through the client; Known is
+// false until SetTorch has been called.
+type TorchState struct {
+	On    bool
+	Known bool
+}
+
+// deviceState remembers the device settings last set through a client,
+// for the ones Android cannot report back.
+type deviceState struct {
+	mu         sync.Mutex
+	brightness Brightness
+	torch      TorchState
+}
+
+// lastBrightness returns the brightness last set.
+func (d *deviceState) lastBrightness() Brightness {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.brightness
+}
+
+// setBrightness records a brightness that was just set.
+func (d *deviceState) setBrightness(b Brightness) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.brightness = b
+}
+
+// lastTorch returns the flashlight state last set.
+func (d *deviceState) lastTorch() TorchState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.torch
+}
+
+// setTorch records a flashlight state that was just set.
+func (d *deviceState) setTorch(t TorchState) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.torch = t
+}
+
+// AudioInfo describes the device's audio output, as reported by
+// termux-audio-info.
+type AudioInfo struct {
+	SampleRate          int  `json:"PROPERTY_OUTPUT_SAMPLE_RATE,string"`       // Native output sample rate in Hz
+	FramesPerBuffer     int  `json:"PROPERTY_OUTPUT_FRAMES_PER_BUFFER,string"` // Native output buffer size
+	TrackSampleRate     int  `json:"AUDIOTRACK_SAMPLE_RATE"`
+	TrackBufferFrames   int  `json:"AUDIOTRACK_BUFFER_SIZE_IN_FRAMES"`
+	LowLatencyRate      int  `json:"AUDIOTRACK_SAMPLE_RATE_LOW_LATENCY"`
+	LowLatencyFrames    int  `json:"AUDIOTRACK_BUFFER_SIZE_IN_FRAMES_LOW_LATENCY"`
+	PowerSavingRate     int  `json:"AUDIOTRACK_SAMPLE_RATE_POWER_SAVING"`
+	PowerSavingFrames   int  `json:"AUDIOTRACK_BUFFER_SIZE_IN_FRAMES_POWER_SAVING"`
+	BluetoothA2DP       bool `json:"BLUETOOTH_A2DP_IS_ON"`      // Audio is routed to a Bluetooth device
+	WiredHeadsetPlugged bool `json:"WIREDHEADSET_IS_CONNECTED"` // A wired headset is plugged in
+}
+
+// GetVolumes returns the volume of every audio stream.
+//
+// If not running on Termux, or termux-volume is not installed, returns an
+// empty list.
+//
+// Example:
+//
+//	volumes, _ := termux.GetVolumes()
+//	for _, v := range volumes {
+//	    fmt.Printf("%s: %d/%d\n", v.Stream, v.Volume, v.Max)
+//	}
+func GetVolumes() ([]StreamVolume, error) {
+	return Default().GetVolumes()
+}
+
+// GetVolumesContext is like GetVolumes but takes a context. The command is
+// killed if ctx is done before it completes.
+func GetVolumesContext(ctx context.Context) ([]StreamVolume, error) {
+	return Default().GetVolumesContext(ctx)
+}
+
+// GetVolumes returns the volume of every audio stream.
+func (c *Client) GetVolumes() ([]StreamVolume, error) {
+	return c.GetVolumesContext(context.Background())
+}
+
+// GetVolumesContext is like GetVolumes but takes a context.
+func (c *Client) GetVolumesContext(ctx context.Context) ([]StreamVolume, error) {
+	if ok, err := c.deviceCommand("termux-volume"); !ok {
+		if err != nil {
+			return nil, err
+		}
+		return []StreamVolume{}, nil
+	}
+
+	output, err := c.run(ctx, "termux-volume")
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []StreamVolume
+	if err := json.Unmarshal(output, &volumes); err != nil {
+		return nil, err
+	}
+	return volumes, nil
+}
+
+// GetVolume returns the volume of one audio stream.
+//
+// If not running on Termux, or termux-volume is not installed, returns a
+// zero StreamVolume for the stream.
+func GetVolume(stream VolumeStream) (StreamVolume, error) {
+	return Default().GetVolume(stream)
+}
+
+// GetVolumeContext is like GetVolume but takes a context. The command is
+// killed if ctx is done before it completes.
+func GetVolumeContext(ctx context.Context, stream VolumeStream) (StreamVolume, error) {
+	return Default().GetVolumeContext(ctx, stream)
+}
+
+// GetVolume returns the volume of one audio stream.
+func (c *Client) GetVolume(stream VolumeStream) (StreamVolume, error) {
+	return c.GetVolumeContext(context.Background(), stream)
+}
+
+// GetVolumeContext is like GetVolume but takes a context.
+func (c *Client) GetVolumeContext(ctx context.Context, stream VolumeStream) (StreamVolume, error) {
+	volumes, err := c.GetVolumesContext(ctx)
+	if err != nil {
+		return StreamVolume{}, err
+	}
+	if len(volumes) == 0 {
+		return StreamVolume{Stream: stream}, nil
+	}
+	for _, v := range volumes {
+		if v.Stream == stream {
+			return v, nil
+		}
+	}
+	return StreamVolume{}, fmt.Errorf("termux: unknown volume stream %q", stream)
+}
+
+// SetVolume sets the volume of an audio stream. Levels above the stream's
+// maximum (see GetVolume) are capped by Android.
+//
+// If not running on Termux, or termux-volume is not installed, this is a
+// no-op.
+//
+// Example:
+//
+//	music, _ := termux.GetVolume(termux.VolumeMusic)
+//	termux.SetVolume(termux.VolumeMusic, music.Max/2)
+func SetVolume(stream VolumeStream, level int) error {
+	return Default().SetVolume(stream, level)
+}
+
+// SetVolumeContext is like SetVolume but takes a context. The command is
+// killed if ctx is done before it completes.
+func SetVolumeContext(ctx context.Context, stream VolumeStream, level int) error {
+	return Default().SetVolumeContext(ctx, stream, level)
+}
+
+// SetVolume sets the volume of an audio stream.
+func (c *Client) SetVolume(stream VolumeStream, level int) error {
+	return c.SetVolumeContext(context.Background(), stream, level)
+}
+
+// SetVolumeContext is like SetVolume but takes a context.
+func (c *Client) SetVolumeContext(ctx context.Context, stream VolumeStream, level int) error {
+	if stream == "" {
+		return errors.New("termux: SetVolume needs a stream")
+	}
+	if level < 0 {
+		return fmt.Errorf("termux: volume %d is negative", level)
+	}
+	if ok, err := c.deviceCommand("termux-volume"); !ok {
+		return err
+	}
+
+	output, err := c.run(ctx, "termux-volume", string(stream), formatInt(level))
+	if err != nil {
+		return err
+	}
+	return deviceOutputErr("termux-volume", output)
+}
+
+// SetBrightness sets the screen brightness to level, from 0 (darkest) to
+// MaxBrightness, and turns adaptive brightness off.
+//
+// Termux:API needs the "modify system settings" permission; without it
+// ErrPermissionDenied is returned.
+//
+// If not running on Termux, or termux-brightness is not installed, this is
+// a no-op.
+//
+// Example:
+//
+//	// Dim the kiosk display after a minute without input
+//	termux.SetBrightness(20)
+func SetBrightness(level int) error {
+	return Default().SetBrightness(level)
+}
+
+// SetBrightnessContext is like SetBrightness but takes a context. The
+// command is killed if ctx is done before it completes.
+func SetBrightnessContext(ctx context.Context, level int) error {
+	return Default().SetBrightnessContext(ctx, level)
+}
+
+// SetBrightness sets the screen brightness to level.
+func (c *Client) SetBrightness(level int) error {
+	return c.SetBrightnessContext(context.Background(), level)
+}
+
+// SetBrightnessContext is like SetBrightness but takes a context.
+func (c *Client) SetBrightnessContext(ctx context.Context, level int) error {
+	if level < 0 || level > MaxBrightness {
+		return fmt.Errorf("termux: brightness %d out of range 0-%d", level, MaxBrightness)
+	}
+	return c.setBrightness(ctx, Brightness{Level: level, Known: true}, formatInt(level))
+}
+
+// SetAutoBrightness turns adaptive brightness on.
+//
+// If not running on Termux, or termux-brightness is not installed, this is
+// a no-op.
+func SetAutoBrightness() error {
+	return Default().SetAutoBrightness()
+}
+
+// SetAutoBrightnessContext is like SetAutoBrightness but takes a context.
+// The command is killed if ctx is done before it completes.
+func SetAutoBrightnessContext(ctx context.Context) error {
+	return Default().SetAutoBrightnessContext(ctx)
+}
+
+// SetAutoBrightness turns adaptive brightness on.
+func (c *Client) SetAutoBrightness() error {
+	return c.SetAutoBrightnessContext(context.Background())
+}
+
+// SetAutoBrightnessContext is like SetAutoBrightness but takes a context.
+func (c *Client) SetAutoBrightnessContext(ctx context.Context) error {
+	level := c.device.lastBrightness().Level
+	return c.setBrightness(ctx, Brightness{Level: level, Auto: true, Known: true}, "auto")
+}
+
+// setBrightness runs termux-brightness with arg and remembers state.
+func (c *Client) setBrightness(ctx context.Context, state Brightness, arg string) error {
+	if ok, err := c.deviceCommand("termux-brightness"); !ok {
+		return err
+	}
+
+	output, err := c.run(ctx, "termux-brightness", arg)
+	if err != nil {
+		return err
+	}
+	if err := deviceOutputErr("termux-brightness", output); err != nil {
+		return err
+	}
+
+	c.device.setBrightness(state)
+	return nil
+}
+
+// GetBrightness returns the screen brightness setting.
+//
+// Termux:API has no command to read it, so it is read with Android's
+// settings tool where that is allowed, and otherwise is the value last
+// set through the client. If neither is available Known is false.
+//
+// If not running on Termux, returns a Brightness with Known false.
+func GetBrightness() (Brightness, error) {
+	return Default().GetBrightness()
+}
+
+// GetBrightnessContext is like GetBrightness but takes a context. The
+// command is killed if ctx is done before it completes.
+func GetBrightnessContext(ctx context.Context) (Brightness, error) {
+	return Default().GetBrightnessContext(ctx)
+}
+
+// GetBrightness returns the screen brightness setting.
+func (c *Client) GetBrightness() (Brightness, error) {
+	return c.GetBrightnessContext(context.Background())
+}
+
+// GetBrightnessContext is like GetBrightness but takes a context.
+func (c *Client) GetBrightnessContext(ctx context.Context) (Brightness, error) {
+	if !c.IsTermux() {
+		return Brightness{}, c.fallbackErr()
+	}
+
+	if state, ok := c.readBrightnessSetting(ctx); ok {
+		return state, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return Brightness{}, &TimeoutError{Command: "settings", Err: err}
+	}
+
+	return c.device.lastBrightness(), nil
+}
+
+// readBrightnessSetting reads the brightness with "settings get", which
+// some Android versions refuse to apps.
+func (c *Client) readBrightnessSetting(ctx context.Context) (Brightness, bool) {
+	if !c.commandAvailable("settings") {
+		return Brightness{}, false
+	}
+	get := func(key string) (int, bool) {
+		output, err := c.run(ctx, "settings", "get", "system", key)
+		if err != nil {
+			return 0, false
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(string(output)))
+		return n, err == nil
+	}
+
+	mode, ok := get("screen_brightness_mode")
+	if !ok {
+		return Brightness{}, false
+	}
+	level, ok := get("screen_brightness")
+	if !ok {
+		return Brightness{}, false
+	}
+	return Brightness{Level: min(max(level, 0), MaxBrightness), Auto: mode == 1, Known: true}, true
+}
+
+// SetTorch turns the camera flashlight on or off.
+//
+// If not running on Termux, or termux-torch is not installed, this is a
+// no-op.
+//
+// Example:
+//
+//	termux.SetTorch(true)
+//	defer termux.SetTorch(false)
+func SetTorch(on bool) error {
+	return Default().SetTorch(on)
+}
+
+// SetTorchContext is like SetTorch but takes a context. The command is
+// killed if ctx is done before it completes.
+func SetTorchContext(ctx context.Context, on bool) error {
+	return Default().SetTorchContext(ctx, on)
+}
+
+// SetTorch turns the camera flashlight on or off.
+func (c *Client) SetTorch(on bool) error {
+	return c.SetTorchContext(context.Background(), on)
+}
+
+// SetTorchContext is like SetTorch but takes a context.
+func (c *Client) SetTorchContext(ctx context.Context, on bool) error {
+	if ok, err := c.deviceCommand("termux-torch"); !ok {
+		return err
+	}
+
+	arg := "off"
+	if on {
+		arg = "on"
+	}
+	output, err := c.run(ctx, "termux-torch", arg)
+	if err != nil {
+		return err
+	}
+	if err := deviceOutputErr("termux-torch", output); err != nil {
+		return err
+	}
+
+	c.device.setTorch(TorchState{On: on, Known: true})
+	return nil
+}
+
+// GetTorch returns the flashlight state last set through the client.
+//
+// If not running on Termux, returns a TorchState with Known false.
+func GetTorch() (TorchState, error) {
+	return Default().GetTorch()
+}
+
+// GetTorch returns the flashlight state last set through the client.
+func (c *Client) GetTorch() (TorchState, error) {
+	if !c.IsTermux() {
+		return TorchState{}, c.fallbackErr()
+	}
+
+	return c.device.lastTorch(), nil
+}
+
+// GetAudioInfo returns the device's audio output properties.
+//
+// If not running on Termux, or termux-audio-info is not installed, returns
+// a zero AudioInfo.
+//
+// Example:
+//
+//	info, _ := termux.GetAudioInfo()
+//	if info.WiredHeadsetPlugged || info.BluetoothA2DP {
+//	    // Safe to play the alert at full volume
+//	}
+func GetAudioInfo() (AudioInfo, error) {
+	return Default().GetAudioInfo()
+}
+
+// GetAudioInfoContext is like GetAudioInfo but takes a context. The
+// command is killed if ctx is done before it completes.
+func GetAudioInfoContext(ctx context.Context) (AudioInfo, error) {
+	return Default().GetAudioInfoContext(ctx)
+}
+
+// GetAudioInfo returns the device's audio output properties.
+func (c *Client) GetAudioInfo() (AudioInfo, error) {
+	return c.GetAudioInfoContext(context.Background())
+}
+
+// GetAudioInfoContext is like GetAudioInfo but takes a context.
+func (c *Client) GetAudioInfoContext(ctx context.Context) (AudioInfo, error) {
+	if ok, err := c.deviceCommand("termux-audio-info"); !ok {
+		return AudioInfo{}, err
+	}
+
+	output, err := c.run(ctx, "termux-audio-info")
+	if err != nil {
+		return AudioInfo{}, err
+	}
+
+	var info AudioInfo
+	if err := json.Unmarshal(output, &info); err != nil {
+		return AudioInfo{}, err
+	}
+	return info, nil
+}
+
+// deviceCommand reports whether the device control command name can be
+// run. It cannot outside Termux, or with an older Termux:API that lacks
+// the command; err is then what the caller should return: nil, or in
+// strict mode ErrNotTermux or ErrAPINotInstalled.
+func (c *Client) deviceCommand(name string) (ok bool, err error) {
+	if !c.IsTermux() {
+		return false, c.fallbackErr()
+	}
+	if !c.commandAvailable(name) {
+		if c.Strict() {
+			return false, &CommandError{Command: name, ExitCode: -1, Kind: ErrAPINotInstalled, Err: exec.ErrNotFound}
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// deviceOutputErr turns the message a device control command prints on
+// failure into an error. Success is silent.
+func deviceOutputErr(name string, output []byte) error {
+	msg := strings.TrimSpace(string(output))
+	switch {
+	case msg == "":
+		return nil
+	case strings.Contains(strings.ToLower(msg), "permission"):
+		return fmt.Errorf("%w: %s: %s", ErrPermissionDenied, name, msg)
+	}
+	return fmt.Errorf("termux: %s: %s", name, msg)
+}
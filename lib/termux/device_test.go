@@ -0,0 +1,134 @@
+package termux_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestVolume(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	volumes := `[
+		{"stream": "call", "volume": 3, "max_volume": 5},
+		{"stream": "music", "volume": 6, "max_volume": 15}
+	]`
+	fake.Respond("termux-volume", volumes)
+	fake.Respond("termux-volume", volumes)
+	fake.Respond("termux-volume", "")
+	client := termux.NewClient(fake)
+
+	music, err := client.GetVolume(termux.VolumeMusic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if music.Volume != 6 || music.Max != 15 || music.Fraction() != 0.4 {
+		t.Errorf("music = %+v", music)
+	}
+	if _, err := client.GetVolume("radio"); err == nil {
+		t.Error("GetVolume of an unknown stream succeeded")
+	}
+
+	if err := client.SetVolume(termux.VolumeMusic, 10); err != nil {
+		t.Fatal(err)
+	}
+	calls := fake.CallsTo("termux-volume")
+	if got := strings.Join(calls[len(calls)-1].Args, " "); got != "music 10" {
+		t.Errorf("set args = %q", got)
+	}
+	if err := client.SetVolume(termux.VolumeMusic, -1); err == nil {
+		t.Error("negative volume accepted")
+	}
+}
+
+func TestBrightness(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("settings")
+	client := termux.NewClient(fake)
+
+	if b, _ := client.GetBrightness(); b.Known {
+		t.Errorf("brightness before any set = %+v, want unknown", b)
+	}
+	if err := client.SetBrightness(40); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := client.GetBrightness(); b != (termux.Brightness{Level: 40, Known: true}) {
+		t.Errorf("brightness = %+v, want the level just set", b)
+	}
+	if err := client.SetAutoBrightness(); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := client.GetBrightness(); !b.Auto {
+		t.Errorf("brightness = %+v, want auto", b)
+	}
+	if got := fake.CallsTo("termux-brightness"); got[0].Args[0] != "40" || got[1].Args[0] != "auto" {
+		t.Errorf("termux-brightness calls = %v", got)
+	}
+	if err := client.SetBrightness(300); err == nil {
+		t.Error("brightness 300 accepted")
+	}
+
+	fake.Respond("termux-brightness", "ERROR: Termux:API has no permission to write settings")
+	if err := client.SetBrightness(10); !errors.Is(err, termux.ErrPermissionDenied) {
+		t.Errorf("err = %v, want ErrPermissionDenied", err)
+	}
+}
+
+func TestBrightnessFromSettings(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("settings", "0\n")
+	fake.Respond("settings", "128\n")
+
+	b, err := termux.NewClient(fake).GetBrightness()
+	if err != nil || b != (termux.Brightness{Level: 128, Known: true}) {
+		t.Errorf("GetBrightness = %+v, %v", b, err)
+	}
+}
+
+func TestTorchAndAudioInfo(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-audio-info", `{
+		"PROPERTY_OUTPUT_SAMPLE_RATE": "48000", "PROPERTY_OUTPUT_FRAMES_PER_BUFFER": "192",
+		"AUDIOTRACK_SAMPLE_RATE": 48000, "BLUETOOTH_A2DP_IS_ON": false, "WIREDHEADSET_IS_CONNECTED": true
+	}`)
+	client := termux.NewClient(fake)
+
+	if err := client.SetTorch(true); err != nil {
+		t.Fatal(err)
+	}
+	if state, _ := client.GetTorch(); state != (termux.TorchState{On: true, Known: true}) {
+		t.Errorf("torch = %+v", state)
+	}
+
+	info, err := client.GetAudioInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.SampleRate != 48000 || info.FramesPerBuffer != 192 || !info.WiredHeadsetPlugged {
+		t.Errorf("audio info = %+v", info)
+	}
+}
+
+func TestDeviceControlsMissingCommand(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-torch")
+	fake.Uninstall("termux-volume")
+	client := termux.NewClient(fake)
+
+	if err := client.SetTorch(true); err != nil {
+		t.Errorf("SetTorch without termux-torch = %v, want a no-op", err)
+	}
+	if volumes, err := client.GetVolumes(); err != nil || len(volumes) != 0 {
+		t.Errorf("GetVolumes without termux-volume = %v, %v", volumes, err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("ran %v", fake.Calls())
+	}
+
+	client.SetStrict(true)
+	if err := client.SetTorch(true); !errors.Is(err, termux.ErrAPINotInstalled) {
+		t.Errorf("strict SetTorch = %v, want ErrAPINotInstalled", err)
+	}
+}
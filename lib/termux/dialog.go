@@ -0,0 +1,378 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DialogWidget is a termux-dialog widget type.
+type DialogWidget string
+
+const (
+	DialogConfirm  DialogWidget = "confirm"  // Yes/no question
+	DialogCheckbox DialogWidget = "checkbox" // Pick several Values
+	DialogCounter  DialogWidget = "counter"  // Pick a number between Min and Max
+	DialogDate     DialogWidget = "date"     // Pick a date
+	DialogRadio    DialogWidget = "radio"    // Pick one of Values with radio buttons
+	DialogSheet    DialogWidget = "sheet"    // Pick one of Values from a bottom sheet
+	DialogSpinner  DialogWidget = "spinner"  // Pick one of Values from a dropdown
+	DialogSpeech   DialogWidget = "speech"   // Dictate text
+	DialogText     DialogWidget = "text"     // Enter text
+	DialogTime     DialogWidget = "time"     // Pick a time of day
+)
+
+// ErrInvalidDialog is returned by ShowDialog (and DialogSpec.Validate) for
+// a spec termux-dialog would reject.
+var ErrInvalidDialog = errors.New("termux: invalid dialog")
+
+// ErrPasswordMismatch is returned by ConfirmPasswordDialog when the two
+// entries differ.
+var ErrPasswordMismatch = errors.New("termux: passwords do not match")
+
+// dialogDateLayout is the date format requested from the date widget (as
+// a Java SimpleDateFormat pattern) and its Go equivalent.
+const (
+	dialogDatePattern = "yyyy-MM-dd"
+	dialogDateLayout  = "2006-01-02"
+	dialogTimeLayout  = "15:04"
+)
+
+// DialogSpec describes a termux-dialog invocation. Each field applies only
+// to the widgets noted on it.
+//
+// Example:
+//
+//	result, err := termux.ShowDialog(termux.DialogSpec{
+//	    Widget: termux.DialogCheckbox,
+//	    Title:  "Deploy to",
+//	    Values: []string{"staging", "prod, eu", "prod, us"}, // commas are escaped
+//	})
+//	if errors.Is(err, termux.ErrCancelled) {
+//	    return
+//	}
+//	fmt.Println(result.Indices) // e.g. [1 2]
+type DialogSpec struct {
+	Widget DialogWidget // Empty means DialogText
+	Title  string
+	Hint   string   // Prompt text (confirm, text, speech)
+	Values []string // Choices (checkbox, radio, sheet, spinner); may contain commas
+
+	Multiline bool // Multi-line input (text); not with Numeric
+	Numeric   bool // Number keyboard (text); not with Multiline
+	Password  bool // Hidden input (text)
+
+	Min, Max int // Range (counter); both zero uses the widget default
+	Start    int // Initial value (counter); clamped to the range
+}
+
+// Validate reports the first problem that would make termux-dialog reject
+// the spec. The returned error wraps ErrInvalidDialog.
+func (s *DialogSpec) Validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidDialog, fmt.Sprintf(format, args...))
+	}
+
+	widget := s.widget()
+	switch widget {
+	case DialogCheckbox, DialogRadio, DialogSheet, DialogSpinner:
+		if len(s.Values) == 0 {
+			return invalid("%s dialog needs values", widget)
+		}
+	case DialogConfirm, DialogCounter, DialogDate, DialogSpeech, DialogText, DialogTime:
+		if len(s.Values) > 0 {
+			return invalid("%s dialog does not take values", widget)
+		}
+	default:
+		return invalid("unknown widget %q", widget)
+	}
+
+	if widget != DialogText && (s.Multiline || s.Numeric || s.Password) {
+		return invalid("multiline, numeric and password apply only to text dialogs")
+	}
+	if s.Multiline && s.Numeric {
+		return invalid("text dialog cannot be both multiline and numeric")
+	}
+	if s.Hint != "" && widget != DialogConfirm && widget != DialogText && widget != DialogSpeech {
+		return invalid("%s dialog does not take a hint", widget)
+	}
+	if s.Min > s.Max {
+		return invalid("counter range %d..%d is empty", s.Min, s.Max)
+	}
+	return nil
+}
+
+// widget returns the spec's widget, defaulting to text.
+func (s *DialogSpec) widget() DialogWidget {
+	if s.Widget == "" {
+		return DialogText
+	}
+	return s.Widget
+}
+
+// args builds the termux-dialog arguments for s.
+func (s *DialogSpec) args() []string {
+	args := []string{string(s.widget())}
+	if s.Title != "" {
+		args = append(args, "-t", s.Title)
+	}
+	if s.Hint != "" {
+		args = append(args, "-i", s.Hint)
+	}
+	if len(s.Values) > 0 {
+		args = append(args, "-v", joinDialogValues(s.Values))
+	}
+	if s.Multiline {
+		args = append(args, "-m")
+	}
+	if s.Numeric {
+		args = append(args, "-n")
+	}
+	if s.Password {
+		args = append(args, "-p")
+	}
+
+	switch s.widget() {
+	case DialogCounter:
+		if s.Min != 0 || s.Max != 0 {
+			start := min(max(s.Start, s.Min), s.Max)
+			args = append(args, "-r", formatInt(s.Min)+","+formatInt(s.Max)+","+formatInt(start))
+		}
+	case DialogDate:
+		args = append(args, "-d", dialogDatePattern)
+	}
+	return args
+}
+
+// joinDialogValues joins values for -v, escaping backslashes and commas
+// inside values as termux-dialog expects ("\\" and "\,").
+func joinDialogValues(values []string) string {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		v = strings.ReplaceAll(v, `\`, `\\`)
+		escaped[i] = strings.ReplaceAll(v, ",", `\,`)
+	}
+	return strings.Join(escaped, ",")
+}
+
+// SplitDialogValues splits the comma-separated value list taken by
+// RadioDialog, CheckboxDialog and SpinnerDialog into DialogSpec.Values.
+// A comma or backslash inside a value is escaped with a backslash ("\,"
+// and "\\"), as termux-dialog expects. Entries are otherwise kept as
+// given, empty ones included, so Indices in the result refer to the same
+// items whichever dialog shows them.
+func SplitDialogValues(values string) []string {
+	var (
+		split []string
+		cur   strings.Builder
+	)
+	for i := 0; i < len(values); i++ {
+		switch ch := values[i]; {
+		case ch == '\\' && i+1 < len(values) && (values[i+1] == '\\' || values[i+1] == ','):
+			i++
+			cur.WriteByte(values[i])
+		case ch == ',':
+			split = append(split, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(ch)
+		}
+	}
+	return append(split, cur.String())
+}
+
+// DialogResult represents the result from a dialog interaction. Besides
+// the raw Code and Text, the typed field for the widget is filled in.
+type DialogResult struct {
+	Code int    `json:"code"`
+	Text string `json:"text"` // Entered or selected text; "yes"/"no" for confirm
+
+	Confirmed bool      `json:"-"`                 // Confirm: the user answered yes
+	Index     int       `json:"index"`             // Radio, sheet, spinner: selected index, -1 if none
+	Values    []string  `json:"values,omitempty"`  // Checkbox: selected values
+	Indices   []int     `json:"indices,omitempty"` // Checkbox: selected indices
+	Number    int       `json:"-"`                 // Counter: chosen number
+	Time      time.Time `json:"-"`                 // Date: midnight local time; time: that time today
+}
+
+// UnmarshalJSON decodes termux-dialog output, where checkbox selections
+// are objects with an index and text.
+func (r *DialogResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Code   int               `json:"code"`
+		Text   string            `json:"text"`
+		Index  *int              `json:"index"`
+		Values []json.RawMessage `json:"values"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = DialogResult{Code: raw.Code, Text: raw.Text, Index: -1}
+	if raw.Index != nil {
+		r.Index = *raw.Index
+	}
+	for _, v := range raw.Values {
+		var item struct {
+			Index int    `json:"index"`
+			Text  string `json:"text"`
+		}
+		if err := json.Unmarshal(v, &item); err == nil {
+			r.Values = append(r.Values, item.Text)
+			r.Indices = append(r.Indices, item.Index)
+			continue
+		}
+		var text string
+		if err := json.Unmarshal(v, &text); err != nil {
+			return err
+		}
+		r.Values = append(r.Values, text)
+	}
+	return nil
+}
+
+// dialogCodeCancel is the termux-dialog result code for a cancelled or
+// dismissed dialog. It mirrors Android's DialogInterface.BUTTON_NEGATIVE.
+const dialogCodeCancel = -2 // BUTTON_NEGATIVE, also used when dismissed
+
+// parseDialogResult decodes termux-dialog output and fills in the typed
+// field for widget. A dismissed or cancelled dialog is reported as
+// ErrCancelled; for confirm dialogs only a dismissal without a "yes" or
+// "no" answer counts as cancelled.
+func parseDialogResult(widget DialogWidget, output []byte) (*DialogResult, error) {
+	var result DialogResult
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code == dialogCodeCancel {
+		answered := widget == DialogConfirm && (result.Text == "yes" || result.Text == "no")
+		if !answered {
+			return nil, ErrCancelled
+		}
+	}
+
+	var err error
+	switch widget {
+	case DialogConfirm:
+		result.Confirmed = result.Text == "yes"
+	case DialogCounter:
+		result.Number, err = strconv.Atoi(strings.TrimSpace(result.Text))
+	case DialogDate:
+		result.Time, err = time.ParseInLocation(dialogDateLayout, strings.TrimSpace(result.Text), time.Local)
+	case DialogTime:
+		var clock time.Time
+		clock, err = time.Parse(dialogTimeLayout, strings.TrimSpace(result.Text))
+		now := time.Now()
+		result.Time = time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("termux: %s dialog result %q: %w", widget, result.Text, err)
+	}
+	return &result, nil
+}
+
+// ShowDialog validates spec, shows the dialog and returns the user's
+// response with the typed field for the widget filled in.
+//
+// Returns ErrCancelled if the user dismisses the dialog.
+//
+// If not running on Termux, returns an empty DialogResult.
+//
+// Example:
+//
+//	result, err := termux.ShowDialog(termux.DialogSpec{
+//	    Widget: termux.DialogCounter,
+//	    Title:  "Parallel jobs",
+//	    Min:    1, Max: 16, Start: 4,
+//	})
+//	if err == nil {
+//	    jobs = result.Number
+//	}
+func ShowDialog(spec DialogSpec) (*DialogResult, error) {
+	return Default().ShowDialog(spec)
+}
+
+// ShowDialogContext is like ShowDialog but takes a context. The dialog is
+// killed if ctx is done before the user responds.
+func ShowDialogContext(ctx context.Context, spec DialogSpec) (*DialogResult, error) {
+	return Default().ShowDialogContext(ctx, spec)
+}
+
+// ShowDialog validates spec, shows the dialog and returns the response.
+func (c *Client) ShowDialog(spec DialogSpec) (*DialogResult, error) {
+	return c.ShowDialogContext(context.Background(), spec)
+}
+
+// ShowDialogContext is like ShowDialog but takes a context.
+func (c *Client) ShowDialogContext(ctx context.Context, spec DialogSpec) (*DialogResult, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return c.showDialog(ctx, spec)
+}
+
+// showDialog shows the dialog for spec without validating it.
+func (c *Client) showDialog(ctx context.Context, spec DialogSpec) (*DialogResult, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return &DialogResult{Index: -1}, nil
+	}
+
+	output, err := c.run(ctx, "termux-dialog", spec.args()...)
+	if err != nil {
+		return nil, err
+	}
+	return parseDialogResult(spec.widget(), output)
+}
+
+// ConfirmPasswordDialog asks for a new password twice and returns it if
+// both entries match, or ErrPasswordMismatch if they don't.
+//
+// Returns ErrCancelled if the user dismisses either dialog.
+//
+// If not running on Termux, returns an empty string.
+//
+// Example:
+//
+//	pass, err := termux.ConfirmPasswordDialog("New vault password")
+//	if errors.Is(err, termux.ErrPasswordMismatch) {
+//	    termux.Toast("Passwords didn't match")
+//	}
+func ConfirmPasswordDialog(title string) (string, error) {
+	return Default().ConfirmPasswordDialog(title)
+}
+
+// ConfirmPasswordDialogContext is like ConfirmPasswordDialog but takes a
+// context. The dialogs are killed if ctx is done before the user responds.
+func ConfirmPasswordDialogContext(ctx context.Context, title string) (string, error) {
+	return Default().ConfirmPasswordDialogContext(ctx, title)
+}
+
+// ConfirmPasswordDialog asks for a new password twice.
+func (c *Client) ConfirmPasswordDialog(title string) (string, error) {
+	return c.ConfirmPasswordDialogContext(context.Background(), title)
+}
+
+// ConfirmPasswordDialogContext is like ConfirmPasswordDialog but takes a
+// context.
+func (c *Client) ConfirmPasswordDialogContext(ctx context.Context, title string) (string, error) {
+	first, err := c.ShowDialogContext(ctx, DialogSpec{Title: title, Hint: "Enter password", Password: true})
+	if err != nil {
+		return "", err
+	}
+	second, err := c.ShowDialogContext(ctx, DialogSpec{Title: title, Hint: "Confirm password", Password: true})
+	if err != nil {
+		return "", err
+	}
+	if first.Text != second.Text {
+		return "", ErrPasswordMismatch
+	}
+	return first.Text, nil
+}
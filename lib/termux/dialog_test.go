@@ -0,0 +1,169 @@
+package termux_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestShowDialogArgs(t *testing.T) {
+	tests := []struct {
+		spec termux.DialogSpec
+		want []string
+	}{
+		{
+			termux.DialogSpec{Widget: termux.DialogSheet, Title: "Region", Values: []string{"eu, west", "us"}},
+			[]string{"sheet", "-t", "Region", "-v", `eu\, west,us`},
+		},
+		{
+			termux.DialogSpec{Title: "Notes", Hint: "Anything else?", Multiline: true},
+			[]string{"text", "-t", "Notes", "-i", "Anything else?", "-m"},
+		},
+		{
+			termux.DialogSpec{Widget: termux.DialogText, Numeric: true, Password: true},
+			[]string{"text", "-n", "-p"},
+		},
+		{
+			termux.DialogSpec{Widget: termux.DialogCounter, Min: 1, Max: 16, Start: 40},
+			[]string{"counter", "-r", "1,16,16"},
+		},
+		{
+			termux.DialogSpec{Widget: termux.DialogDate, Title: "Due"},
+			[]string{"date", "-t", "Due", "-d", "yyyy-MM-dd"},
+		},
+	}
+	for _, tt := range tests {
+		fake := termuxtest.NewRunner()
+		text := "1"
+		if tt.spec.Widget == termux.DialogDate {
+			text = "2024-05-01"
+		}
+		fake.Respond("termux-dialog", `{"code":-1,"text":"`+text+`"}`)
+		if _, err := termux.NewClient(fake).ShowDialog(tt.spec); err != nil {
+			t.Errorf("ShowDialog(%+v): %v", tt.spec, err)
+			continue
+		}
+		if got := fake.CallsTo("termux-dialog")[0].Args; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("args = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestShowDialogTypedResults(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-dialog", `{"code":-1,"text":"[b, c]","values":[{"index":1,"text":"b"},{"index":2,"text":"c"}]}`)
+	fake.Respond("termux-dialog", `{"code":-1,"text":"c","index":2}`)
+	fake.Respond("termux-dialog", `{"code":-1,"text":"7"}`)
+	fake.Respond("termux-dialog", `{"code":-1,"text":"2024-05-01"}`)
+	fake.Respond("termux-dialog", `{"code":-1,"text":"14:30"}`)
+	client := termux.NewClient(fake)
+	values := []string{"a", "b", "c"}
+
+	r, err := client.ShowDialog(termux.DialogSpec{Widget: termux.DialogCheckbox, Values: values})
+	if err != nil || !reflect.DeepEqual(r.Indices, []int{1, 2}) || !reflect.DeepEqual(r.Values, []string{"b", "c"}) {
+		t.Errorf("checkbox = %+v, %v", r, err)
+	}
+
+	r, err = client.ShowDialog(termux.DialogSpec{Widget: termux.DialogRadio, Values: values})
+	if err != nil || r.Index != 2 || r.Text != "c" {
+		t.Errorf("radio = %+v, %v", r, err)
+	}
+
+	r, err = client.ShowDialog(termux.DialogSpec{Widget: termux.DialogCounter})
+	if err != nil || r.Number != 7 {
+		t.Errorf("counter = %+v, %v", r, err)
+	}
+
+	r, err = client.ShowDialog(termux.DialogSpec{Widget: termux.DialogDate})
+	if err != nil || !r.Time.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("date = %+v, %v", r, err)
+	}
+
+	r, err = client.ShowDialog(termux.DialogSpec{Widget: termux.DialogTime})
+	if err != nil || r.Time.Hour() != 14 || r.Time.Minute() != 30 {
+		t.Errorf("time = %+v, %v", r, err)
+	}
+}
+
+func TestSplitDialogValues(t *testing.T) {
+	tests := []struct {
+		values string
+		want   []string
+	}{
+		{"a,b", []string{"a", "b"}},
+		{"a,,b", []string{"a", "", "b"}},
+		{" a , b", []string{" a ", " b"}},
+		{`eu\, west,us`, []string{"eu, west", "us"}},
+		{`C:\\,D:\`, []string{`C:\`, `D:\`}},
+	}
+	for _, tt := range tests {
+		if got := termux.SplitDialogValues(tt.values); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitDialogValues(%q) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestDialogValuesRoundTrip(t *testing.T) {
+	values := []string{`C:\`, "a,b", "", `\,`, "x"}
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-dialog", `{"code":-1,"text":"x","index":4}`)
+	spec := termux.DialogSpec{Widget: termux.DialogRadio, Values: values}
+	if _, err := termux.NewClient(fake).ShowDialog(spec); err != nil {
+		t.Fatal(err)
+	}
+
+	args := fake.CallsTo("termux-dialog")[0].Args
+	joined := args[len(args)-1]
+	if got := termux.SplitDialogValues(joined); !reflect.DeepEqual(got, values) {
+		t.Errorf("SplitDialogValues(%q) = %q, want %q", joined, got, values)
+	}
+}
+
+func TestDialogPassesArgumentsThrough(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-dialog", `{"code":-1,"text":"ok"}`)
+	if _, err := termux.NewClient(fake).Dialog("slider", "Volume", "Pick one"); err != nil {
+		t.Fatalf("Dialog: %v", err)
+	}
+	want := []string{"slider", "-t", "Volume", "-i", "Pick one"}
+	if got := fake.CallsTo("termux-dialog")[0].Args; !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+}
+
+func TestDialogSpecValidate(t *testing.T) {
+	bad := []termux.DialogSpec{
+		{Widget: "slider"},
+		{Widget: termux.DialogRadio},
+		{Widget: termux.DialogText, Values: []string{"a"}},
+		{Widget: termux.DialogText, Multiline: true, Numeric: true},
+		{Widget: termux.DialogConfirm, Password: true},
+		{Widget: termux.DialogDate, Hint: "when?"},
+		{Widget: termux.DialogCounter, Min: 5, Max: 1},
+	}
+	for _, spec := range bad {
+		if err := spec.Validate(); !errors.Is(err, termux.ErrInvalidDialog) {
+			t.Errorf("Validate(%+v) = %v, want ErrInvalidDialog", spec, err)
+		}
+	}
+}
+
+func TestConfirmPasswordDialog(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-dialog", `{"code":-1,"text":"hunter2"}`)
+	fake.Respond("termux-dialog", `{"code":-1,"text":"hunter3"}`)
+	client := termux.NewClient(fake)
+
+	if _, err := client.ConfirmPasswordDialog("Vault"); !errors.Is(err, termux.ErrPasswordMismatch) {
+		t.Errorf("err = %v, want ErrPasswordMismatch", err)
+	}
+	for _, call := range fake.CallsTo("termux-dialog") {
+		if call.Args[len(call.Args)-1] != "-p" {
+			t.Errorf("args = %q, want a password dialog", call.Args)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package termux
+
+import (
+	"errors"
+	"io/fs"
+	"os/exec"
+	"strings"
+)
+
+// Sentinel errors that Termux failures are classified into. Match them
+// with errors.Is; the underlying runner error stays reachable too.
+//
+// Example:
+//
+//	loc, err := termux.GetLocation()
+//	switch {
+//	case errors.Is(err, termux.ErrPermissionDenied):
+//	    termux.Toast("Grant location permission to Termux:API")
+//	case errors.Is(err, termux.ErrAPINotInstalled):
+//	    fmt.Println("Install the Termux:API app from F-Droid")
+//	case err != nil:
+//	    log.Fatal(err)
+//	}
+var (
+	// ErrNotTermux is returned in strict mode (see SetStrict) when a
+	// function is called outside Termux.
+	ErrNotTermux = errors.New("termux: not running on Termux")
+
+	// ErrAPINotInstalled means the command or the Termux:API app that
+	// services it is missing.
+	ErrAPINotInstalled = errors.New("termux: Termux:API not installed")
+
+	// ErrPermissionDenied means Android denied the permission the command
+	// needs (location, microphone, SMS, ...).
+	ErrPermissionDenied = errors.New("termux: permission denied")
+
+	// ErrCancelled means the user dismissed a dialog without answering.
+	ErrCancelled = errors.New("termux: cancelled by user")
+
+	// ErrTimeout is reported when a command is killed because its context
+	// was done before the command completed. The underlying context error
+	// is still reachable with errors.Is(err, context.DeadlineExceeded) or
+	// context.Canceled.
+	ErrTimeout = errors.New("termux: command timed out")
+)
+
+// TimeoutError records a command that was killed because its context
+// was done.
+type TimeoutError struct {
+	Command string // Name of the command that was killed
+	Err     error  // The context error (DeadlineExceeded or Canceled)
+}
+
+func (e *TimeoutError) Error() string {
+	return "termux: " + e.Command + ": " + e.Err.Error()
+}
+
+// Is reports whether target is ErrTimeout.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// Unwrap returns the context error.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// ExitError is returned by a Runner when a command exits with a non-zero
+// status.
+type ExitError struct {
+	Code   int    // Process exit code
+	Stderr []byte // Captured standard error output
+}
+
+func (e *ExitError) Error() string {
+	msg := "exit status " + formatInt(e.Code)
+	if stderr := strings.TrimSpace(string(e.Stderr)); stderr != "" {
+		msg += ": " + stderr
+	}
+	return msg
+}
+
+// CommandError describes a failed Termux command.
+//
+// Kind holds the sentinel error the failure was classified into (one of
+// ErrAPINotInstalled, ErrPermissionDenied, ...) or nil if it could not be
+// classified. errors.Is matches both Kind and the underlying Err.
+type CommandError struct {
+	Command  string // Name of the command that failed
+	ExitCode int    // Exit code, or -1 if the command did not run
+	Stderr   string // Captured standard error output
+	Kind     error  // Classified sentinel error, or nil
+	Err      error  // Underlying error returned by the runner
+}
+
+func (e *CommandError) Error() string {
+	msg := "termux: " + e.Command
+	if e.Kind != nil {
+		msg += ": " + strings.TrimPrefix(e.Kind.Error(), "termux: ")
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the classified kind and the underlying error.
+func (e *CommandError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// Shell exit codes for commands that could not be started.
+const (
+	exitNotExecutable = 126
+	exitNotFound      = 127
+)
+
+// classifyError wraps a runner error for the named command in a
+// CommandError, classifying it by exit code and stderr. Timeouts are
+// returned unchanged.
+func classifyError(name string, err error) error {
+	var timeout *TimeoutError
+	if errors.As(err, &timeout) {
+		return err
+	}
+
+	cmdErr := &CommandError{Command: name, ExitCode: -1, Err: err}
+
+	var exitErr *ExitError
+	switch {
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		cmdErr.Kind = ErrAPINotInstalled
+	case errors.Is(err, fs.ErrPermission):
+		cmdErr.Kind = ErrPermissionDenied
+	case errors.As(err, &exitErr):
+		cmdErr.ExitCode = exitErr.Code
+		cmdErr.Stderr = strings.TrimSpace(string(exitErr.Stderr))
+		cmdErr.Kind = classifyExit(exitErr.Code, cmdErr.Stderr)
+	}
+
+	return cmdErr
+}
+
+// classifyExit maps an exit code and stderr text to a sentinel error.
+func classifyExit(code int, stderr string) error {
+	lower := strings.ToLower(stderr)
+
+	switch {
+	case code == exitNotFound,
+		strings.Contains(lower, "command not found"),
+		strings.Contains(lower, "not installed"),
+		strings.Contains(lower, "com.termux.api") && strings.Contains(lower, "not found"),
+		strings.Contains(lower, "com.termux.api") && strings.Contains(lower, "does not exist"):
+		return ErrAPINotInstalled
+	case code == exitNotExecutable,
+		strings.Contains(lower, "permission"),
+		strings.Contains(lower, "not granted"),
+		strings.Contains(lower, "securityexception"):
+		return ErrPermissionDenied
+	case strings.Contains(lower, "cancelled"), strings.Contains(lower, "canceled"):
+		return ErrCancelled
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package termux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name string
+		resp termuxtest.Response
+		want error
+	}{
+		{
+			name: "permission",
+			resp: termuxtest.Response{ExitCode: 1, Stderr: "java.lang.SecurityException: Permission Denial: requires ACCESS_FINE_LOCATION"},
+			want: termux.ErrPermissionDenied,
+		},
+		{
+			name: "not found",
+			resp: termuxtest.Response{ExitCode: 127, Stderr: "termux-location: command not found"},
+			want: termux.ErrAPINotInstalled,
+		},
+		{
+			name: "api app missing",
+			resp: termuxtest.Response{ExitCode: 1, Stderr: "Error: package com.termux.api not found"},
+			want: termux.ErrAPINotInstalled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := termuxtest.NewRunner()
+			fake.RespondWith("termux-location", tt.resp)
+			client := termux.NewClient(fake)
+
+			_, err := client.GetLocation()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("GetLocation error = %v, want %v", err, tt.want)
+			}
+
+			var cmdErr *termux.CommandError
+			if !errors.As(err, &cmdErr) {
+				t.Fatalf("GetLocation error %T is not a *CommandError", err)
+			}
+			if cmdErr.ExitCode != tt.resp.ExitCode || cmdErr.Stderr != tt.resp.Stderr {
+				t.Errorf("CommandError = %+v", cmdErr)
+			}
+		})
+	}
+}
+
+func TestUnclassifiedErrorStillWrapped(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Exit("termux-battery-status", 3, "something odd")
+	client := termux.NewClient(fake)
+
+	_, err := client.GetBatteryStatus()
+	var exitErr *termux.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 3 {
+		t.Fatalf("GetBatteryStatus error = %v, want wrapped ExitError with code 3", err)
+	}
+	for _, sentinel := range []error{termux.ErrPermissionDenied, termux.ErrAPINotInstalled, termux.ErrCancelled} {
+		if errors.Is(err, sentinel) {
+			t.Errorf("error unexpectedly matches %v", sentinel)
+		}
+	}
+}
+
+func TestDialogCancelled(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-dialog", `{"code":-2,"text":""}`)
+	client := termux.NewClient(fake)
+
+	if _, err := client.TextDialog("Name", ""); !errors.Is(err, termux.ErrCancelled) {
+		t.Errorf("TextDialog error = %v, want ErrCancelled", err)
+	}
+	if _, err := client.ConfirmDialog("Sure?", ""); !errors.Is(err, termux.ErrCancelled) {
+		t.Errorf("ConfirmDialog error = %v, want ErrCancelled", err)
+	}
+}
+
+func TestConfirmDialogNoIsNotCancelled(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-dialog", `{"code":-2,"text":"no"}`)
+	client := termux.NewClient(fake)
+
+	ok, err := client.ConfirmDialog("Sure?", "")
+	if err != nil || ok {
+		t.Errorf("ConfirmDialog = %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate")
+	client := termux.NewClient(fake)
+
+	status, err := client.GetBatteryStatus()
+	if err != nil || status.Percentage != 100 {
+		t.Fatalf("non-strict GetBatteryStatus = %+v, %v; want placeholder", status, err)
+	}
+
+	client.SetStrict(true)
+	if _, err := client.GetBatteryStatus(); !errors.Is(err, termux.ErrNotTermux) {
+		t.Errorf("strict GetBatteryStatus error = %v, want ErrNotTermux", err)
+	}
+	if _, err := client.CounterDialog("Count", 1, 5); !errors.Is(err, termux.ErrNotTermux) {
+		t.Errorf("strict CounterDialog error = %v, want ErrNotTermux", err)
+	}
+	if err := client.Toast("hi"); !errors.Is(err, termux.ErrNotTermux) {
+		t.Errorf("strict Toast error = %v, want ErrNotTermux", err)
+	}
+}
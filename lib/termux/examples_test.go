@@ -3,8 +3,11 @@ package termux_test
 import (
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"time"
 
-	"github.com/yourname/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux"
 )
 
 // Example_basicNotification shows a simple notification.
@@ -34,8 +37,8 @@ func Example_notificationWithButtons() {
 		"PR #123 has been created and is ready for review",
 		termux.WithID("pr-123"),
 		termux.WithPriority("high"),
-		termux.WithButton("View PR", "termux-open-url https://github.com/user/repo/pull/123"),
-		termux.WithButton("Copy URL", "bash -c 'echo https://github.com/user/repo/pull/123 | termux-clipboard-set'"),
+		termux.WithButton("View PR", []string{"termux-open-url", "https://github.com/user/repo/pull/123"}),
+		termux.WithButton("Copy URL", []string{"termux-clipboard-set", "https://github.com/user/repo/pull/123"}),
 		termux.WithVibrate("100,50,100"),
 		termux.WithSound(),
 	)
@@ -94,6 +97,34 @@ func Example_batteryAwareTask() {
 	fmt.Println("Processing...")
 }
 
+// Example_scheduledSync shows a background job that keeps syncing while
+// the TUI is closed, and checks the battery each time it runs.
+func Example_scheduledSync() {
+	// On "enable background sync": the job runs `notes sync` every hour
+	// on Wi-Fi, even after a reboot
+	exe, _ := os.Executable()
+	err := termux.InstallJob(termux.Job{
+		ID:        1,
+		Period:    time.Hour,
+		Network:   termux.JobNetworkUnmetered,
+		Persisted: true,
+	}, termux.JobScript{
+		Command:  []string{exe, "sync"},
+		LogFile:  filepath.Join(os.TempDir(), "notes-sync.log"),
+		WakeLock: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// In the `sync` subcommand the job runs
+	battery, _ := termux.GetBatteryStatus()
+	if battery.Percentage < 20 && battery.Status != "CHARGING" {
+		return // Try again next period
+	}
+	fmt.Println("Syncing...")
+}
+
 // Example_voiceInput shows voice-controlled workflows.
 func Example_voiceInput() {
 	termux.Toast("Listening...")
@@ -198,7 +229,7 @@ func Example_location() {
 	termux.Notify(
 		"Location",
 		fmt.Sprintf("%.4f, %.4f (±%.1fm)", loc.Latitude, loc.Longitude, loc.Accuracy),
-		termux.WithButton("Copy", fmt.Sprintf("bash -c 'echo %.4f,%.4f | termux-clipboard-set'", loc.Latitude, loc.Longitude)),
+		termux.WithButton("Copy", []string{"termux-clipboard-set", fmt.Sprintf("%.4f,%.4f", loc.Latitude, loc.Longitude)}),
 	)
 }
 
@@ -371,7 +402,7 @@ func Example_backgroundWorker() {
 		termux.WithID("worker-done"),
 		termux.WithPriority("high"),
 		termux.WithSound(),
-		termux.WithButton("View Results", "termux-open-url https://..."),
+		termux.WithButton("View Results", []string{"termux-open-url", "https://..."}),
 	)
 
 	termux.Speak(fmt.Sprintf("All tasks complete. %d succeeded", successCount))
@@ -0,0 +1,18 @@
+//go:build !unix
+
+package termux
+
+import (
+	"errors"
+	"fmt"
+)
+
+// makeFIFO fails on platforms without named pipes.
+func makeFIFO(path string) error {
+	return fmt.Errorf("termux: named pipes: %w", errors.ErrUnsupported)
+}
+
+// processAlive reports every process as running, so no pipe is swept.
+func processAlive(pid int) bool {
+	return true
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package termux
+
+import (
+	"errors"
+	"syscall"
+)
+
+// makeFIFO creates a named pipe at path readable and writable only by the
+// current user.
+func makeFIFO(path string) error {
+	return syscall.Mkfifo(path, 0o600)
+}
+
+// processAlive reports whether a process with the given PID is running.
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
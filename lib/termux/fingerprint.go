@@ -0,0 +1,261 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// AuthResult is the outcome of a fingerprint prompt.
+type AuthResult string
+
+const (
+	AuthSuccess AuthResult = "AUTH_RESULT_SUCCESS"
+	AuthFailure AuthResult = "AUTH_RESULT_FAILURE"
+	AuthUnknown AuthResult = "AUTH_RESULT_UNKNOWN" // The prompt never completed
+)
+
+// FingerprintError is a reason reported for a failed fingerprint prompt.
+type FingerprintError string
+
+const (
+	FingerprintNoHardware      FingerprintError = "ERROR_NO_HARDWARE"
+	FingerprintNotEnrolled     FingerprintError = "ERROR_NO_ENROLLED_FINGERPRINTS"
+	FingerprintTimeout         FingerprintError = "ERROR_TIMEOUT"
+	FingerprintTooManyAttempts FingerprintError = "ERROR_TOO_MANY_FAILED_ATTEMPTS"
+	FingerprintLockout         FingerprintError = "ERROR_LOCKOUT"
+	FingerprintCancelled       FingerprintError = "ERROR_CANCELLED" // The user dismissed the prompt
+)
+
+var (
+	// ErrFingerprintUnavailable means the device has no fingerprint
+	// sensor or no fingerprint enrolled.
+	ErrFingerprintUnavailable = errors.New("termux: fingerprint unavailable")
+
+	// ErrFingerprintLockout means Android locked the sensor after too
+	// many failed attempts.
+	ErrFingerprintLockout = errors.New("termux: fingerprint locked out")
+
+	// ErrAuthFailed means the user could not be authenticated.
+	ErrAuthFailed = errors.New("termux: authentication failed")
+)
+
+// FingerprintOptions configures the fingerprint prompt. Empty fields use
+// the defaults of termux-fingerprint.
+type FingerprintOptions struct {
+	Title       string
+	Subtitle    string
+	Description string
+	Cancel      string // Label of the negative button, e.g. "Use password"
+}
+
+// args builds the termux-fingerprint flags for o.
+func (o FingerprintOptions) args() []string {
+	var args []string
+	if o.Title != "" {
+		args = append(args, "-t", o.Title)
+	}
+	if o.Subtitle != "" {
+		args = append(args, "-s", o.Subtitle)
+	}
+	if o.Description != "" {
+		args = append(args, "-d", o.Description)
+	}
+	if o.Cancel != "" {
+		args = append(args, "-c", o.Cancel)
+	}
+	return args
+}
+
+// FingerprintResult is the outcome of Authenticate, as reported by
+// termux-fingerprint.
+type FingerprintResult struct {
+	Result         AuthResult         `json:"auth_result"`
+	Errors         []FingerprintError `json:"errors"`          // Why authentication failed, if it did
+	FailedAttempts int                `json:"failed_attempts"` // Fingerprints rejected before the outcome
+}
+
+// Success reports whether the user was authenticated.
+func (r FingerprintResult) Success() bool {
+	return r.Result == AuthSuccess
+}
+
+// Err returns nil on success, and otherwise an error matching one of
+// ErrFingerprintUnavailable, ErrFingerprintLockout, ErrCancelled or
+// ErrAuthFailed.
+func (r FingerprintResult) Err() error {
+	if r.Success() {
+		return nil
+	}
+	has := func(e ...FingerprintError) bool {
+		return slices.ContainsFunc(r.Errors, func(got FingerprintError) bool { return slices.Contains(e, got) })
+	}
+
+	kind := ErrAuthFailed
+	switch {
+	case has(FingerprintNoHardware, FingerprintNotEnrolled):
+		kind = ErrFingerprintUnavailable
+	case has(FingerprintLockout, FingerprintTooManyAttempts):
+		kind = ErrFingerprintLockout
+	case has(FingerprintCancelled):
+		kind = ErrCancelled
+	}
+	if len(r.Errors) == 0 {
+		return kind
+	}
+	return fmt.Errorf("%w: %v", kind, r.Errors)
+}
+
+// Authenticate shows the system fingerprint prompt and waits for the
+// outcome. A failed authentication is not an error: check the result's
+// Success or Err. The error is only for failures to run the prompt.
+//
+// If not running on Termux, reports AuthFailure with FingerprintNoHardware
+// so callers never mistake a fallback for a successful authentication.
+//
+// Example:
+//
+//	res, err := termux.Authenticate(termux.FingerprintOptions{Title: "Unlock vault"})
+//	if err != nil {
+//	    return err
+//	}
+//	if errors.Is(res.Err(), termux.ErrFingerprintUnavailable) {
+//	    // Ask for the master password instead
+//	}
+func Authenticate(opts FingerprintOptions) (FingerprintResult, error) {
+	return Default().Authenticate(opts)
+}
+
+// AuthenticateContext is like Authenticate but takes a context. The
+// prompt is killed if ctx is done before the user responds.
+func AuthenticateContext(ctx context.Context, opts FingerprintOptions) (FingerprintResult, error) {
+	return Default().AuthenticateContext(ctx, opts)
+}
+
+// Authenticate shows the system fingerprint prompt.
+func (c *Client) Authenticate(opts FingerprintOptions) (FingerprintResult, error) {
+	return c.AuthenticateContext(context.Background(), opts)
+}
+
+// AuthenticateContext is like Authenticate but takes a context.
+func (c *Client) AuthenticateContext(ctx context.Context, opts FingerprintOptions) (FingerprintResult, error) {
+	if !c.IsTermux() {
+		return FingerprintResult{Result: AuthFailure, Errors: []FingerprintError{FingerprintNoHardware}}, c.fallbackErr()
+	}
+
+	output, err := c.run(ctx, "termux-fingerprint", opts.args()...)
+	if err != nil {
+		return FingerprintResult{}, err
+	}
+
+	var res FingerprintResult
+	if err := json.Unmarshal(output, &res); err != nil {
+		return FingerprintResult{}, err
+	}
+	return res, nil
+}
+
+// UnlockMethod is how Unlock authenticated the user.
+type UnlockMethod string
+
+const (
+	UnlockFingerprint UnlockMethod = "fingerprint"
+	UnlockPassword    UnlockMethod = "password"
+)
+
+// UnlockOptions configures Unlock. Empty fields get sensible defaults.
+type UnlockOptions struct {
+	Fingerprint FingerprintOptions // Cancel defaults to "Use password"
+
+	PasswordTitle string // Defaults to Fingerprint.Title, then "Password"
+	PasswordHint  string // Defaults to "Enter password"
+}
+
+// UnlockResult reports how Unlock authenticated the user.
+type UnlockResult struct {
+	Method      UnlockMethod
+	Password    string            // Entered password, for UnlockPassword
+	Fingerprint FingerprintResult // Outcome of the fingerprint prompt
+}
+
+// Unlock authenticates the user with their fingerprint, falling back to
+// a PasswordDialog when the fingerprint prompt fails, is dismissed with
+// its "Use password" button, or the device has no usable sensor.
+//
+// A password is returned to the caller to check (or to derive a key
+// from); Unlock itself only vouches for fingerprints. Returns
+// ErrCancelled if the password dialog is dismissed or left empty.
+//
+// Unlock fails closed: if not running on Termux it returns ErrNotTermux,
+// even outside strict mode.
+//
+// Example:
+//
+//	res, err := termux.Unlock(termux.UnlockOptions{
+//	    Fingerprint: termux.FingerprintOptions{Title: "Unlock vault"},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	if res.Method == termux.UnlockPassword && !vault.CheckPassword(res.Password) {
+//	    return errWrongPassword
+//	}
+func Unlock(opts UnlockOptions) (UnlockResult, error) {
+	return Default().Unlock(opts)
+}
+
+// UnlockContext is like Unlock but takes a context. The prompts are
+// killed if ctx is done before the user responds.
+func UnlockContext(ctx context.Context, opts UnlockOptions) (UnlockResult, error) {
+	return Default().UnlockContext(ctx, opts)
+}
+
+// Unlock authenticates the user with their fingerprint or a password.
+func (c *Client) Unlock(opts UnlockOptions) (UnlockResult, error) {
+	return c.UnlockContext(context.Background(), opts)
+}
+
+// UnlockContext is like Unlock but takes a context.
+func (c *Client) UnlockContext(ctx context.Context, opts UnlockOptions) (UnlockResult, error) {
+	if !c.IsTermux() {
+		return UnlockResult{}, ErrNotTermux
+	}
+
+	fpOpts := opts.Fingerprint
+	if fpOpts.Cancel == "" {
+		fpOpts.Cancel = "Use password"
+	}
+	fp, err := c.AuthenticateContext(ctx, fpOpts)
+	if err != nil && !errors.Is(err, ErrAPINotInstalled) {
+		return UnlockResult{}, err
+	}
+	res := UnlockResult{Fingerprint: fp}
+	if fp.Success() {
+		res.Method = UnlockFingerprint
+		return res, nil
+	}
+
+	title := opts.PasswordTitle
+	if title == "" {
+		title = opts.Fingerprint.Title
+	}
+	if title == "" {
+		title = "Password"
+	}
+	hint := opts.PasswordHint
+	if hint == "" {
+		hint = "Enter password"
+	}
+	password, err := c.PasswordDialogContext(ctx, title, hint)
+	if err != nil {
+		return res, err
+	}
+	if password == "" {
+		return res, ErrCancelled
+	}
+	res.Method = UnlockPassword
+	res.Password = password
+	return res, nil
+}
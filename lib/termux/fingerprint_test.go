@@ -0,0 +1,164 @@
+package termux_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestAuthenticate(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-fingerprint", `{"errors": [], "failed_attempts": 1, "auth_result": "AUTH_RESULT_SUCCESS"}`)
+	fake.Respond("termux-fingerprint", `{"errors": ["ERROR_LOCKOUT"], "failed_attempts": 5, "auth_result": "AUTH_RESULT_FAILURE"}`)
+	client := termux.NewClient(fake)
+
+	res, err := client.Authenticate(termux.FingerprintOptions{Title: "Unlock vault", Cancel: "Use password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Success() || res.Err() != nil || res.FailedAttempts != 1 {
+		t.Errorf("result = %+v", res)
+	}
+	if got := strings.Join(fake.CallsTo("termux-fingerprint")[0].Args, " "); got != "-t Unlock vault -c Use password" {
+		t.Errorf("args = %q", got)
+	}
+
+	res, _ = client.Authenticate(termux.FingerprintOptions{})
+	if res.Success() || !errors.Is(res.Err(), termux.ErrFingerprintLockout) {
+		t.Errorf("lockout result = %+v, Err = %v", res, res.Err())
+	}
+}
+
+func TestFingerprintResultErr(t *testing.T) {
+	tests := []struct {
+		errors []termux.FingerprintError
+		want   error
+	}{
+		{[]termux.FingerprintError{termux.FingerprintNotEnrolled}, termux.ErrFingerprintUnavailable},
+		{[]termux.FingerprintError{termux.FingerprintCancelled}, termux.ErrCancelled},
+		{[]termux.FingerprintError{termux.FingerprintTimeout}, termux.ErrAuthFailed},
+		{nil, termux.ErrAuthFailed},
+	}
+	for _, tt := range tests {
+		res := termux.FingerprintResult{Result: termux.AuthFailure, Errors: tt.errors}
+		if err := res.Err(); !errors.Is(err, tt.want) {
+			t.Errorf("Err() with %v = %v, want %v", tt.errors, err, tt.want)
+		}
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-fingerprint", `{"errors": [], "auth_result": "AUTH_RESULT_SUCCESS"}`)
+	fake.Respond("termux-fingerprint", `{"errors": ["ERROR_NO_ENROLLED_FINGERPRINTS"], "auth_result": "AUTH_RESULT_FAILURE"}`)
+	fake.Respond("termux-dialog", `{"code": -1, "text": "hunter2"}`)
+	client := termux.NewClient(fake)
+	opts := termux.UnlockOptions{Fingerprint: termux.FingerprintOptions{Title: "Vault"}}
+
+	res, err := client.Unlock(opts)
+	if err != nil || res.Method != termux.UnlockFingerprint {
+		t.Fatalf("Unlock = %+v, %v; want fingerprint", res, err)
+	}
+	if len(fake.CallsTo("termux-dialog")) != 0 {
+		t.Error("password asked for after a fingerprint")
+	}
+	if args := fake.CallsTo("termux-fingerprint")[0].Args; args[len(args)-1] != "Use password" {
+		t.Errorf("fingerprint args = %q, want a \"Use password\" button", args)
+	}
+
+	res, err = client.Unlock(opts)
+	if err != nil || res.Method != termux.UnlockPassword || res.Password != "hunter2" {
+		t.Fatalf("Unlock = %+v, %v; want the password", res, err)
+	}
+	if !errors.Is(res.Fingerprint.Err(), termux.ErrFingerprintUnavailable) {
+		t.Errorf("fingerprint outcome = %+v", res.Fingerprint)
+	}
+	if args := strings.Join(fake.CallsTo("termux-dialog")[0].Args, " "); !strings.Contains(args, "-p") || !strings.Contains(args, "Vault") {
+		t.Errorf("dialog args = %q, want a password dialog titled Vault", args)
+	}
+}
+
+func TestUnlockFailsClosed(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate")
+
+	if _, err := termux.NewClient(fake).Unlock(termux.UnlockOptions{}); !errors.Is(err, termux.ErrNotTermux) {
+		t.Errorf("Unlock off Termux = %v, want ErrNotTermux", err)
+	}
+
+	fake = termuxtest.NewRunner()
+	fake.Respond("termux-fingerprint", `{"errors": ["ERROR_CANCELLED"], "auth_result": "AUTH_RESULT_FAILURE"}`)
+	fake.Respond("termux-dialog", `{"code": -1, "text": ""}`)
+	if _, err := termux.NewClient(fake).Unlock(termux.UnlockOptions{}); !errors.Is(err, termux.ErrCancelled) {
+		t.Errorf("Unlock with an empty password = %v, want ErrCancelled", err)
+	}
+}
+
+func TestKeystore(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-keystore", `[{"alias": "vault", "algorithm": "EC", "size": 256,
+		"inside_secure_hardware": true, "user_authentication": {"required": true, "validity_duration_seconds": 30}}]`)
+	fake.Respond("termux-keystore", "")
+	fake.Respond("termux-keystore", "\x30\x45\x02\x21")
+	fake.Respond("termux-keystore", "true\n")
+	fake.Respond("termux-keystore", "android.security.keystore.UserNotAuthenticatedException: User not authenticated")
+	client := termux.NewClient(fake)
+
+	keys, err := client.ListKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].Algorithm != termux.KeyEC || !keys[0].UserAuthentication.Required || keys[0].UserAuthentication.ValiditySeconds != 30 {
+		t.Errorf("keys = %+v", keys)
+	}
+
+	err = client.GenerateKey("vault", termux.KeyOptions{Algorithm: termux.KeyEC, Size: 256, AuthValidity: 1500 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(fake.CallsTo("termux-keystore")[1].Args, " "); got != "generate vault -a EC -s 256 -u 2" {
+		t.Errorf("generate args = %q", got)
+	}
+
+	sig, err := client.Sign("vault", termux.SignSHA256WithECDSA, []byte("challenge"))
+	if err != nil || string(sig) != "\x30\x45\x02\x21" {
+		t.Fatalf("Sign = %x, %v", sig, err)
+	}
+	if call := fake.CallsTo("termux-keystore")[2]; string(call.Stdin) != "challenge" {
+		t.Errorf("sign stdin = %q", call.Stdin)
+	}
+
+	ok, err := client.Verify("vault", termux.SignSHA256WithECDSA, []byte("challenge"), sig)
+	if err != nil || !ok {
+		t.Fatalf("Verify = %v, %v", ok, err)
+	}
+	verify := fake.CallsTo("termux-keystore")[3]
+	if verify.Args[0] != "verify" || string(verify.Stdin) != "challenge" {
+		t.Errorf("verify call = %+v", verify)
+	}
+	if _, err := os.Stat(verify.Args[3]); !os.IsNotExist(err) {
+		t.Errorf("signature file %s left behind", verify.Args[3])
+	}
+
+	if _, err := client.Sign("vault", termux.SignSHA256WithECDSA, nil); !errors.Is(err, termux.ErrPermissionDenied) {
+		t.Errorf("Sign without authentication = %v, want ErrPermissionDenied", err)
+	}
+}
+
+func TestKeystoreFailsClosed(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate")
+	client := termux.NewClient(fake)
+
+	if sig, err := client.Sign("vault", termux.SignSHA256WithECDSA, []byte("challenge")); !errors.Is(err, termux.ErrNotTermux) || sig != nil {
+		t.Errorf("Sign off Termux = %x, %v; want ErrNotTermux", sig, err)
+	}
+	if ok, err := client.Verify("vault", termux.SignSHA256WithECDSA, []byte("challenge"), []byte("sig")); !errors.Is(err, termux.ErrNotTermux) || ok {
+		t.Errorf("Verify off Termux = %v, %v; want ErrNotTermux", ok, err)
+	}
+}
@@ -1,18 +1,11 @@
 package termux
 
 import (
-	"encoding/json"
-	"os/exec"
+	"context"
+	"strconv"
 	"strings"
 )
 
-// DialogResult represents the result from a dialog interaction.
-type DialogResult struct {
-	Code   int      `json:"code"`
-	Text   string   `json:"text"`
-	Values []string `json:"values,omitempty"` // For checkbox dialogs
-}
-
 // SpeechToText converts speech to text using Google's speech recognition.
 // The function blocks until speech is detected and processed.
 //
@@ -26,12 +19,27 @@ type DialogResult struct {
 //	}
 //	fmt.Println("You said:", text)
 func SpeechToText() (string, error) {
-	if !IsTermux() {
-		return "", nil
+	return Default().SpeechToText()
+}
+
+// SpeechToTextContext is like SpeechToText but takes a context. The
+// command is killed if ctx is done before it completes.
+func SpeechToTextContext(ctx context.Context) (string, error) {
+	return Default().SpeechToTextContext(ctx)
+}
+
+// SpeechToText converts speech to text using Google's speech recognition.
+func (c *Client) SpeechToText() (string, error) {
+	return c.SpeechToTextContext(context.Background())
+}
+
+// SpeechToTextContext is like SpeechToText but takes a context.
+func (c *Client) SpeechToTextContext(ctx context.Context) (string, error) {
+	if !c.IsTermux() {
+		return "", c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-speech-to-text")
-	output, err := cmd.Output()
+	output, err := c.run(ctx, "termux-speech-to-text")
 	if err != nil {
 		return "", err
 	}
@@ -42,18 +50,40 @@ func SpeechToText() (string, error) {
 // Speak converts text to speech using the device's TTS engine.
 // The function returns immediately (speech plays asynchronously).
 //
-// If not running on Termux, this is a no-op.
+// On a Linux desktop the text is spoken with espeak-ng, espeak or spd-say
+// (see LinuxBackend). Otherwise, if not running on Termux, this is a no-op.
 //
 // Example:
 //
 //	termux.Speak("Task complete")
 func Speak(text string) error {
-	if !IsTermux() {
-		return nil
+	return Default().Speak(text)
+}
+
+// SpeakContext is like Speak but takes a context. The command is killed if
+// ctx is done before it completes.
+func SpeakContext(ctx context.Context, text string) error {
+	return Default().SpeakContext(ctx, text)
+}
+
+// Speak converts text to speech using the device's TTS engine.
+func (c *Client) Speak(text string) error {
+	return c.SpeakContext(context.Background(), text)
+}
+
+// SpeakContext is like Speak but takes a context.
+func (c *Client) SpeakContext(ctx context.Context, text string) error {
+	return c.Backend().Speak(ctx, text)
+}
+
+// termuxSpeak speaks text with termux-tts-speak.
+func (c *Client) termuxSpeak(ctx context.Context, text string) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-tts-speak", text)
-	return cmd.Run()
+	_, err := c.runInput(ctx, []byte(text), "termux-tts-speak")
+	return err
 }
 
 // SpeakWithOptions speaks text with advanced TTS options.
@@ -64,7 +94,10 @@ func Speak(text string) error {
 //   - language: Language code (e.g., "en-US", "es-ES")
 //   - pitch: Pitch level (0.0 - 2.0, default 1.0)
 //   - rate: Speech rate (0.0 - 2.0, default 1.0)
-//   - stream: Audio stream type (e.g., "STREAM_NOTIFICATION")
+//   - stream: Audio stream type (e.g., "NOTIFICATION"; see TTSStream)
+//
+// Use SpeakVoice to pass the options as a Voice, or a Speaker to queue
+// utterances instead of talking over each other.
 //
 // If not running on Termux, this is a no-op.
 //
@@ -72,32 +105,29 @@ func Speak(text string) error {
 //
 //	termux.SpeakWithOptions("Hello world", "", "en-US", 1.2, 0.9, "")
 func SpeakWithOptions(text, engine, language string, pitch, rate float64, stream string) error {
-	if !IsTermux() {
-		return nil
-	}
+	return Default().SpeakWithOptions(text, engine, language, pitch, rate, stream)
+}
 
-	args := []string{"termux-tts-speak"}
+// SpeakWithOptionsContext is like SpeakWithOptions but takes a context.
+// The command is killed if ctx is done before it completes.
+func SpeakWithOptionsContext(ctx context.Context, text, engine, language string, pitch, rate float64, stream string) error {
+	return Default().SpeakWithOptionsContext(ctx, text, engine, language, pitch, rate, stream)
+}
 
-	if engine != "" {
-		args = append(args, "-e", engine)
-	}
-	if language != "" {
-		args = append(args, "-l", language)
-	}
-	if pitch != 0.0 {
-		args = append(args, "-p", formatFloat(pitch))
-	}
-	if rate != 0.0 {
-		args = append(args, "-r", formatFloat(rate))
-	}
-	if stream != "" {
-		args = append(args, "-s", stream)
-	}
+// SpeakWithOptions speaks text with advanced TTS options.
+func (c *Client) SpeakWithOptions(text, engine, language string, pitch, rate float64, stream string) error {
+	return c.SpeakWithOptionsContext(context.Background(), text, engine, language, pitch, rate, stream)
+}
 
-	args = append(args, text)
+// SpeakWithOptionsContext is like SpeakWithOptions but takes a context.
+func (c *Client) SpeakWithOptionsContext(ctx context.Context, text, engine, language string, pitch, rate float64, stream string) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
 
-	cmd := exec.Command(args[0], args[1:]...)
-	return cmd.Run()
+	voice := Voice{Engine: engine, Language: language, Pitch: pitch, Rate: rate, Stream: TTSStream(stream)}
+	_, err := c.runInput(ctx, []byte(text), "termux-tts-speak", voice.args()...)
+	return err
 }
 
 // Dialog shows a native Android dialog and returns the user's input.
@@ -107,35 +137,49 @@ func SpeakWithOptions(text, engine, language string, pitch, rate float64, stream
 //   - title: Dialog title
 //   - hint: Hint/prompt text
 //
-// Additional parameters vary by dialog type. Use the type-specific helper functions instead.
+// Additional parameters vary by dialog type. Use ShowDialog with a
+// DialogSpec, or the type-specific helper functions, instead.
+//
+// Unlike ShowDialog, Dialog does not validate its arguments: they are
+// passed to termux-dialog as given, which reports any it rejects.
+//
+// If the user dismisses the dialog, returns ErrCancelled.
 //
 // If not running on Termux, returns an empty DialogResult.
 func Dialog(dialogType, title, hint string) (*DialogResult, error) {
-	if !IsTermux() {
-		return &DialogResult{}, nil
-	}
-
-	args := []string{"termux-dialog", dialogType, "-t", title}
-	if hint != "" {
-		args = append(args, "-i", hint)
-	}
+	return Default().Dialog(dialogType, title, hint)
+}
 
-	cmd := exec.Command(args[0], args[1:]...)
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, err
-	}
+// DialogContext is like Dialog but takes a context. The dialog process is
+// killed if ctx is done before the user responds, so an ignored dialog
+// cannot block the caller forever.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	result, err := termux.DialogContext(ctx, "text", "Commit Message", "")
+//	if errors.Is(err, termux.ErrTimeout) {
+//	    // User walked away - keep the previous message
+//	}
+func DialogContext(ctx context.Context, dialogType, title, hint string) (*DialogResult, error) {
+	return Default().DialogContext(ctx, dialogType, title, hint)
+}
 
-	var result DialogResult
-	if err := json.Unmarshal(output, &result); err != nil {
-		return nil, err
-	}
+// Dialog shows a native Android dialog and returns the user's input.
+func (c *Client) Dialog(dialogType, title, hint string) (*DialogResult, error) {
+	return c.DialogContext(context.Background(), dialogType, title, hint)
+}
 
-	return &result, nil
+// DialogContext is like Dialog but takes a context.
+func (c *Client) DialogContext(ctx context.Context, dialogType, title, hint string) (*DialogResult, error) {
+	return c.showDialog(ctx, DialogSpec{Widget: DialogWidget(dialogType), Title: title, Hint: hint})
 }
 
 // ConfirmDialog shows a yes/no confirmation dialog.
 //
+// Returns ErrCancelled if the user dismisses the dialog without answering.
+//
 // If not running on Termux, returns false.
 //
 // Example:
@@ -148,20 +192,38 @@ func Dialog(dialogType, title, hint string) (*DialogResult, error) {
 //	    // User clicked "yes"
 //	}
 func ConfirmDialog(title, message string) (bool, error) {
-	if !IsTermux() {
-		return false, nil
+	return Default().ConfirmDialog(title, message)
+}
+
+// ConfirmDialogContext is like ConfirmDialog but takes a context. The
+// command is killed if ctx is done before it completes.
+func ConfirmDialogContext(ctx context.Context, title, message string) (bool, error) {
+	return Default().ConfirmDialogContext(ctx, title, message)
+}
+
+// ConfirmDialog shows a yes/no confirmation dialog.
+func (c *Client) ConfirmDialog(title, message string) (bool, error) {
+	return c.ConfirmDialogContext(context.Background(), title, message)
+}
+
+// ConfirmDialogContext is like ConfirmDialog but takes a context.
+func (c *Client) ConfirmDialogContext(ctx context.Context, title, message string) (bool, error) {
+	if !c.IsTermux() {
+		return false, c.fallbackErr()
 	}
 
-	result, err := Dialog("confirm", title, message)
+	result, err := c.ShowDialogContext(ctx, DialogSpec{Widget: DialogConfirm, Title: title, Hint: message})
 	if err != nil {
 		return false, err
 	}
 
-	return result.Text == "yes", nil
+	return result.Confirmed, nil
 }
 
 // TextDialog shows a text input dialog and returns the entered text.
 //
+// Returns ErrCancelled if the user dismisses the dialog.
+//
 // If not running on Termux, returns an empty string.
 //
 // Example:
@@ -172,43 +234,52 @@ func ConfirmDialog(title, message string) (bool, error) {
 //	}
 //	fmt.Println("Message:", message)
 func TextDialog(title, hint string) (string, error) {
-	if !IsTermux() {
-		return "", nil
-	}
+	return Default().TextDialog(title, hint)
+}
 
-	result, err := Dialog("text", title, hint)
-	if err != nil {
-		return "", err
-	}
+// TextDialogContext is like TextDialog but takes a context. The command is
+// killed if ctx is done before it completes.
+func TextDialogContext(ctx context.Context, title, hint string) (string, error) {
+	return Default().TextDialogContext(ctx, title, hint)
+}
 
-	return result.Text, nil
+// TextDialog shows a text input dialog and returns the entered text.
+func (c *Client) TextDialog(title, hint string) (string, error) {
+	return c.TextDialogContext(context.Background(), title, hint)
+}
+
+// TextDialogContext is like TextDialog but takes a context.
+func (c *Client) TextDialogContext(ctx context.Context, title, hint string) (string, error) {
+	return c.textDialog(ctx, DialogSpec{Widget: DialogText, Title: title, Hint: hint})
 }
 
 // PasswordDialog shows a password input dialog (hidden text entry).
 //
+// Returns ErrCancelled if the user dismisses the dialog.
+//
 // If not running on Termux, returns an empty string.
 //
 // Example:
 //
 //	apiKey, err := termux.PasswordDialog("API Key", "Enter your API key:")
 func PasswordDialog(title, hint string) (string, error) {
-	if !IsTermux() {
-		return "", nil
-	}
+	return Default().PasswordDialog(title, hint)
+}
 
-	args := []string{"termux-dialog", "text", "-t", title, "-i", hint, "-p"}
-	cmd := exec.Command(args[0], args[1:]...)
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
+// PasswordDialogContext is like PasswordDialog but takes a context. The
+// command is killed if ctx is done before it completes.
+func PasswordDialogContext(ctx context.Context, title, hint string) (string, error) {
+	return Default().PasswordDialogContext(ctx, title, hint)
+}
 
-	var result DialogResult
-	if err := json.Unmarshal(output, &result); err != nil {
-		return "", err
-	}
+// PasswordDialog shows a password input dialog (hidden text entry).
+func (c *Client) PasswordDialog(title, hint string) (string, error) {
+	return c.PasswordDialogContext(context.Background(), title, hint)
+}
 
-	return result.Text, nil
+// PasswordDialogContext is like PasswordDialog but takes a context.
+func (c *Client) PasswordDialogContext(ctx context.Context, title, hint string) (string, error) {
+	return c.textDialog(ctx, DialogSpec{Widget: DialogText, Title: title, Hint: hint, Password: true})
 }
 
 // RadioDialog shows a radio button dialog (single choice).
@@ -217,6 +288,8 @@ func PasswordDialog(title, hint string) (string, error) {
 //   - title: Dialog title
 //   - values: Comma-separated list of options
 //
+// Returns ErrCancelled if the user dismisses the dialog.
+//
 // If not running on Termux, returns an empty string.
 //
 // Example:
@@ -227,23 +300,23 @@ func PasswordDialog(title, hint string) (string, error) {
 //	}
 //	fmt.Println("Selected:", action)
 func RadioDialog(title string, values string) (string, error) {
-	if !IsTermux() {
-		return "", nil
-	}
+	return Default().RadioDialog(title, values)
+}
 
-	args := []string{"termux-dialog", "radio", "-t", title, "-v", values}
-	cmd := exec.Command(args[0], args[1:]...)
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
+// RadioDialogContext is like RadioDialog but takes a context. The command
+// is killed if ctx is done before it completes.
+func RadioDialogContext(ctx context.Context, title string, values string) (string, error) {
+	return Default().RadioDialogContext(ctx, title, values)
+}
 
-	var result DialogResult
-	if err := json.Unmarshal(output, &result); err != nil {
-		return "", err
-	}
+// RadioDialog shows a radio button dialog (single choice).
+func (c *Client) RadioDialog(title string, values string) (string, error) {
+	return c.RadioDialogContext(context.Background(), title, values)
+}
 
-	return result.Text, nil
+// RadioDialogContext is like RadioDialog but takes a context.
+func (c *Client) RadioDialogContext(ctx context.Context, title string, values string) (string, error) {
+	return c.textDialog(ctx, DialogSpec{Widget: DialogRadio, Title: title, Values: SplitDialogValues(values)})
 }
 
 // CheckboxDialog shows a checkbox dialog (multiple choice).
@@ -254,6 +327,8 @@ func RadioDialog(title string, values string) (string, error) {
 //
 // Returns a slice of selected values.
 //
+// Returns ErrCancelled if the user dismisses the dialog.
+//
 // If not running on Termux, returns an empty slice.
 //
 // Example:
@@ -266,22 +341,34 @@ func RadioDialog(title string, values string) (string, error) {
 //	    fmt.Println("Selected:", opt)
 //	}
 func CheckboxDialog(title string, values string) ([]string, error) {
-	if !IsTermux() {
+	return Default().CheckboxDialog(title, values)
+}
+
+// CheckboxDialogContext is like CheckboxDialog but takes a context. The
+// command is killed if ctx is done before it completes.
+func CheckboxDialogContext(ctx context.Context, title string, values string) ([]string, error) {
+	return Default().CheckboxDialogContext(ctx, title, values)
+}
+
+// CheckboxDialog shows a checkbox dialog (multiple choice).
+func (c *Client) CheckboxDialog(title string, values string) ([]string, error) {
+	return c.CheckboxDialogContext(context.Background(), title, values)
+}
+
+// CheckboxDialogContext is like CheckboxDialog but takes a context.
+func (c *Client) CheckboxDialogContext(ctx context.Context, title string, values string) ([]string, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
 		return []string{}, nil
 	}
 
-	args := []string{"termux-dialog", "checkbox", "-t", title, "-v", values}
-	cmd := exec.Command(args[0], args[1:]...)
-	output, err := cmd.Output()
+	result, err := c.ShowDialogContext(ctx, DialogSpec{Widget: DialogCheckbox, Title: title, Values: SplitDialogValues(values)})
 	if err != nil {
 		return nil, err
 	}
 
-	var result DialogResult
-	if err := json.Unmarshal(output, &result); err != nil {
-		return nil, err
-	}
-
 	return result.Values, nil
 }
 
@@ -291,103 +378,108 @@ func CheckboxDialog(title string, values string) ([]string, error) {
 //   - title: Dialog title
 //   - values: Comma-separated list of options
 //
+// Returns ErrCancelled if the user dismisses the dialog.
+//
 // If not running on Termux, returns an empty string.
 //
 // Example:
 //
 //	model, err := termux.SpinnerDialog("Choose Model", "sonnet,opus,haiku")
 func SpinnerDialog(title string, values string) (string, error) {
-	if !IsTermux() {
-		return "", nil
-	}
+	return Default().SpinnerDialog(title, values)
+}
 
-	args := []string{"termux-dialog", "spinner", "-t", title, "-v", values}
-	cmd := exec.Command(args[0], args[1:]...)
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
+// SpinnerDialogContext is like SpinnerDialog but takes a context. The
+// command is killed if ctx is done before it completes.
+func SpinnerDialogContext(ctx context.Context, title string, values string) (string, error) {
+	return Default().SpinnerDialogContext(ctx, title, values)
+}
 
-	var result DialogResult
-	if err := json.Unmarshal(output, &result); err != nil {
-		return "", err
-	}
+// SpinnerDialog shows a dropdown spinner dialog.
+func (c *Client) SpinnerDialog(title string, values string) (string, error) {
+	return c.SpinnerDialogContext(context.Background(), title, values)
+}
 
-	return result.Text, nil
+// SpinnerDialogContext is like SpinnerDialog but takes a context.
+func (c *Client) SpinnerDialogContext(ctx context.Context, title string, values string) (string, error) {
+	return c.textDialog(ctx, DialogSpec{Widget: DialogSpinner, Title: title, Values: SplitDialogValues(values)})
 }
 
 // DateDialog shows a date picker dialog.
 //
-// Parameters:
-//   - title: Dialog title
-//   - defaultDate: Default date in YYYY-MM-DD format (empty for today)
+// The picker always starts at today; termux-dialog cannot preselect a
+// date.
 //
 // Returns the selected date in YYYY-MM-DD format.
 //
+// Returns ErrCancelled if the user dismisses the dialog.
+//
 // If not running on Termux, returns an empty string.
 //
 // Example:
 //
-//	date, err := termux.DateDialog("Select Date", "2025-10-30")
-func DateDialog(title, defaultDate string) (string, error) {
-	if !IsTermux() {
-		return "", nil
-	}
+//	date, err := termux.DateDialog("Select Date")
+func DateDialog(title string) (string, error) {
+	return Default().DateDialog(title)
+}
 
-	args := []string{"termux-dialog", "date", "-t", title}
-	if defaultDate != "" {
-		args = append(args, "-d", defaultDate)
-	}
+// DateDialogContext is like DateDialog but takes a context. The command is
+// killed if ctx is done before it completes.
+func DateDialogContext(ctx context.Context, title string) (string, error) {
+	return Default().DateDialogContext(ctx, title)
+}
 
-	cmd := exec.Command(args[0], args[1:]...)
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
+// DateDialog shows a date picker dialog.
+func (c *Client) DateDialog(title string) (string, error) {
+	return c.DateDialogContext(context.Background(), title)
+}
+
+// DateDialogContext is like DateDialog but takes a context.
+func (c *Client) DateDialogContext(ctx context.Context, title string) (string, error) {
+	if !c.IsTermux() {
+		return "", c.fallbackErr()
 	}
 
-	var result DialogResult
-	if err := json.Unmarshal(output, &result); err != nil {
+	result, err := c.ShowDialogContext(ctx, DialogSpec{Widget: DialogDate, Title: title})
+	if err != nil {
 		return "", err
 	}
 
-	return result.Text, nil
+	return result.Time.Format(dialogDateLayout), nil
 }
 
 // TimeDialog shows a time picker dialog.
 //
-// Parameters:
-//   - title: Dialog title
-//   - defaultTime: Default time in HH:MM format (empty for now)
+// The picker always starts at the current time; termux-dialog cannot
+// preselect a time.
 //
 // Returns the selected time in HH:MM format.
 //
+// Returns ErrCancelled if the user dismisses the dialog.
+//
 // If not running on Termux, returns an empty string.
 //
 // Example:
 //
-//	time, err := termux.TimeDialog("Select Time", "14:30")
-func TimeDialog(title, defaultTime string) (string, error) {
-	if !IsTermux() {
-		return "", nil
-	}
-
-	args := []string{"termux-dialog", "time", "-t", title}
-	if defaultTime != "" {
-		args = append(args, "-d", defaultTime)
-	}
+//	time, err := termux.TimeDialog("Select Time")
+func TimeDialog(title string) (string, error) {
+	return Default().TimeDialog(title)
+}
 
-	cmd := exec.Command(args[0], args[1:]...)
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
+// TimeDialogContext is like TimeDialog but takes a context. The command is
+// killed if ctx is done before it completes.
+func TimeDialogContext(ctx context.Context, title string) (string, error) {
+	return Default().TimeDialogContext(ctx, title)
+}
 
-	var result DialogResult
-	if err := json.Unmarshal(output, &result); err != nil {
-		return "", err
-	}
+// TimeDialog shows a time picker dialog.
+func (c *Client) TimeDialog(title string) (string, error) {
+	return c.TimeDialogContext(context.Background(), title)
+}
 
-	return result.Text, nil
+// TimeDialogContext is like TimeDialog but takes a context.
+func (c *Client) TimeDialogContext(ctx context.Context, title string) (string, error) {
+	return c.textDialog(ctx, DialogSpec{Widget: DialogTime, Title: title})
 }
 
 // CounterDialog shows a counter dialog with increment/decrement buttons.
@@ -399,47 +491,61 @@ func TimeDialog(title, defaultTime string) (string, error) {
 //
 // Returns the selected count as a string.
 //
+// Returns ErrCancelled if the user dismisses the dialog.
+//
 // If not running on Termux, returns "0".
 //
 // Example:
 //
 //	count, err := termux.CounterDialog("How many tasks?", 1, 10)
 func CounterDialog(title string, min, max int) (string, error) {
-	if !IsTermux() {
+	return Default().CounterDialog(title, min, max)
+}
+
+// CounterDialogContext is like CounterDialog but takes a context. The
+// command is killed if ctx is done before it completes.
+func CounterDialogContext(ctx context.Context, title string, min, max int) (string, error) {
+	return Default().CounterDialogContext(ctx, title, min, max)
+}
+
+// CounterDialog shows a counter dialog with increment/decrement buttons.
+func (c *Client) CounterDialog(title string, min, max int) (string, error) {
+	return c.CounterDialogContext(context.Background(), title, min, max)
+}
+
+// CounterDialogContext is like CounterDialog but takes a context.
+func (c *Client) CounterDialogContext(ctx context.Context, title string, min, max int) (string, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return "", err
+		}
 		return "0", nil
 	}
 
-	rangeStr := formatInt(min) + "," + formatInt(max)
-	args := []string{"termux-dialog", "counter", "-t", title, "-r", rangeStr}
-
-	cmd := exec.Command(args[0], args[1:]...)
-	output, err := cmd.Output()
+	result, err := c.ShowDialogContext(ctx, DialogSpec{Widget: DialogCounter, Title: title, Min: min, Max: max, Start: min})
 	if err != nil {
 		return "", err
 	}
 
-	var result DialogResult
-	if err := json.Unmarshal(output, &result); err != nil {
+	return result.Text, nil
+}
+
+// textDialog shows spec and returns the result text, or "" outside Termux.
+func (c *Client) textDialog(ctx context.Context, spec DialogSpec) (string, error) {
+	if !c.IsTermux() {
+		return "", c.fallbackErr()
+	}
+
+	result, err := c.ShowDialogContext(ctx, spec)
+	if err != nil {
 		return "", err
 	}
 
 	return result.Text, nil
 }
 
-// formatFloat converts a float64 to a string with reasonable precision.
+// formatFloat converts a float64 to the shortest string that parses back
+// to the same value (e.g., "1.25", "0.9", "2").
 func formatFloat(f float64) string {
-	// Simple implementation for common values
-	if f == 0.0 {
-		return "0.0"
-	}
-	if f == 1.0 {
-		return "1.0"
-	}
-	if f == 2.0 {
-		return "2.0"
-	}
-	// For other values, approximate with 1 decimal
-	intPart := int(f)
-	fracPart := int((f - float64(intPart)) * 10)
-	return formatInt(intPart) + "." + formatInt(fracPart)
+	return strconv.FormatFloat(f, 'f', -1, 64)
 }
@@ -0,0 +1,528 @@
+package termux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JobNetwork is the network a scheduled job waits for.
+type JobNetwork string
+
+const (
+	JobNetworkAny        JobNetwork = "any" // Any connection (termux-job-scheduler's default)
+	JobNetworkUnmetered  JobNetwork = "unmetered"
+	JobNetworkCellular   JobNetwork = "cellular"
+	JobNetworkNotRoaming JobNetwork = "not_roaming"
+	JobNetworkNone       JobNetwork = "none" // Run without waiting for a network
+)
+
+// MinJobPeriod is the shortest period Android allows for a periodic job;
+// ScheduleJob raises shorter periods to it.
+const MinJobPeriod = 15 * time.Minute
+
+// Job is a script scheduled to run in the background by Android's job
+// scheduler, whether or not Termux is open.
+type Job struct {
+	// ID identifies the job. Scheduling a job replaces any other with the
+	// same ID.
+	ID int
+
+	Script string        // Path of the script to run; made absolute
+	Period time.Duration // Run about this often, at least MinJobPeriod; 0 runs the job once
+
+	// Constraints the device must meet before the job runs.
+	Network         JobNetwork // "" for JobNetworkAny
+	Charging        bool       // Only while charging
+	Idle            bool       // Only while the device is idle
+	StorageNotLow   bool       // Only when storage is not low (Android 8+)
+	AllowLowBattery bool       // Also when the battery is low (Android 8+)
+
+	// Persisted keeps the job scheduled across reboots.
+	Persisted bool
+}
+
+// validate reports whether j can be scheduled. A period under a
+// millisecond is rejected rather than raised, as it is almost certainly a
+// unit mistake such as Period: 60.
+func (j Job) validate() error {
+	if j.ID < 0 {
+		return fmt.Errorf("termux: job ID %d is negative", j.ID)
+	}
+	if j.Period < 0 {
+		return fmt.Errorf("termux: job period %v is negative", j.Period)
+	}
+	if j.Period > 0 && j.Period < time.Millisecond {
+		return fmt.Errorf("termux: job period %v is under a millisecond", j.Period)
+	}
+	return nil
+}
+
+// args builds the termux-job-scheduler arguments that schedule j.
+func (j Job) args() []string {
+	args := []string{"--script", j.Script, "--job-id", formatInt(j.ID)}
+	if j.Period > 0 {
+		period := max(j.Period, MinJobPeriod)
+		args = append(args, "--period-ms", strconv.FormatInt(period.Milliseconds(), 10))
+	}
+	if j.Network != "" {
+		args = append(args, "--network", string(j.Network))
+	}
+	if j.Charging {
+		args = append(args, "--charging", "true")
+	}
+	if j.Idle {
+		args = append(args, "--idle", "true")
+	}
+	if j.StorageNotLow {
+		args = append(args, "--storage-not-low", "true")
+	}
+	if j.AllowLowBattery {
+		args = append(args, "--battery-not-low", "false")
+	}
+	if j.Persisted {
+		args = append(args, "--persisted", "true")
+	}
+	return args
+}
+
+// ScheduleJob schedules job.Script to run in the background, replacing
+// any job with the same ID. Use InstallJob to generate the script too.
+//
+// If not running on Termux, this is a no-op.
+//
+// Example:
+//
+//	err := termux.ScheduleJob(termux.Job{
+//	    ID:        1,
+//	    Script:    filepath.Join(home, "bin", "sync.sh"),
+//	    Period:    time.Hour,
+//	    Network:   termux.JobNetworkUnmetered,
+//	    Persisted: true,
+//	})
+func ScheduleJob(job Job) error {
+	return Default().ScheduleJob(job)
+}
+
+// ScheduleJobContext is like ScheduleJob but takes a context. The command
+// is killed if ctx is done before it completes.
+func ScheduleJobContext(ctx context.Context, job Job) error {
+	return Default().ScheduleJobContext(ctx, job)
+}
+
+// ScheduleJob schedules job.Script to run in the background.
+func (c *Client) ScheduleJob(job Job) error {
+	return c.ScheduleJobContext(context.Background(), job)
+}
+
+// ScheduleJobContext is like ScheduleJob but takes a context.
+func (c *Client) ScheduleJobContext(ctx context.Context, job Job) error {
+	if job.Script == "" {
+		return errors.New("termux: ScheduleJob needs a script")
+	}
+	if err := job.validate(); err != nil {
+		return err
+	}
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	abs, err := filepath.Abs(job.Script)
+	if err != nil {
+		return err
+	}
+	job.Script = abs
+	output, err := c.run(ctx, "termux-job-scheduler", job.args()...)
+	if err != nil {
+		return err
+	}
+	return jobOutputErr(output)
+}
+
+// InstallJob writes script to job.Script and schedules it. An empty
+// job.Script defaults to JobScriptPath(job.ID). Reinstalling a job
+// rewrites its script and replaces the schedule.
+//
+// If not running on Termux, this is a no-op and no script is written.
+//
+// Example:
+//
+//	exe, _ := os.Executable()
+//	err := termux.InstallJob(termux.Job{ID: 1, Period: time.Hour, Persisted: true}, termux.JobScript{
+//	    Command:  []string{exe, "sync"},
+//	    LogFile:  filepath.Join(dataDir, "sync.log"),
+//	    WakeLock: true,
+//	})
+func InstallJob(job Job, script JobScript) error {
+	return Default().InstallJob(job, script)
+}
+
+// InstallJobContext is like InstallJob but takes a context. The command is
+// killed if ctx is done before it completes.
+func InstallJobContext(ctx context.Context, job Job, script JobScript) error {
+	return Default().InstallJobContext(ctx, job, script)
+}
+
+// InstallJob writes script to job.Script and schedules it.
+func (c *Client) InstallJob(job Job, script JobScript) error {
+	return c.InstallJobContext(context.Background(), job, script)
+}
+
+// InstallJobContext is like InstallJob but takes a context.
+func (c *Client) InstallJobContext(ctx context.Context, job Job, script JobScript) error {
+	if err := job.validate(); err != nil {
+		return err
+	}
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	if job.Script == "" {
+		path, err := JobScriptPath(job.ID)
+		if err != nil {
+			return err
+		}
+		job.Script = path
+	}
+	if err := script.WriteFile(job.Script); err != nil {
+		return err
+	}
+	return c.ScheduleJobContext(ctx, job)
+}
+
+// JobScriptPath returns where InstallJob writes the script of job id by
+// default: ~/.termux/jobs/job-<id>.sh.
+func JobScriptPath(id int) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".termux", "jobs", "job-"+formatInt(id)+".sh"), nil
+}
+
+// ListJobs returns the pending scheduled jobs. Network is not reported,
+// and AllowLowBattery and StorageNotLow only on Android 8+.
+//
+// If not running on Termux, returns an empty list.
+func ListJobs() ([]Job, error) {
+	return Default().ListJobs()
+}
+
+// ListJobsContext is like ListJobs but takes a context. The command is
+// killed if ctx is done before it completes.
+func ListJobsContext(ctx context.Context) ([]Job, error) {
+	return Default().ListJobsContext(ctx)
+}
+
+// ListJobs returns the pending scheduled jobs.
+func (c *Client) ListJobs() ([]Job, error) {
+	return c.ListJobsContext(context.Background())
+}
+
+// ListJobsContext is like ListJobs but takes a context.
+func (c *Client) ListJobsContext(ctx context.Context) ([]Job, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return []Job{}, nil
+	}
+
+	output, err := c.run(ctx, "termux-job-scheduler", "--pending")
+	if err != nil {
+		return nil, err
+	}
+	return parsePendingJobs(string(output))
+}
+
+// CancelJob cancels the scheduled job with the given ID. Its script is
+// left in place.
+//
+// If not running on Termux, this is a no-op.
+func CancelJob(id int) error {
+	return Default().CancelJob(id)
+}
+
+// CancelJobContext is like CancelJob but takes a context. The command is
+// killed if ctx is done before it completes.
+func CancelJobContext(ctx context.Context, id int) error {
+	return Default().CancelJobContext(ctx, id)
+}
+
+// CancelJob cancels the scheduled job with the given ID.
+func (c *Client) CancelJob(id int) error {
+	return c.CancelJobContext(context.Background(), id)
+}
+
+// CancelJobContext is like CancelJob but takes a context.
+func (c *Client) CancelJobContext(ctx context.Context, id int) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	_, err := c.run(ctx, "termux-job-scheduler", "--cancel", "--job-id", formatInt(id))
+	return err
+}
+
+// CancelAllJobs cancels every job scheduled through Termux, including
+// other programs' jobs.
+//
+// If not running on Termux, this is a no-op.
+func CancelAllJobs() error {
+	return Default().CancelAllJobs()
+}
+
+// CancelAllJobsContext is like CancelAllJobs but takes a context. The
+// command is killed if ctx is done before it completes.
+func CancelAllJobsContext(ctx context.Context) error {
+	return Default().CancelAllJobsContext(ctx)
+}
+
+// CancelAllJobs cancels every job scheduled through Termux.
+func (c *Client) CancelAllJobs() error {
+	return c.CancelAllJobsContext(context.Background())
+}
+
+// CancelAllJobsContext is like CancelAllJobs but takes a context.
+func (c *Client) CancelAllJobsContext(ctx context.Context) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	_, err := c.run(ctx, "termux-job-scheduler", "--cancel-all")
+	return err
+}
+
+// jobOutputErr checks the message termux-job-scheduler prints after
+// scheduling, which ends in "response: 1" when the job was accepted.
+func jobOutputErr(output []byte) error {
+	msg := strings.TrimSpace(string(output))
+	if i := strings.LastIndex(msg, "response:"); i >= 0 {
+		if strings.TrimSpace(msg[i+len("response:"):]) == "1" {
+			return nil
+		}
+		return fmt.Errorf("termux: termux-job-scheduler rejected the job: %s", msg)
+	}
+	lower := strings.ToLower(msg)
+	if strings.HasPrefix(lower, "error") || strings.Contains(lower, "no such file") {
+		return fmt.Errorf("termux: termux-job-scheduler: %s", msg)
+	}
+	return nil
+}
+
+// parsePendingJobs parses termux-job-scheduler --pending output:
+//
+//	Pending Job 1: /data/.../sync.sh (periodic: 3600000ms) (persisted) (battery not low)
+//
+// The flags are read from the end of the line, so a script path
+// containing " (" is kept whole.
+func parsePendingJobs(output string) ([]Job, error) {
+	jobs := []Job{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "Pending ")
+		rest, ok := strings.CutPrefix(line, "Job ")
+		if !ok {
+			continue
+		}
+		idText, rest, ok := strings.Cut(rest, ": ")
+		id, err := strconv.Atoi(idText)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("termux: bad pending job %q", line)
+		}
+
+		job := Job{ID: id}
+		var flags []string
+		for strings.HasSuffix(rest, ")") {
+			i := strings.LastIndex(rest, " (")
+			if i < 0 {
+				break
+			}
+			flags = append(flags, strings.TrimSpace(rest[i+2:len(rest)-1]))
+			rest = strings.TrimSpace(rest[:i])
+		}
+		job.Script = rest
+		for _, flag := range flags {
+			if ms, ok := strings.CutPrefix(flag, "periodic: "); ok {
+				n, err := strconv.ParseInt(strings.TrimSuffix(ms, "ms"), 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("termux: bad job period %q", flag)
+				}
+				job.Period = time.Duration(n) * time.Millisecond
+			}
+		}
+		job.Charging = slices.Contains(flags, "while charging")
+		job.Idle = slices.Contains(flags, "while idle")
+		job.Persisted = slices.Contains(flags, "persisted")
+		job.StorageNotLow = slices.Contains(flags, "storage not low")
+		job.AllowLowBattery = !slices.Contains(flags, "battery not low")
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// JobScript is a shell script for a scheduled job to run. Jobs start
+// without a terminal, in the home directory and with only Termux's base
+// environment, so the script sets up what the command needs.
+type JobScript struct {
+	Command []string          // Program and arguments to run
+	Dir     string            // Working directory; "" for the home directory
+	Env     map[string]string // Extra environment variables
+	LogFile string            // Append the command's output here; "" leaves it unredirected
+
+	// WakeLock holds the Termux wake lock while the command runs, so the
+	// device cannot sleep mid-job. It is released when the command exits,
+	// even if a foreground program still wants it.
+	WakeLock bool
+}
+
+// String renders the script. Environment variables whose names Validate
+// rejects are left out.
+//
+// Example output:
+//
+//	#!/data/data/com.termux/files/usr/bin/sh
+//	# Scheduled with termux-job-scheduler. Generated; edits are overwritten.
+//	cd /data/data/com.termux/files/home/notes || exit 1
+//	export NOTES_SYNC=1
+//	termux-wake-lock
+//	/data/data/com.termux/files/home/go/bin/notes sync >>sync.log 2>&1
+//	status=$?
+//	termux-wake-unlock
+//	exit $status
+func (s JobScript) String() string {
+	return s.render("Scheduled with termux-job-scheduler.")
+}
+
+// generatedNote ends the comment heading every generated script; it marks
+// files that may be overwritten.
+const generatedNote = " Generated; edits are overwritten."
+
+// render renders the script under a comment line of header and
+// generatedNote.
+func (s JobScript) render(header string) string {
+	var b strings.Builder
+	b.WriteString("#!" + filepath.Join(termuxPrefix(), "bin", "sh") + "\n")
+	b.WriteString("# " + header + generatedNote + "\n")
+	if s.Dir != "" {
+		b.WriteString("cd " + ShellQuote(s.Dir) + " || exit 1\n")
+	}
+	keys, _ := s.envKeys()
+	for _, k := range keys {
+		b.WriteString("export " + k + "=" + ShellQuote(s.Env[k]) + "\n")
+	}
+	if s.WakeLock {
+		b.WriteString("termux-wake-lock\n")
+	}
+	if len(s.Command) > 0 {
+		b.WriteString(ShellCommand(s.Command[0], s.Command[1:]...))
+		if s.LogFile != "" {
+			b.WriteString(" >>" + ShellQuote(s.LogFile) + " 2>&1")
+		}
+		b.WriteString("\nstatus=$?\n")
+	} else {
+		b.WriteString("status=0\n")
+	}
+	if s.WakeLock {
+		b.WriteString("termux-wake-unlock\n")
+	}
+	b.WriteString("exit $status\n")
+	return b.String()
+}
+
+// Validate reports whether the script can be rendered: it needs a
+// command, and environment variable names must be valid shell names.
+func (s JobScript) Validate() error {
+	if len(s.Command) == 0 || s.Command[0] == "" {
+		return errors.New("termux: JobScript needs a command")
+	}
+	_, err := s.envKeys()
+	return err
+}
+
+// envKeys returns the valid environment variable names in s.Env, sorted,
+// and an error for the first invalid one.
+func (s JobScript) envKeys() ([]string, error) {
+	keys := make([]string, 0, len(s.Env))
+	var bad []string
+	for k := range s.Env {
+		if isShellName(k) {
+			keys = append(keys, k)
+		} else {
+			bad = append(bad, k)
+		}
+	}
+	slices.Sort(keys)
+	if len(bad) > 0 {
+		return keys, fmt.Errorf("termux: JobScript: bad environment variable name %q", slices.Min(bad))
+	}
+	return keys, nil
+}
+
+// WriteFile validates the script and writes it to path as an executable
+// file, creating the directory if needed. The file is replaced
+// atomically, so a job starting meanwhile runs the old or new script,
+// never a partial one.
+func (s JobScript) WriteFile(path string) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	return writeExecutable(path, []byte(s.String()))
+}
+
+// writeExecutable atomically writes data to path with mode 0700.
+func writeExecutable(path string, data []byte) error {
+	return writeFileAtomic(path, data, 0o700)
+}
+
+// writeFileAtomic writes data to path with mode perm by renaming a
+// temporary file over it, creating the directory if needed.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), perm)
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// isShellName reports whether s is a valid shell variable name.
+func isShellName(s string) bool {
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// termuxPrefix returns the Termux installation prefix ($PREFIX).
+func termuxPrefix() string {
+	if prefix := os.Getenv("PREFIX"); prefix != "" {
+		return prefix
+	}
+	return "/data/data/com.termux/files/usr"
+}
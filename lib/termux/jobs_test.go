@@ -0,0 +1,162 @@
+package termux_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestScheduleJob(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-job-scheduler", "Scheduling Job 7: /home/sync.sh (periodic: 3600000ms) - response: 1")
+	fake.Respond("termux-job-scheduler", "Scheduling Job 7: /home/sync.sh - response: 0")
+	client := termux.NewClient(fake)
+
+	job := termux.Job{
+		ID:              7,
+		Script:          "/home/sync.sh",
+		Period:          time.Hour,
+		Network:         termux.JobNetworkUnmetered,
+		Charging:        true,
+		Idle:            true,
+		AllowLowBattery: true,
+		Persisted:       true,
+	}
+	if err := client.ScheduleJob(job); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(fake.CallsTo("termux-job-scheduler")[0].Args, " ")
+	want := "--script /home/sync.sh --job-id 7 --period-ms 3600000 --network unmetered --charging true --idle true --battery-not-low false --persisted true"
+	if got != want {
+		t.Errorf("args = %q\nwant   %q", got, want)
+	}
+
+	if err := client.ScheduleJob(job); err == nil {
+		t.Error("rejected job reported as scheduled")
+	}
+}
+
+func TestScheduleJobPeriod(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-job-scheduler", "Scheduling Job 1: /home/sync.sh (periodic: 900000ms) - response: 1")
+	client := termux.NewClient(fake)
+
+	if err := client.ScheduleJob(termux.Job{ID: 1, Script: "/home/sync.sh", Period: time.Minute}); err != nil {
+		t.Fatal(err)
+	}
+	args := fake.CallsTo("termux-job-scheduler")[0].Args
+	if got := strings.Join(args, " "); !strings.Contains(got, "--period-ms 900000") {
+		t.Errorf("args = %q, want the period raised to MinJobPeriod", got)
+	}
+
+	for _, period := range []time.Duration{-time.Hour, time.Microsecond, 60} {
+		if err := client.ScheduleJob(termux.Job{ID: 1, Script: "/home/sync.sh", Period: period}); err == nil {
+			t.Errorf("period %v accepted", period)
+		}
+	}
+	if calls := fake.CallsTo("termux-job-scheduler"); len(calls) != 1 {
+		t.Errorf("scheduled %d times, want bad periods rejected before running", len(calls))
+	}
+}
+
+func TestListAndCancelJobs(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-job-scheduler", strings.Join([]string{
+		"Pending Job 1: /home/.termux/jobs/job-1.sh (periodic: 900000ms) (persisted) (battery not low) (network: NetworkRequest [ NONE ])",
+		"Pending Job 2: /home/remind.sh (while charging) (while idle)",
+		"Pending Job 3: /home/my (old) jobs/run.sh (persisted)",
+	}, "\n"))
+	client := termux.NewClient(fake)
+
+	jobs, err := client.ListJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("got %d jobs, want 3", len(jobs))
+	}
+	want := termux.Job{ID: 1, Script: "/home/.termux/jobs/job-1.sh", Period: 15 * time.Minute, Persisted: true}
+	if jobs[0] != want {
+		t.Errorf("jobs[0] = %+v\nwant      %+v", jobs[0], want)
+	}
+	if j := jobs[1]; !j.Charging || !j.Idle || j.Period != 0 || !j.AllowLowBattery {
+		t.Errorf("jobs[1] = %+v", j)
+	}
+	if j := jobs[2]; j.Script != "/home/my (old) jobs/run.sh" || !j.Persisted {
+		t.Errorf("jobs[2] = %+v", j)
+	}
+
+	client.CancelJob(2)
+	client.CancelAllJobs()
+	calls := fake.CallsTo("termux-job-scheduler")
+	if got := strings.Join(calls[1].Args, " "); got != "--cancel --job-id 2" {
+		t.Errorf("cancel args = %q", got)
+	}
+	if got := calls[2].Args; len(got) != 1 || got[0] != "--cancel-all" {
+		t.Errorf("cancel-all args = %q", got)
+	}
+}
+
+func TestJobScript(t *testing.T) {
+	t.Setenv("PREFIX", "/usr")
+	script := termux.JobScript{
+		Command:  []string{"/home/go/bin/notes", "sync", "--note=it's"},
+		Dir:      "/home/notes dir",
+		Env:      map[string]string{"B": "2", "A": "one two"},
+		LogFile:  "/home/sync.log",
+		WakeLock: true,
+	}
+	want := `#!/usr/bin/sh
+# Scheduled with termux-job-scheduler. Generated; edits are overwritten.
+cd '/home/notes dir' || exit 1
+export A='one two'
+export B=2
+termux-wake-lock
+/home/go/bin/notes sync '--note=it'\''s' >>/home/sync.log 2>&1
+status=$?
+termux-wake-unlock
+exit $status
+`
+	if got := script.String(); got != want {
+		t.Errorf("script:\n%s\nwant:\n%s", got, want)
+	}
+
+	bad := termux.JobScript{Command: []string{"x"}, Env: map[string]string{"A; rm": "1", "B": "2"}}
+	if err := bad.Validate(); err == nil {
+		t.Error("bad variable name accepted")
+	}
+	if got := bad.String(); strings.Contains(got, "A; rm") || !strings.Contains(got, "export B=2\n") {
+		t.Errorf("script with a bad variable name:\n%s", got)
+	}
+	if err := (termux.JobScript{}).Validate(); err == nil {
+		t.Error("script without a command accepted")
+	}
+}
+
+func TestInstallJob(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-job-scheduler", "Scheduling Job 3: ... - response: 1")
+
+	err := termux.NewClient(fake).InstallJob(termux.Job{ID: 3, Period: time.Hour}, termux.JobScript{Command: []string{"true"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(home, ".termux", "jobs", "job-3.sh")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("script mode = %v, want executable", info.Mode())
+	}
+	if args := fake.CallsTo("termux-job-scheduler")[0].Args; args[1] != path {
+		t.Errorf("scheduled script = %q, want %q", args[1], path)
+	}
+}
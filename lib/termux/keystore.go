@@ -0,0 +1,330 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// KeyAlgorithm is the type of key GenerateKey creates.
+type KeyAlgorithm string
+
+const (
+	KeyRSA KeyAlgorithm = "RSA" // termux-keystore's default
+	KeyEC  KeyAlgorithm = "EC"
+)
+
+// Signature algorithms accepted by Sign and Verify. RSA keys take the
+// "withRSA" ones and EC keys the "withECDSA" ones.
+const (
+	SignSHA256WithRSA   = "SHA256withRSA"
+	SignSHA384WithRSA   = "SHA384withRSA"
+	SignSHA512WithRSA   = "SHA512withRSA"
+	SignSHA256WithECDSA = "SHA256withECDSA"
+	SignSHA384WithECDSA = "SHA384withECDSA"
+	SignSHA512WithECDSA = "SHA512withECDSA"
+)
+
+// KeyOptions configures GenerateKey. The zero value creates a 2048-bit
+// RSA key usable without authentication.
+type KeyOptions struct {
+	Algorithm KeyAlgorithm
+	Size      int // Bits: 2048, 3072 or 4096 for RSA; 256, 384 or 521 for EC
+
+	// AuthValidity requires the user to have unlocked the device (with
+	// fingerprint or screen lock) within this long before each use of the
+	// key. Zero means no authentication is required. Rounded up to whole
+	// seconds.
+	AuthValidity time.Duration
+}
+
+// args builds the termux-keystore generate arguments for alias.
+func (o KeyOptions) args(alias string) []string {
+	args := []string{"generate", alias}
+	if o.Algorithm != "" {
+		args = append(args, "-a", string(o.Algorithm))
+	}
+	if o.Size > 0 {
+		args = append(args, "-s", formatInt(o.Size))
+	}
+	if o.AuthValidity > 0 {
+		args = append(args, "-u", formatInt(int((o.AuthValidity+time.Second-1)/time.Second)))
+	}
+	return args
+}
+
+// KeyInfo describes a key in the Android keystore, as reported by
+// termux-keystore list.
+type KeyInfo struct {
+	Alias     string       `json:"alias"`
+	Algorithm KeyAlgorithm `json:"algorithm"`
+	Size      int          `json:"size"` // Bits
+
+	// InsideSecureHardware reports whether the key lives in a TEE or
+	// secure element rather than in software.
+	InsideSecureHardware bool `json:"inside_secure_hardware"`
+
+	UserAuthentication struct {
+		Required        bool `json:"required"`
+		ValiditySeconds int  `json:"validity_duration_seconds"`
+	} `json:"user_authentication"`
+}
+
+// ListKeys returns the keys in the Termux:API keystore. Keys never leave
+// the keystore; only their details are listed.
+//
+// If not running on Termux, returns an empty list.
+//
+// Example:
+//
+//	keys, _ := termux.ListKeys()
+//	for _, k := range keys {
+//	    fmt.Println(k.Alias, k.Algorithm, k.Size, k.InsideSecureHardware)
+//	}
+func ListKeys() ([]KeyInfo, error) {
+	return Default().ListKeys()
+}
+
+// ListKeysContext is like ListKeys but takes a context. The command is
+// killed if ctx is done before it completes.
+func ListKeysContext(ctx context.Context) ([]KeyInfo, error) {
+	return Default().ListKeysContext(ctx)
+}
+
+// ListKeys returns the keys in the Termux:API keystore.
+func (c *Client) ListKeys() ([]KeyInfo, error) {
+	return c.ListKeysContext(context.Background())
+}
+
+// ListKeysContext is like ListKeys but takes a context.
+func (c *Client) ListKeysContext(ctx context.Context) ([]KeyInfo, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return []KeyInfo{}, nil
+	}
+
+	output, err := c.run(ctx, "termux-keystore", "list", "-d")
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []KeyInfo
+	if err := json.Unmarshal(output, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GenerateKey creates a key pair named alias in the Android keystore.
+// The private key cannot be exported; use Sign and Verify with it.
+//
+// If not running on Termux, this is a no-op.
+//
+// Example:
+//
+//	err := termux.GenerateKey("vault", termux.KeyOptions{
+//	    Algorithm:    termux.KeyEC,
+//	    Size:         256,
+//	    AuthValidity: 30 * time.Second, // Sign only shortly after Unlock
+//	})
+func GenerateKey(alias string, opts KeyOptions) error {
+	return Default().GenerateKey(alias, opts)
+}
+
+// GenerateKeyContext is like GenerateKey but takes a context. The command
+// is killed if ctx is done before it completes.
+func GenerateKeyContext(ctx context.Context, alias string, opts KeyOptions) error {
+	return Default().GenerateKeyContext(ctx, alias, opts)
+}
+
+// GenerateKey creates a key pair named alias in the Android keystore.
+func (c *Client) GenerateKey(alias string, opts KeyOptions) error {
+	return c.GenerateKeyContext(context.Background(), alias, opts)
+}
+
+// GenerateKeyContext is like GenerateKey but takes a context.
+func (c *Client) GenerateKeyContext(ctx context.Context, alias string, opts KeyOptions) error {
+	if alias == "" {
+		return errors.New("termux: GenerateKey needs an alias")
+	}
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	output, err := c.run(ctx, "termux-keystore", opts.args(alias)...)
+	if err != nil {
+		return err
+	}
+	return keystoreOutputErr(output)
+}
+
+// DeleteKey removes the key named alias from the Android keystore. This
+// cannot be undone: data signed with or locked to the key is orphaned.
+//
+// If not running on Termux, this is a no-op.
+func DeleteKey(alias string) error {
+	return Default().DeleteKey(alias)
+}
+
+// DeleteKeyContext is like DeleteKey but takes a context. The command is
+// killed if ctx is done before it completes.
+func DeleteKeyContext(ctx context.Context, alias string) error {
+	return Default().DeleteKeyContext(ctx, alias)
+}
+
+// DeleteKey removes the key named alias from the Android keystore.
+func (c *Client) DeleteKey(alias string) error {
+	return c.DeleteKeyContext(context.Background(), alias)
+}
+
+// DeleteKeyContext is like DeleteKey but takes a context.
+func (c *Client) DeleteKeyContext(ctx context.Context, alias string) error {
+	if alias == "" {
+		return errors.New("termux: DeleteKey needs an alias")
+	}
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	output, err := c.run(ctx, "termux-keystore", "delete", alias)
+	if err != nil {
+		return err
+	}
+	return keystoreOutputErr(output)
+}
+
+// Sign signs data with the private key named alias using algorithm (one
+// of the Sign* constants) and returns the signature.
+//
+// If the key requires authentication and the device was not unlocked
+// recently enough, ErrPermissionDenied is returned; call Unlock and retry.
+//
+// Sign fails closed: if not running on Termux it returns ErrNotTermux,
+// even outside strict mode.
+//
+// Example:
+//
+//	sig, err := termux.Sign("vault", termux.SignSHA256WithECDSA, challenge)
+func Sign(alias, algorithm string, data []byte) ([]byte, error) {
+	return Default().Sign(alias, algorithm, data)
+}
+
+// SignContext is like Sign but takes a context. The command is killed if
+// ctx is done before it completes.
+func SignContext(ctx context.Context, alias, algorithm string, data []byte) ([]byte, error) {
+	return Default().SignContext(ctx, alias, algorithm, data)
+}
+
+// Sign signs data with the private key named alias.
+func (c *Client) Sign(alias, algorithm string, data []byte) ([]byte, error) {
+	return c.SignContext(context.Background(), alias, algorithm, data)
+}
+
+// SignContext is like Sign but takes a context.
+func (c *Client) SignContext(ctx context.Context, alias, algorithm string, data []byte) ([]byte, error) {
+	if alias == "" || algorithm == "" {
+		return nil, errors.New("termux: Sign needs a key alias and an algorithm")
+	}
+	if !c.IsTermux() {
+		return nil, ErrNotTermux
+	}
+
+	output, err := c.runInput(ctx, data, "termux-keystore", "sign", alias, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	// A signature is binary; a failure is reported as text and leaves
+	// no signature
+	if len(output) == 0 {
+		return nil, fmt.Errorf("termux: termux-keystore sign: no signature for key %q", alias)
+	}
+	if isKeystoreMessage(output) {
+		return nil, keystoreOutputErr(output)
+	}
+	return output, nil
+}
+
+// Verify reports whether signature is a valid signature of data by the
+// key named alias, using algorithm (one of the Sign* constants).
+//
+// Verify fails closed: if not running on Termux it returns ErrNotTermux,
+// even outside strict mode, rather than reporting a bad signature.
+func Verify(alias, algorithm string, data, signature []byte) (bool, error) {
+	return Default().Verify(alias, algorithm, data, signature)
+}
+
+// VerifyContext is like Verify but takes a context. The command is killed
+// if ctx is done before it completes.
+func VerifyContext(ctx context.Context, alias, algorithm string, data, signature []byte) (bool, error) {
+	return Default().VerifyContext(ctx, alias, algorithm, data, signature)
+}
+
+// Verify reports whether signature is a valid signature of data.
+func (c *Client) Verify(alias, algorithm string, data, signature []byte) (bool, error) {
+	return c.VerifyContext(context.Background(), alias, algorithm, data, signature)
+}
+
+// VerifyContext is like Verify but takes a context.
+func (c *Client) VerifyContext(ctx context.Context, alias, algorithm string, data, signature []byte) (bool, error) {
+	if alias == "" || algorithm == "" {
+		return false, errors.New("termux: Verify needs a key alias and an algorithm")
+	}
+	if !c.IsTermux() {
+		return false, ErrNotTermux
+	}
+
+	// termux-keystore reads the signature from a file and the data from
+	// stdin
+	sigFile, err := os.CreateTemp("", "termux-signature-*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(sigFile.Name())
+	_, err = sigFile.Write(signature)
+	if closeErr := sigFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, err
+	}
+
+	output, err := c.runInput(ctx, data, "termux-keystore", "verify", alias, algorithm, sigFile.Name())
+	if err != nil {
+		return false, err
+	}
+	switch strings.TrimSpace(string(output)) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, keystoreOutputErr(output)
+}
+
+// isKeystoreMessage reports whether output is one of the text messages
+// termux-keystore prints on failure rather than signature bytes.
+func isKeystoreMessage(output []byte) bool {
+	text := strings.ToLower(strings.TrimSpace(string(output)))
+	return strings.HasPrefix(text, "error") || strings.Contains(text, "exception")
+}
+
+// keystoreOutputErr turns the message termux-keystore prints on failure
+// into an error. Authentication-bound keys used too long after the last
+// unlock fail with a UserNotAuthenticatedException, reported as
+// ErrPermissionDenied.
+func keystoreOutputErr(output []byte) error {
+	msg := strings.TrimSpace(string(output))
+	switch {
+	case msg == "":
+		return nil
+	case strings.Contains(msg, "UserNotAuthenticated"):
+		return fmt.Errorf("%w: termux-keystore: %s", ErrPermissionDenied, msg)
+	}
+	return fmt.Errorf("termux: termux-keystore: %s", msg)
+}
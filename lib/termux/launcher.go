@@ -0,0 +1,376 @@
+package termux
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// LauncherKind is where a launcher script is installed, and so how it
+// starts.
+type LauncherKind string
+
+const (
+	// LauncherShortcut is a Termux:Widget shortcut in ~/.shortcuts. Tapping
+	// it opens a terminal session running the script, so it suits the TUI.
+	LauncherShortcut LauncherKind = "shortcut"
+
+	// LauncherTask is a Termux:Widget task in ~/.shortcuts/tasks. Tapping
+	// it runs the script in the background, without a terminal.
+	LauncherTask LauncherKind = "task"
+
+	// LauncherBoot is a Termux:Boot script in ~/.termux/boot, run without
+	// a terminal when the device starts. Boot scripts run in name order.
+	LauncherBoot LauncherKind = "boot"
+)
+
+// LauncherKinds lists every LauncherKind.
+var LauncherKinds = []LauncherKind{LauncherShortcut, LauncherTask, LauncherBoot}
+
+// ErrLauncherConflict means a launcher's path holds a file that was not
+// generated by InstallLauncher, which is left alone unless Launcher.Force
+// is set.
+var ErrLauncherConflict = errors.New("termux: launcher path holds a file not generated by InstallLauncher")
+
+// Launcher names a launcher script and where it goes.
+type Launcher struct {
+	Kind LauncherKind
+	Name string // File name of the script, shown as the widget label
+
+	// Icon is a PNG file shown for a shortcut or task in the widget. It is
+	// copied to ~/.shortcuts/icons/<Name>.png, and the script records that
+	// it did so. Boot scripts have no icon.
+	Icon string
+
+	// Force replaces or removes a file at the launcher's path even if
+	// InstallLauncher did not generate it.
+	Force bool
+}
+
+// header is the comment line heading the script of l.
+func (l Launcher) header() string {
+	header := "Termux:Widget shortcut"
+	switch l.Kind {
+	case LauncherTask:
+		header = "Termux:Widget task"
+	case LauncherBoot:
+		header = "Termux:Boot script"
+	}
+	if l.Icon != "" {
+		header += iconNote
+	}
+	return header + "."
+}
+
+// iconNote follows the kind in the header of a script whose icon was
+// installed with it. Only such icons are ever removed.
+const iconNote = " with icon"
+
+// validate reports whether l names a valid launcher.
+func (l Launcher) validate() error {
+	if !slices.Contains(LauncherKinds, l.Kind) {
+		return fmt.Errorf("termux: unknown launcher kind %q", l.Kind)
+	}
+	// Names starting with "." are hidden from the widget
+	if l.Name == "" || strings.HasPrefix(l.Name, ".") || strings.ContainsAny(l.Name, "/\x00") {
+		return fmt.Errorf("termux: bad launcher name %q", l.Name)
+	}
+	// The widget keeps tasks and icons in these directories of ~/.shortcuts
+	if l.Kind == LauncherShortcut && (l.Name == "tasks" || l.Name == "icons") {
+		return fmt.Errorf("termux: launcher name %q is reserved for shortcuts", l.Name)
+	}
+	if l.Icon != "" && l.Kind == LauncherBoot {
+		return errors.New("termux: boot scripts have no icon")
+	}
+	return nil
+}
+
+// LauncherPath returns where a launcher of the given kind and name is
+// installed: ~/.shortcuts/<name>, ~/.shortcuts/tasks/<name> or
+// ~/.termux/boot/<name>.
+func LauncherPath(kind LauncherKind, name string) (string, error) {
+	if err := (Launcher{Kind: kind, Name: name}).validate(); err != nil {
+		return "", err
+	}
+	dir, err := launcherDir(kind)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// LauncherIconPath returns where the widget looks for the icon of the
+// shortcut or task named name: ~/.shortcuts/icons/<name>.png.
+func LauncherIconPath(name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".shortcuts", "icons", name+".png"), nil
+}
+
+// launcherDir returns the directory launchers of kind are installed in.
+func launcherDir(kind LauncherKind) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	switch kind {
+	case LauncherTask:
+		return filepath.Join(home, ".shortcuts", "tasks"), nil
+	case LauncherBoot:
+		return filepath.Join(home, ".termux", "boot"), nil
+	}
+	return filepath.Join(home, ".shortcuts"), nil
+}
+
+// InstallLauncher writes script as the launcher l, with executable
+// permissions, and copies its icon. Without an icon, one an earlier
+// InstallLauncher of l installed is removed unless the shortcut or task of
+// the same name still uses it; an icon put there any other way is left
+// alone. It is idempotent: files already up to date are left untouched,
+// and changed reports whether anything was written. Scripts are replaced atomically, so a tap meanwhile runs the
+// old or new script, never a partial one.
+//
+// Launchers are plain files, so they are written even when not running
+// on Termux; they only take effect with the Termux:Widget or Termux:Boot
+// app installed. Tasks and boot scripts run without a terminal: give them
+// a headless mode of the program, and a LogFile to see its output.
+//
+// Example:
+//
+//	exe, _ := os.Executable()
+//	changed, err := termux.InstallLauncher(termux.Launcher{
+//	    Kind: termux.LauncherShortcut,
+//	    Name: "Notes",
+//	    Icon: "assets/notes.png",
+//	}, termux.JobScript{Command: []string{exe}})
+func InstallLauncher(l Launcher, script JobScript) (changed bool, err error) {
+	if err := l.validate(); err != nil {
+		return false, err
+	}
+	if err := script.Validate(); err != nil {
+		return false, err
+	}
+	path, err := LauncherPath(l.Kind, l.Name)
+	if err != nil {
+		return false, err
+	}
+
+	var icon []byte
+	if l.Icon != "" {
+		if icon, err = os.ReadFile(l.Icon); err != nil {
+			return false, err
+		}
+		if !bytes.HasPrefix(icon, pngSignature) {
+			return false, fmt.Errorf("termux: launcher icon %s is not a PNG", l.Icon)
+		}
+	}
+
+	data := []byte(script.render(l.header()))
+	current, err := readLauncher(path, l.Force)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(current, data) || !isExecutable(path) {
+		if err := writeExecutable(path, data); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	if l.Kind == LauncherBoot {
+		return changed, nil
+	}
+	iconPath, err := LauncherIconPath(l.Name)
+	if err != nil {
+		return changed, err
+	}
+	if icon == nil {
+		if !ownsIcon(current) {
+			return changed, nil
+		}
+		shared, err := siblingInstalled(l)
+		if err != nil || shared {
+			return changed, err
+		}
+		if err := os.Remove(iconPath); err == nil {
+			changed = true
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return changed, err
+		}
+		return changed, nil
+	}
+	if current, err := os.ReadFile(iconPath); err != nil || !bytes.Equal(current, icon) {
+		if err := writeFileAtomic(iconPath, icon, 0o600); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// RemoveLauncher removes the launcher l and, for a shortcut or task
+// whose name no other widget launcher uses, the icon InstallLauncher
+// installed with it. A launcher that is not installed is not an error.
+//
+// Example:
+//
+//	err := termux.RemoveLauncher(termux.Launcher{Kind: termux.LauncherBoot, Name: "notes-sync"})
+func RemoveLauncher(l Launcher) error {
+	if err := l.validate(); err != nil {
+		return err
+	}
+	path, err := LauncherPath(l.Kind, l.Name)
+	if err != nil {
+		return err
+	}
+	current, err := readLauncher(path, l.Force)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if l.Kind == LauncherBoot || !ownsIcon(current) {
+		return nil
+	}
+
+	if shared, err := siblingInstalled(l); err != nil || shared {
+		return err
+	}
+	iconPath, err := LauncherIconPath(l.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(iconPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// InstalledLauncher is a launcher script found by ListLaunchers.
+type InstalledLauncher struct {
+	Launcher         // Icon is the installed icon, or "" if there is none
+	Path      string // The script
+	Generated bool   // Written by InstallLauncher, rather than by hand
+}
+
+// ListLaunchers returns the installed shortcuts, tasks and boot scripts,
+// in that order and by name within each kind, including ones written by
+// hand.
+//
+// Example:
+//
+//	launchers, _ := termux.ListLaunchers()
+//	for _, l := range launchers {
+//	    fmt.Println(l.Kind, l.Name, l.Path)
+//	}
+func ListLaunchers() ([]InstalledLauncher, error) {
+	launchers := []InstalledLauncher{}
+	for _, kind := range LauncherKinds {
+		dir, err := launcherDir(kind)
+		if err != nil {
+			return nil, err
+		}
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			// Skip the tasks and icons directories, and temporary files
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			l := InstalledLauncher{
+				Launcher: Launcher{Kind: kind, Name: entry.Name()},
+				Path:     filepath.Join(dir, entry.Name()),
+			}
+			if kind != LauncherBoot {
+				if icon, err := LauncherIconPath(l.Name); err == nil && fileExists(icon) {
+					l.Icon = icon
+				}
+			}
+			if data, err := os.ReadFile(l.Path); err == nil {
+				l.Generated = isGeneratedScript(data)
+			}
+			launchers = append(launchers, l)
+		}
+	}
+	return launchers, nil
+}
+
+// siblingInstalled reports whether the shortcut or task l shares its
+// icon with an installed task or shortcut of the same name: one written
+// by hand, or generated with the icon.
+func siblingInstalled(l Launcher) (bool, error) {
+	other := LauncherShortcut
+	if l.Kind == LauncherShortcut {
+		other = LauncherTask
+	}
+	path, err := LauncherPath(other, l.Name)
+	if err != nil {
+		return false, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !isGeneratedScript(data) || ownsIcon(data), nil
+}
+
+// pngSignature starts every PNG file.
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// readLauncher returns the contents of the launcher script at path, or
+// nil if there is none. Unless force is set, a file InstallLauncher did
+// not generate is reported as ErrLauncherConflict.
+func readLauncher(path string, force bool) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !force && !isGeneratedScript(data) {
+		return nil, fmt.Errorf("%w: %s", ErrLauncherConflict, path)
+	}
+	return data, nil
+}
+
+// isGeneratedScript reports whether data is a script rendered from a
+// JobScript, whose second line is a comment ending in generatedNote.
+func isGeneratedScript(data []byte) bool {
+	lines := strings.SplitN(string(data), "\n", 3)
+	return len(lines) == 3 && strings.HasPrefix(lines[1], "# ") && strings.HasSuffix(lines[1], generatedNote)
+}
+
+// ownsIcon reports whether data is a generated script whose icon was
+// installed with it.
+func ownsIcon(data []byte) bool {
+	lines := strings.SplitN(string(data), "\n", 3)
+	return isGeneratedScript(data) && strings.Contains(lines[1], iconNote+".")
+}
+
+// isExecutable reports whether path is a file with mode 0700.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm() == 0o700
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
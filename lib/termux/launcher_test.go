@@ -0,0 +1,216 @@
+package termux_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+const testPNG = "\x89PNG\r\n\x1a\nicon"
+
+func TestInstallLauncher(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PREFIX", "/usr")
+	icon := filepath.Join(t.TempDir(), "notes.png")
+	if err := os.WriteFile(icon, []byte(testPNG), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := termux.Launcher{Kind: termux.LauncherShortcut, Name: "Notes", Icon: icon}
+	script := termux.JobScript{Command: []string{"/home/go/bin/notes"}}
+	changed, err := termux.InstallLauncher(l, script)
+	if err != nil || !changed {
+		t.Fatalf("InstallLauncher = %v, %v, want true, nil", changed, err)
+	}
+
+	path := filepath.Join(home, ".shortcuts", "Notes")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "#!/usr/bin/sh\n# Termux:Widget shortcut with icon. Generated; edits are overwritten.\n") {
+		t.Errorf("script:\n%s", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o700 {
+		t.Errorf("script mode = %v, want 0700", info.Mode())
+	}
+	if got, _ := os.ReadFile(filepath.Join(home, ".shortcuts", "icons", "Notes.png")); string(got) != testPNG {
+		t.Errorf("icon = %q, want %q", got, testPNG)
+	}
+
+	// Reinstalling is a no-op, but repairs permissions
+	if changed, err := termux.InstallLauncher(l, script); err != nil || changed {
+		t.Errorf("reinstall = %v, %v, want false, nil", changed, err)
+	}
+	os.Chmod(path, 0o600)
+	if changed, err := termux.InstallLauncher(l, script); err != nil || !changed {
+		t.Errorf("reinstall after chmod = %v, %v, want true, nil", changed, err)
+	}
+
+	script.Command = append(script.Command, "--light")
+	if changed, err := termux.InstallLauncher(l, script); err != nil || !changed {
+		t.Errorf("update = %v, %v, want true, nil", changed, err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "notes --light") {
+		t.Errorf("updated script:\n%s", data)
+	}
+
+	// Reinstalling without the icon removes it, unless a task shares it
+	iconPath := filepath.Join(home, ".shortcuts", "icons", "Notes.png")
+	task := termux.Launcher{Kind: termux.LauncherTask, Name: "Notes", Icon: icon}
+	if _, err := termux.InstallLauncher(task, script); err != nil {
+		t.Fatal(err)
+	}
+	l.Icon = ""
+	if _, err := termux.InstallLauncher(l, script); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(iconPath); err != nil {
+		t.Errorf("icon removed while the task uses it: %v", err)
+	}
+	task.Icon = ""
+	if changed, err := termux.InstallLauncher(task, script); err != nil || !changed {
+		t.Errorf("reinstall without icon = %v, %v, want true, nil", changed, err)
+	}
+	if _, err := os.Stat(iconPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("stale icon left behind: %v", err)
+	}
+
+	// An icon the launcher was not installed with is the user's
+	os.WriteFile(iconPath, []byte(testPNG), 0o600)
+	if changed, err := termux.InstallLauncher(l, script); err != nil || changed {
+		t.Errorf("reinstall = %v, %v, want false, nil", changed, err)
+	}
+	if err := termux.RemoveLauncher(l); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(iconPath); err != nil {
+		t.Errorf("user's icon removed: %v", err)
+	}
+}
+
+func TestInstallLauncherKinds(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	script := termux.JobScript{Command: []string{"notes", "sync"}, LogFile: "sync.log"}
+
+	for kind, dir := range map[termux.LauncherKind]string{
+		termux.LauncherTask: ".shortcuts/tasks",
+		termux.LauncherBoot: ".termux/boot",
+	} {
+		if _, err := termux.InstallLauncher(termux.Launcher{Kind: kind, Name: "notes-sync"}, script); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(home, dir, "notes-sync")); err != nil {
+			t.Errorf("%s: %v", kind, err)
+		}
+	}
+
+	bad := []termux.Launcher{
+		{Kind: "widget", Name: "x"},
+		{Kind: termux.LauncherShortcut, Name: ""},
+		{Kind: termux.LauncherShortcut, Name: ".hidden"},
+		{Kind: termux.LauncherShortcut, Name: "a/b"},
+		{Kind: termux.LauncherShortcut, Name: "tasks"},
+		{Kind: termux.LauncherShortcut, Name: "icons"},
+		{Kind: termux.LauncherBoot, Name: "x", Icon: "x.png"},
+	}
+	for _, l := range bad {
+		if _, err := termux.InstallLauncher(l, script); err == nil {
+			t.Errorf("InstallLauncher(%+v) accepted", l)
+		}
+	}
+
+	notPNG := filepath.Join(t.TempDir(), "icon.png")
+	os.WriteFile(notPNG, []byte("GIF89a"), 0o644)
+	if _, err := termux.InstallLauncher(termux.Launcher{Kind: termux.LauncherTask, Name: "x", Icon: notPNG}, script); err == nil {
+		t.Error("non-PNG icon accepted")
+	}
+}
+
+func TestLauncherConflict(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".termux", "boot", "start-sshd")
+	os.MkdirAll(filepath.Dir(path), 0o700)
+	handWritten := "#!/bin/sh\nsshd\n"
+	os.WriteFile(path, []byte(handWritten), 0o700)
+
+	l := termux.Launcher{Kind: termux.LauncherBoot, Name: "start-sshd"}
+	script := termux.JobScript{Command: []string{"sshd"}}
+	if _, err := termux.InstallLauncher(l, script); !errors.Is(err, termux.ErrLauncherConflict) {
+		t.Errorf("InstallLauncher over a hand-written script: err = %v, want ErrLauncherConflict", err)
+	}
+	if err := termux.RemoveLauncher(l); !errors.Is(err, termux.ErrLauncherConflict) {
+		t.Errorf("RemoveLauncher of a hand-written script: err = %v, want ErrLauncherConflict", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != handWritten {
+		t.Errorf("hand-written script changed to:\n%s", data)
+	}
+
+	l.Force = true
+	if changed, err := termux.InstallLauncher(l, script); err != nil || !changed {
+		t.Errorf("forced InstallLauncher = %v, %v, want true, nil", changed, err)
+	}
+}
+
+func TestListAndRemoveLaunchers(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	icon := filepath.Join(t.TempDir(), "notes.png")
+	os.WriteFile(icon, []byte(testPNG), 0o644)
+	script := termux.JobScript{Command: []string{"notes"}}
+
+	for _, l := range []termux.Launcher{
+		{Kind: termux.LauncherBoot, Name: "notes"},
+		{Kind: termux.LauncherTask, Name: "notes", Icon: icon},
+		{Kind: termux.LauncherShortcut, Name: "notes", Icon: icon},
+	} {
+		if _, err := termux.InstallLauncher(l, script); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(home, ".shortcuts", "backup"), []byte("#!/bin/sh\n"), 0o700)
+
+	launchers, err := termux.ListLaunchers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, l := range launchers {
+		got = append(got, string(l.Kind)+" "+l.Name)
+		if l.Generated != (l.Name == "notes") {
+			t.Errorf("%s %s: Generated = %v", l.Kind, l.Name, l.Generated)
+		}
+	}
+	want := "shortcut backup,shortcut notes,task notes,boot notes"
+	if strings.Join(got, ",") != want {
+		t.Errorf("launchers = %v, want %s", got, want)
+	}
+	if launchers[1].Icon != filepath.Join(home, ".shortcuts", "icons", "notes.png") {
+		t.Errorf("icon = %q", launchers[1].Icon)
+	}
+
+	// The icon stays while the task still uses it
+	iconPath := filepath.Join(home, ".shortcuts", "icons", "notes.png")
+	if err := termux.RemoveLauncher(termux.Launcher{Kind: termux.LauncherShortcut, Name: "notes"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(iconPath); err != nil {
+		t.Errorf("icon removed while the task uses it: %v", err)
+	}
+	if err := termux.RemoveLauncher(termux.Launcher{Kind: termux.LauncherTask, Name: "notes"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(iconPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("icon left behind: %v", err)
+	}
+	if err := termux.RemoveLauncher(termux.Launcher{Kind: termux.LauncherTask, Name: "notes"}); err != nil {
+		t.Errorf("removing a missing launcher: %v", err)
+	}
+}
@@ -0,0 +1,358 @@
+package termux
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// LocationRequest selects how termux-location obtains a fix (its -r flag).
+type LocationRequest string
+
+const (
+	LocationRequestOnce    LocationRequest = "once"    // Wait for a fresh fix (the default)
+	LocationRequestLast    LocationRequest = "last"    // Return the last known fix immediately
+	LocationRequestUpdates LocationRequest = "updates" // Report fixes continuously
+)
+
+// ErrNoLocation is returned by GetLastLocation when the device has no
+// cached fix to report.
+var ErrNoLocation = errors.New("termux: no location fix available")
+
+// GetLastLocation returns the last known location without waiting for a
+// new fix. It is fast but may be stale; check Location.Time. An empty
+// provider uses GPS.
+//
+// If not running on Termux, returns a default Location at (0, 0).
+//
+// Example:
+//
+//	// Show something immediately, then refine with a fresh fix
+//	if loc, err := termux.GetLastLocation("network"); err == nil {
+//	    m.position = loc
+//	}
+func GetLastLocation(provider string) (*Location, error) {
+	return Default().GetLastLocation(provider)
+}
+
+// GetLastLocationContext is like GetLastLocation but takes a context. The
+// command is killed if ctx is done before it completes.
+func GetLastLocationContext(ctx context.Context, provider string) (*Location, error) {
+	return Default().GetLastLocationContext(ctx, provider)
+}
+
+// GetLastLocation returns the last known location without waiting for a
+// new fix.
+func (c *Client) GetLastLocation(provider string) (*Location, error) {
+	return c.GetLastLocationContext(context.Background(), provider)
+}
+
+// GetLastLocationContext is like GetLastLocation but takes a context.
+func (c *Client) GetLastLocationContext(ctx context.Context, provider string) (*Location, error) {
+	return c.location(ctx, locationArgs(provider, LocationRequestLast)...)
+}
+
+// location runs termux-location with args and decodes the single fix it
+// reports.
+func (c *Client) location(ctx context.Context, args ...string) (*Location, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return &Location{}, nil
+	}
+
+	output, err := c.run(ctx, "termux-location", args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil, ErrNoLocation
+	}
+
+	var loc Location
+	if err := json.Unmarshal(output, &loc); err != nil {
+		return nil, err
+	}
+	loc.stamp(time.Now())
+	return &loc, nil
+}
+
+// locationArgs builds termux-location flags. Empty values use the
+// command's defaults.
+func locationArgs(provider string, request LocationRequest) []string {
+	var args []string
+	if provider != "" {
+		args = append(args, "-p", provider)
+	}
+	if request != "" {
+		args = append(args, "-r", string(request))
+	}
+	return args
+}
+
+// stamp sets Time from the fix's age as of now.
+func (l *Location) stamp(now time.Time) {
+	l.Time = now.Add(-time.Duration(l.ElapsedMs) * time.Millisecond)
+}
+
+// earthRadius is the mean Earth radius in meters.
+const earthRadius = 6371000
+
+// DistanceTo returns the great-circle distance to other in meters.
+func (l Location) DistanceTo(other Location) float64 {
+	lat1 := l.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (other.Longitude - l.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// TrackOptions configures TrackLocation.
+type TrackOptions struct {
+	Provider    string  // "gps", "network" or "passive"; empty uses GPS
+	MinDistance float64 // Skip fixes closer than this many meters to the last one delivered
+	MaxAccuracy float64 // Skip fixes less accurate than this many meters; zero accepts all
+	Record      bool    // Keep delivered fixes for Track and WriteGPX
+}
+
+// LocationTracker delivers live location updates from a running
+// termux-location process. Always Close a tracker when done with it.
+type LocationTracker struct {
+	opts    TrackOptions
+	updates chan Location
+	cancel  context.CancelFunc
+	done    chan struct{}
+	err     error
+
+	mu    sync.Mutex
+	last  *Location
+	track []Location
+}
+
+// Updates returns the channel fixes are delivered on, after filtering. It
+// is closed when the tracker stops.
+func (t *LocationTracker) Updates() <-chan Location {
+	return t.updates
+}
+
+// Err returns the error that stopped the tracker, once Updates is closed.
+// Stopping by Close or by the context being done is not an error.
+func (t *LocationTracker) Err() error {
+	select {
+	case <-t.done:
+		return t.err
+	default:
+		return nil
+	}
+}
+
+// Close stops the termux-location process and waits for it to exit.
+func (t *LocationTracker) Close() error {
+	t.cancel()
+	<-t.done
+	return nil
+}
+
+// Last returns the most recently delivered fix.
+func (t *LocationTracker) Last() (Location, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.last == nil {
+		return Location{}, false
+	}
+	return *t.last, true
+}
+
+// Track returns the fixes recorded so far, oldest first. It is empty
+// unless TrackOptions.Record was set.
+func (t *LocationTracker) Track() []Location {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Location(nil), t.track...)
+}
+
+// WriteGPX writes the recorded track as a GPX document. See WriteGPX.
+func (t *LocationTracker) WriteGPX(w io.Writer, name string) error {
+	return WriteGPX(w, name, t.Track())
+}
+
+// accept applies the filters to loc and, if it passes, records it as the
+// latest fix.
+func (t *LocationTracker) accept(loc Location) bool {
+	if t.opts.MaxAccuracy > 0 && loc.Accuracy > t.opts.MaxAccuracy {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.last != nil && t.opts.MinDistance > 0 && t.last.DistanceTo(loc) < t.opts.MinDistance {
+		return false
+	}
+	t.last = &loc
+	if t.opts.Record {
+		t.track = append(t.track, loc)
+	}
+	return true
+}
+
+// TrackLocation starts continuous location updates (termux-location -r
+// updates). Fixes that fail the accuracy or distance filters are dropped.
+//
+// If not running on Termux, returns a tracker that stops immediately.
+//
+// Example:
+//
+//	tracker, err := termux.TrackLocation(termux.TrackOptions{
+//	    MinDistance: 5,  // meters
+//	    MaxAccuracy: 25, // meters
+//	    Record:      true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for loc := range tracker.Updates() {
+//	    fmt.Printf("%.5f, %.5f\n", loc.Latitude, loc.Longitude)
+//	}
+//
+//	f, _ := os.Create("walk.gpx")
+//	defer f.Close()
+//	tracker.WriteGPX(f, "Morning walk")
+func TrackLocation(opts TrackOptions) (*LocationTracker, error) {
+	return Default().TrackLocation(opts)
+}
+
+// TrackLocationContext is like TrackLocation but takes a context. The
+// tracker stops when ctx is done.
+func TrackLocationContext(ctx context.Context, opts TrackOptions) (*LocationTracker, error) {
+	return Default().TrackLocationContext(ctx, opts)
+}
+
+// TrackLocation starts continuous location updates.
+func (c *Client) TrackLocation(opts TrackOptions) (*LocationTracker, error) {
+	return c.TrackLocationContext(context.Background(), opts)
+}
+
+// TrackLocationContext is like TrackLocation but takes a context.
+func (c *Client) TrackLocationContext(ctx context.Context, opts TrackOptions) (*LocationTracker, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	t := &LocationTracker{
+		opts:    opts,
+		updates: make(chan Location),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			cancel()
+			return nil, err
+		}
+		close(t.updates)
+		close(t.done)
+		return t, nil
+	}
+
+	go c.trackLocation(ctx, t)
+	return t, nil
+}
+
+// trackLocation runs termux-location in updates mode, delivering fixes to
+// t until the process exits or ctx is done.
+func (c *Client) trackLocation(ctx context.Context, t *LocationTracker) {
+	// done closes before updates so Err is settled when a reader sees
+	// the channel close.
+	defer close(t.updates)
+	defer close(t.done)
+	defer t.cancel()
+
+	_, err := c.streamJSON(ctx, func(dec *json.Decoder) error {
+		var loc Location
+		if err := dec.Decode(&loc); err != nil {
+			return err
+		}
+		loc.stamp(time.Now())
+		if !t.accept(loc) {
+			return nil
+		}
+		select {
+		case t.updates <- loc:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, "termux-location", locationArgs(t.opts.Provider, LocationRequestUpdates)...)
+
+	if ctx.Err() == nil {
+		t.err = err
+	}
+}
+
+// gpx is the root of a GPX 1.1 document with a single track.
+type gpx struct {
+	XMLName xml.Name `xml:"http://www.topografix.com/GPX/1/1 gpx"`
+	Version string   `xml:"version,attr"`
+	Creator string   `xml:"creator,attr"`
+	Track   struct {
+		Name    string `xml:"name,omitempty"`
+		Segment struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// gpxPoint is a GPX track point.
+type gpxPoint struct {
+	Lat  float64  `xml:"lat,attr"`
+	Lon  float64  `xml:"lon,attr"`
+	Ele  *float64 `xml:"ele,omitempty"`
+	Time string   `xml:"time,omitempty"`
+}
+
+// WriteGPX writes track as a GPX 1.1 document with a single track named
+// name, readable by mapping apps such as OsmAnd and Google Earth. Android
+// reports an altitude of 0 when the fix has none, as network fixes don't,
+// so points at altitude 0 are written without an elevation.
+//
+// Example:
+//
+//	f, err := os.Create("track.gpx")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//	return termux.WriteGPX(f, "Survey line 3", points)
+func WriteGPX(w io.Writer, name string, track []Location) error {
+	doc := gpx{Version: "1.1", Creator: "TUITemplate"}
+	doc.Track.Name = name
+	for _, loc := range track {
+		point := gpxPoint{Lat: loc.Latitude, Lon: loc.Longitude}
+		if loc.Altitude != 0 {
+			point.Ele = &loc.Altitude
+		}
+		if !loc.Time.IsZero() {
+			point.Time = loc.Time.UTC().Format(time.RFC3339)
+		}
+		doc.Track.Segment.Points = append(doc.Track.Segment.Points, point)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
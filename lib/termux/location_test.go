@@ -0,0 +1,130 @@
+package termux_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestGetLastLocation(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-location", `{"latitude":51.5,"longitude":-0.12,"accuracy":20,"provider":"network","elapsedMs":60000}`)
+	client := termux.NewClient(fake)
+
+	loc, err := client.GetLastLocation("network")
+	if err != nil {
+		t.Fatalf("GetLastLocation: %v", err)
+	}
+	if loc.Latitude != 51.5 || loc.Provider != "network" {
+		t.Errorf("GetLastLocation = %+v", loc)
+	}
+	if age := time.Since(loc.Time); age < time.Minute || age > 2*time.Minute {
+		t.Errorf("fix age = %v, want about a minute", age)
+	}
+
+	want := []string{"-p", "network", "-r", "last"}
+	if calls := fake.CallsTo("termux-location"); !reflect.DeepEqual(calls[0].Args, want) {
+		t.Errorf("args = %q, want %q", calls[0].Args, want)
+	}
+}
+
+func TestGetLastLocationNoFix(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-location", "\n")
+	client := termux.NewClient(fake)
+
+	if _, err := client.GetLastLocation(""); !errors.Is(err, termux.ErrNoLocation) {
+		t.Errorf("err = %v, want ErrNoLocation", err)
+	}
+}
+
+func TestTrackLocationFilters(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-location", `
+{"latitude":51.50000,"longitude":-0.12000,"accuracy":8}
+{"latitude":51.50001,"longitude":-0.12000,"accuracy":8}
+{"latitude":51.50100,"longitude":-0.12000,"accuracy":90}
+{"latitude":51.50100,"longitude":-0.12000,"accuracy":6}
+`)
+	client := termux.NewClient(fake)
+
+	tracker, err := client.TrackLocation(termux.TrackOptions{
+		Provider:    "gps",
+		MinDistance: 5,
+		MaxAccuracy: 25,
+		Record:      true,
+	})
+	if err != nil {
+		t.Fatalf("TrackLocation: %v", err)
+	}
+	defer tracker.Close()
+
+	var got []float64
+	for loc := range tracker.Updates() {
+		got = append(got, loc.Latitude)
+	}
+	if err := tracker.Err(); err != nil {
+		t.Errorf("Err = %v", err)
+	}
+	// The second fix is ~1m away and the third is too inaccurate
+	if want := []float64{51.5, 51.501}; !reflect.DeepEqual(got, want) {
+		t.Errorf("updates = %v, want %v", got, want)
+	}
+	if len(tracker.Track()) != 2 {
+		t.Errorf("Track has %d points, want 2", len(tracker.Track()))
+	}
+	if last, ok := tracker.Last(); !ok || last.Latitude != 51.501 {
+		t.Errorf("Last = %+v, %v", last, ok)
+	}
+
+	want := []string{"-p", "gps", "-r", "updates"}
+	if calls := fake.CallsTo("termux-location"); !reflect.DeepEqual(calls[0].Args, want) {
+		t.Errorf("args = %q, want %q", calls[0].Args, want)
+	}
+}
+
+func TestLocationDistance(t *testing.T) {
+	london := termux.Location{Latitude: 51.5074, Longitude: -0.1278}
+	paris := termux.Location{Latitude: 48.8566, Longitude: 2.3522}
+	if d := london.DistanceTo(paris); d < 340e3 || d > 345e3 {
+		t.Errorf("London-Paris = %.0fm, want ~343km", d)
+	}
+}
+
+func TestWriteGPX(t *testing.T) {
+	track := []termux.Location{
+		{Latitude: 51.5, Longitude: -0.12, Altitude: 11, Time: time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)},
+		{Latitude: 51.501, Longitude: -0.12, Altitude: 12},
+		{Latitude: 51.502, Longitude: -0.12, Provider: "network"},
+	}
+
+	var buf bytes.Buffer
+	if err := termux.WriteGPX(&buf, "Walk", track); err != nil {
+		t.Fatalf("WriteGPX: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<gpx xmlns="http://www.topografix.com/GPX/1/1" version="1.1" creator="TUITemplate">`,
+		`<name>Walk</name>`,
+		`<trkpt lat="51.5" lon="-0.12">`,
+		`<time>2024-05-01T09:30:00Z</time>`,
+		`<ele>12</ele>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("GPX missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "<time>") != 1 {
+		t.Errorf("untimed point written with <time>:\n%s", out)
+	}
+	if strings.Count(out, "<ele>") != 2 {
+		t.Errorf("point without altitude written with <ele>:\n%s", out)
+	}
+}
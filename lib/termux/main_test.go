@@ -0,0 +1,18 @@
+package termux_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// TestMain clears the environment that DetectBackend and LinuxBackend
+// read, so tests see the same headless session whatever session runs
+// them. Tests of desktop sessions set it themselves.
+func TestMain(m *testing.M) {
+	for _, name := range []string{termux.BackendEnv, "DISPLAY", "WAYLAND_DISPLAY", "DBUS_SESSION_BUS_ADDRESS"} {
+		os.Unsetenv(name)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,125 @@
+package termux_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestListCameras(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-camera-info", `[
+		{"id": "0", "facing": "back", "jpeg_output_sizes": [{"width": 4000, "height": 3000}],
+		 "focal_lengths": [4.38], "auto_exposure_modes": ["CONTROL_AE_MODE_ON"], "capabilities": ["raw"]},
+		{"id": "1", "facing": "front", "jpeg_output_sizes": [{"width": 2592, "height": 1944}]}
+	]`)
+
+	cameras, err := termux.NewClient(fake).ListCameras()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cameras) != 2 || cameras[0].JPEGSizes[0].Width != 4000 || cameras[1].Facing != "front" {
+		t.Errorf("cameras = %+v", cameras)
+	}
+}
+
+func TestTakePhoto(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	if err := client.TakePhoto("photo.jpg", "1"); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := filepath.Abs("photo.jpg")
+	if got := fake.CallsTo("termux-camera-photo")[0].Args; strings.Join(got, " ") != "-c 1 "+want {
+		t.Errorf("args = %q", got)
+	}
+
+	fake.Respond("termux-camera-photo", "ERROR: camera 7 not found\n")
+	if err := client.TakePhoto("/tmp/x.jpg", "7"); err == nil || !strings.Contains(err.Error(), "camera 7") {
+		t.Errorf("err = %v, want the camera's message", err)
+	}
+}
+
+func TestRecording(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	// Responses are consumed in order
+	fake.Respond("termux-microphone-record", "Recording started: /sdcard/memo.ogg \nMax Duration: 01:00\n")
+	fake.Respond("termux-microphone-record", "Recording already in progress!")
+	fake.Respond("termux-microphone-record", `{"isRecording": true, "outputFile": "/sdcard/memo.ogg"}`)
+	fake.Respond("termux-microphone-record", "Recording finished: /sdcard/memo.ogg")
+	fake.Respond("termux-microphone-record", "No recording to stop")
+
+	err := client.StartRecording("/sdcard/memo.ogg", termux.RecordingOptions{
+		Encoder:    termux.EncoderOpus,
+		Limit:      time.Minute,
+		BitRate:    32000,
+		SampleRate: 48000,
+		Channels:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(fake.CallsTo("termux-microphone-record")[0].Args, " ")
+	if want := "-f /sdcard/memo.ogg -e opus -l 60 -b 32 -r 48000 -c 1"; got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+
+	if err := client.StartRecording("/sdcard/b.m4a", termux.RecordingOptions{Limit: -1}); !errors.Is(err, termux.ErrRecordingInProgress) {
+		t.Errorf("second start err = %v", err)
+	}
+	if args := fake.CallsTo("termux-microphone-record")[1].Args; args[len(args)-1] != "0" {
+		t.Errorf("unlimited recording args = %q, want -l 0", args)
+	}
+
+	state, err := client.GetRecordingState()
+	if err != nil || !state.Recording || state.File != "/sdcard/memo.ogg" {
+		t.Errorf("state = %+v, %v", state, err)
+	}
+
+	if file, err := client.StopRecording(); err != nil || file != "/sdcard/memo.ogg" {
+		t.Errorf("StopRecording = %q, %v", file, err)
+	}
+
+	if _, err := client.StopRecording(); !errors.Is(err, termux.ErrNotRecording) {
+		t.Errorf("stop when idle err = %v", err)
+	}
+}
+
+func TestPlayerState(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	fake.Respond("termux-media-player", "Status: Paused\nTrack: /sdcard/memo.m4a\nCurrent Position: 01:02 / 1:02:03\n")
+	fake.Respond("termux-media-player", "No track currently playing")
+	state, err := client.GetPlayerState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := termux.PlayerState{
+		Status:   termux.PlayerPaused,
+		Track:    "/sdcard/memo.m4a",
+		Position: 62 * time.Second,
+		Duration: time.Hour + 2*time.Minute + 3*time.Second,
+	}
+	if state != want {
+		t.Errorf("state = %+v, want %+v", state, want)
+	}
+
+	if state, _ := client.GetPlayerState(); state.Status != termux.PlayerStopped {
+		t.Errorf("idle state = %+v", state)
+	}
+	if err := client.PauseMedia(); err == nil {
+		t.Error("PauseMedia with no track succeeded")
+	}
+	if args := fake.CallsTo("termux-media-player")[2].Args; len(args) != 1 || args[0] != "pause" {
+		t.Errorf("pause args = %q", args)
+	}
+}
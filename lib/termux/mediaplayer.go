@@ -0,0 +1,243 @@
+package termux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PlayerStatus is the state of the Termux media player.
+type PlayerStatus string
+
+const (
+	PlayerStopped PlayerStatus = "stopped" // No track loaded
+	PlayerPlaying PlayerStatus = "playing"
+	PlayerPaused  PlayerStatus = "paused"
+)
+
+// PlayerState is the Termux media player's current track and position.
+type PlayerState struct {
+	Status   PlayerStatus
+	Track    string        // Path of the loaded track
+	Position time.Duration // Playback position
+	Duration time.Duration // Track length
+}
+
+// PlayMedia starts playing the audio file at path in the background,
+// replacing any current track. Playback continues after the program
+// exits; call StopMedia to end it.
+//
+// If not running on Termux, this is a no-op.
+//
+// Example:
+//
+//	termux.PlayMedia(entry.VoiceMemo)
+//	// ...
+//	termux.PauseMedia()
+//	termux.ResumeMedia()
+//	termux.StopMedia()
+func PlayMedia(path string) error {
+	return Default().PlayMedia(path)
+}
+
+// PlayMediaContext is like PlayMedia but takes a context. The command is
+// killed if ctx is done before it completes.
+func PlayMediaContext(ctx context.Context, path string) error {
+	return Default().PlayMediaContext(ctx, path)
+}
+
+// PlayMedia starts playing the audio file at path.
+func (c *Client) PlayMedia(path string) error {
+	return c.PlayMediaContext(context.Background(), path)
+}
+
+// PlayMediaContext is like PlayMedia but takes a context.
+func (c *Client) PlayMediaContext(ctx context.Context, path string) error {
+	if path == "" {
+		return errors.New("termux: PlayMedia needs a file")
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	return c.mediaPlayer(ctx, "play", abs)
+}
+
+// PauseMedia pauses playback.
+//
+// If not running on Termux, this is a no-op.
+func PauseMedia() error {
+	return Default().PauseMedia()
+}
+
+// PauseMediaContext is like PauseMedia but takes a context. The command is
+// killed if ctx is done before it completes.
+func PauseMediaContext(ctx context.Context) error {
+	return Default().PauseMediaContext(ctx)
+}
+
+// PauseMedia pauses playback.
+func (c *Client) PauseMedia() error {
+	return c.PauseMediaContext(context.Background())
+}
+
+// PauseMediaContext is like PauseMedia but takes a context.
+func (c *Client) PauseMediaContext(ctx context.Context) error {
+	return c.mediaPlayer(ctx, "pause")
+}
+
+// ResumeMedia resumes paused playback.
+//
+// If not running on Termux, this is a no-op.
+func ResumeMedia() error {
+	return Default().ResumeMedia()
+}
+
+// ResumeMediaContext is like ResumeMedia but takes a context. The command
+// is killed if ctx is done before it completes.
+func ResumeMediaContext(ctx context.Context) error {
+	return Default().ResumeMediaContext(ctx)
+}
+
+// ResumeMedia resumes paused playback.
+func (c *Client) ResumeMedia() error {
+	return c.ResumeMediaContext(context.Background())
+}
+
+// ResumeMediaContext is like ResumeMedia but takes a context.
+func (c *Client) ResumeMediaContext(ctx context.Context) error {
+	return c.mediaPlayer(ctx, "play")
+}
+
+// StopMedia stops playback and unloads the track.
+//
+// If not running on Termux, this is a no-op.
+func StopMedia() error {
+	return Default().StopMedia()
+}
+
+// StopMediaContext is like StopMedia but takes a context. The command is
+// killed if ctx is done before it completes.
+func StopMediaContext(ctx context.Context) error {
+	return Default().StopMediaContext(ctx)
+}
+
+// StopMedia stops playback and unloads the track.
+func (c *Client) StopMedia() error {
+	return c.StopMediaContext(context.Background())
+}
+
+// StopMediaContext is like StopMedia but takes a context.
+func (c *Client) StopMediaContext(ctx context.Context) error {
+	return c.mediaPlayer(ctx, "stop")
+}
+
+// GetPlayerState returns the media player's track and position.
+//
+// If not running on Termux, reports PlayerStopped.
+func GetPlayerState() (PlayerState, error) {
+	return Default().GetPlayerState()
+}
+
+// GetPlayerStateContext is like GetPlayerState but takes a context. The
+// command is killed if ctx is done before it completes.
+func GetPlayerStateContext(ctx context.Context) (PlayerState, error) {
+	return Default().GetPlayerStateContext(ctx)
+}
+
+// GetPlayerState returns the media player's track and position.
+func (c *Client) GetPlayerState() (PlayerState, error) {
+	return c.GetPlayerStateContext(context.Background())
+}
+
+// GetPlayerStateContext is like GetPlayerState but takes a context.
+func (c *Client) GetPlayerStateContext(ctx context.Context) (PlayerState, error) {
+	if !c.IsTermux() {
+		return PlayerState{Status: PlayerStopped}, c.fallbackErr()
+	}
+
+	output, err := c.run(ctx, "termux-media-player", "info")
+	if err != nil {
+		return PlayerState{}, err
+	}
+	return parsePlayerInfo(string(output))
+}
+
+// mediaPlayer runs a termux-media-player control command. The player
+// reports problems on stdout ("Error: ...", "No track to pause").
+func (c *Client) mediaPlayer(ctx context.Context, args ...string) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	output, err := c.run(ctx, "termux-media-player", args...)
+	if err != nil {
+		return err
+	}
+	msg := strings.TrimSpace(string(output))
+	lower := strings.ToLower(msg)
+	if strings.HasPrefix(lower, "error") || strings.HasPrefix(lower, "no track") {
+		return fmt.Errorf("termux: termux-media-player %s: %s", args[0], msg)
+	}
+	return nil
+}
+
+// parsePlayerInfo parses termux-media-player info output:
+//
+//	Status: Playing
+//	Track: /sdcard/memo.m4a
+//	Current Position: 00:12 / 01:05
+func parsePlayerInfo(output string) (PlayerState, error) {
+	state := PlayerState{Status: PlayerStopped}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Status":
+			switch strings.ToLower(value) {
+			case "playing":
+				state.Status = PlayerPlaying
+			case "paused":
+				state.Status = PlayerPaused
+			}
+		case "Track":
+			state.Track = value
+		case "Current Position":
+			pos, total, _ := strings.Cut(value, "/")
+			var err error
+			if state.Position, err = parseClock(pos); err != nil {
+				return PlayerState{}, err
+			}
+			if state.Duration, err = parseClock(total); err != nil {
+				return PlayerState{}, err
+			}
+		}
+	}
+	return state, nil
+}
+
+// parseClock parses a "mm:ss" or "hh:mm:ss" duration.
+func parseClock(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	var d time.Duration
+	for _, part := range strings.Split(s, ":") {
+		if !isDigits(part) {
+			return 0, fmt.Errorf("termux: bad player position %q", s)
+		}
+		var n time.Duration
+		for _, r := range part {
+			n = n*10 + time.Duration(r-'0')
+		}
+		d = d*60 + n
+	}
+	return d * time.Second, nil
+}
@@ -0,0 +1,219 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AudioEncoder is the codec termux-microphone-record encodes with.
+type AudioEncoder string
+
+const (
+	EncoderAAC   AudioEncoder = "aac"    // AAC in an .m4a file (default)
+	EncoderAMRWB AudioEncoder = "amr_wb" // Wideband AMR, for speech
+	EncoderAMRNB AudioEncoder = "amr_nb" // Narrowband AMR, smallest files
+	EncoderOpus  AudioEncoder = "opus"   // Opus in an .ogg file (Android 10+)
+)
+
+var (
+	// ErrRecordingInProgress is returned by StartRecording while another
+	// recording is running.
+	ErrRecordingInProgress = errors.New("termux: recording already in progress")
+
+	// ErrNotRecording is returned by StopRecording when nothing is being
+	// recorded.
+	ErrNotRecording = errors.New("termux: no recording in progress")
+)
+
+// RecordingOptions configures StartRecording. Zero fields use the
+// defaults of termux-microphone-record.
+type RecordingOptions struct {
+	Encoder AudioEncoder
+
+	// Limit stops the recording automatically after this long. Zero means
+	// the default of 15 minutes; negative means no limit.
+	Limit time.Duration
+
+	BitRate    int // Bits per second, e.g. 128000
+	SampleRate int // Samples per second, e.g. 44100
+	Channels   int // 1 for mono, 2 for stereo
+}
+
+// args builds the termux-microphone-record arguments to record to path.
+func (o RecordingOptions) args(path string) []string {
+	args := []string{"-f", path}
+	if o.Encoder != "" {
+		args = append(args, "-e", string(o.Encoder))
+	}
+	switch {
+	case o.Limit < 0:
+		args = append(args, "-l", "0")
+	case o.Limit > 0:
+		args = append(args, "-l", formatInt(int((o.Limit+time.Second-1)/time.Second)))
+	}
+	if o.BitRate > 0 {
+		// termux-microphone-record takes kbps
+		args = append(args, "-b", formatInt(max(o.BitRate/1000, 1)))
+	}
+	if o.SampleRate > 0 {
+		args = append(args, "-r", formatInt(o.SampleRate))
+	}
+	if o.Channels > 0 {
+		args = append(args, "-c", formatInt(o.Channels))
+	}
+	return args
+}
+
+// RecordingState reports whether the microphone is recording.
+type RecordingState struct {
+	Recording bool   `json:"isRecording"`
+	File      string `json:"outputFile"` // File being recorded to; empty when not recording
+}
+
+// StartRecording starts recording from the microphone to path in the
+// background and returns immediately. Call StopRecording to finish the
+// file; it is also finished when opts.Limit is reached.
+//
+// Returns ErrRecordingInProgress if a recording is already running.
+//
+// If not running on Termux, this is a no-op and no file is written.
+//
+// Example:
+//
+//	memo := filepath.Join(entryDir, "memo.m4a")
+//	err := termux.StartRecording(memo, termux.RecordingOptions{Limit: 5 * time.Minute})
+//	// ... user taps "stop"
+//	termux.StopRecording()
+func StartRecording(path string, opts RecordingOptions) error {
+	return Default().StartRecording(path, opts)
+}
+
+// StartRecordingContext is like StartRecording but takes a context. The
+// command is killed if ctx is done before it completes; the recording
+// itself keeps running.
+func StartRecordingContext(ctx context.Context, path string, opts RecordingOptions) error {
+	return Default().StartRecordingContext(ctx, path, opts)
+}
+
+// StartRecording starts recording from the microphone to path.
+func (c *Client) StartRecording(path string, opts RecordingOptions) error {
+	return c.StartRecordingContext(context.Background(), path, opts)
+}
+
+// StartRecordingContext is like StartRecording but takes a context.
+func (c *Client) StartRecordingContext(ctx context.Context, path string, opts RecordingOptions) error {
+	if path == "" {
+		return errors.New("termux: StartRecording needs an output path")
+	}
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	output, err := c.run(ctx, "termux-microphone-record", opts.args(abs)...)
+	if err != nil {
+		return err
+	}
+	_, err = parseRecorderOutput(output, "Recording started")
+	return err
+}
+
+// StopRecording stops the current recording and returns the path of the
+// finished file.
+//
+// Returns ErrNotRecording if nothing was being recorded.
+//
+// If not running on Termux, returns an empty path.
+func StopRecording() (string, error) {
+	return Default().StopRecording()
+}
+
+// StopRecordingContext is like StopRecording but takes a context. The
+// command is killed if ctx is done before it completes.
+func StopRecordingContext(ctx context.Context) (string, error) {
+	return Default().StopRecordingContext(ctx)
+}
+
+// StopRecording stops the current recording.
+func (c *Client) StopRecording() (string, error) {
+	return c.StopRecordingContext(context.Background())
+}
+
+// StopRecordingContext is like StopRecording but takes a context.
+func (c *Client) StopRecordingContext(ctx context.Context) (string, error) {
+	if !c.IsTermux() {
+		return "", c.fallbackErr()
+	}
+
+	output, err := c.run(ctx, "termux-microphone-record", "-q")
+	if err != nil {
+		return "", err
+	}
+	return parseRecorderOutput(output, "Recording finished")
+}
+
+// GetRecordingState reports whether the microphone is recording, and to
+// which file.
+//
+// If not running on Termux, reports no recording.
+func GetRecordingState() (RecordingState, error) {
+	return Default().GetRecordingState()
+}
+
+// GetRecordingStateContext is like GetRecordingState but takes a context.
+// The command is killed if ctx is done before it completes.
+func GetRecordingStateContext(ctx context.Context) (RecordingState, error) {
+	return Default().GetRecordingStateContext(ctx)
+}
+
+// GetRecordingState reports whether the microphone is recording.
+func (c *Client) GetRecordingState() (RecordingState, error) {
+	return c.GetRecordingStateContext(context.Background())
+}
+
+// GetRecordingStateContext is like GetRecordingState but takes a context.
+func (c *Client) GetRecordingStateContext(ctx context.Context) (RecordingState, error) {
+	if !c.IsTermux() {
+		return RecordingState{}, c.fallbackErr()
+	}
+
+	output, err := c.run(ctx, "termux-microphone-record", "-i")
+	if err != nil {
+		return RecordingState{}, err
+	}
+
+	var state RecordingState
+	if err := json.Unmarshal(output, &state); err != nil {
+		return RecordingState{}, err
+	}
+	return state, nil
+}
+
+// parseRecorderOutput extracts the file from a termux-microphone-record
+// message such as "Recording started: /path/memo.m4a", where prefix is the
+// expected message. Other messages are returned as errors.
+func parseRecorderOutput(output []byte, prefix string) (string, error) {
+	text := strings.TrimSpace(string(output))
+	for _, line := range strings.Split(text, "\n") {
+		if file, ok := strings.CutPrefix(strings.TrimSpace(line), prefix+":"); ok {
+			return strings.TrimSpace(file), nil
+		}
+	}
+
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "already in progress"):
+		return "", ErrRecordingInProgress
+	case strings.Contains(lower, "no recording"):
+		return "", ErrNotRecording
+	}
+	return "", fmt.Errorf("termux: termux-microphone-record: %s", text)
+}
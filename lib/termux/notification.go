@@ -0,0 +1,332 @@
+package termux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrInvalidNotification is returned by PostNotification (and
+// Notification.Validate) for a notification termux-notification would
+// reject or misinterpret.
+var ErrInvalidNotification = errors.New("termux: invalid notification")
+
+// NotificationType selects the notification style (--type).
+type NotificationType string
+
+const (
+	NotificationDefault NotificationType = "default" // Standard notification
+	NotificationMedia   NotificationType = "media"   // Media player controls (see MediaActions)
+)
+
+// maxNotificationButtons is how many buttons termux-notification supports.
+const maxNotificationButtons = 3
+
+// NotificationButton is an action button on a notification.
+type NotificationButton struct {
+	Label  string   // Button text
+	Action []string // Program and arguments run when tapped
+}
+
+// MediaActions are the commands run by the controls of a media
+// notification (Type NotificationMedia), each a program and arguments.
+type MediaActions struct {
+	Play     []string
+	Pause    []string
+	Previous []string
+	Next     []string
+}
+
+// isZero reports whether no media action is set.
+func (m MediaActions) isZero() bool {
+	return len(m.Play) == 0 && len(m.Pause) == 0 && len(m.Previous) == 0 && len(m.Next) == 0
+}
+
+// Notification describes everything termux-notification can show. Build
+// one directly and post it with PostNotification; the With... options
+// passed to Notify set a subset of the same fields.
+//
+// Actions are commands run by Termux, given as a program and its
+// arguments. Each word is quoted, so nothing in them is interpreted by
+// the shell.
+//
+// Example:
+//
+//	err := termux.PostNotification(termux.Notification{
+//	    ID:        "deploy",
+//	    Title:     "Deploy ready",
+//	    Content:   "v1.4.2 passed all checks",
+//	    Group:     "deploys",
+//	    AlertOnce: true,
+//	    LEDColor:  "00ff00",
+//	    Action:    []string{"termux-open-url", releaseURL},
+//	    Buttons: []termux.NotificationButton{
+//	        {Label: "Copy tag", Action: []string{"termux-clipboard-set", "v1.4.2"}},
+//	    },
+//	})
+type Notification struct {
+	ID      string // Identifier used to update or remove the notification
+	Title   string
+	Content string
+
+	Group     string           // Notifications in the same group are bundled together
+	Channel   string           // Android notification channel ID
+	Type      NotificationType // Empty means NotificationDefault
+	Priority  string           // "default", "high", "low", "max" or "min"
+	Icon      string           // Material icon name (e.g., "sync")
+	ImagePath string           // Absolute path of an image to show
+
+	Ongoing   bool   // Cannot be swiped away
+	AlertOnce bool   // Don't sound or vibrate again when updated
+	Sound     bool   // Play the notification sound
+	Vibrate   string // Pattern in milliseconds, e.g. "100,50,100"
+
+	LEDColor string        // Blinking LED color as RRGGBB
+	LEDOn    time.Duration // LED on time while blinking (default 800ms)
+	LEDOff   time.Duration // LED off time while blinking (default 800ms)
+
+	Action   []string             // Run when the notification is tapped
+	OnDelete []string             // Run when the notification is dismissed
+	Buttons  []NotificationButton // Up to 3 buttons
+	Media    MediaActions         // Controls for NotificationMedia
+}
+
+// Validate reports the first problem that would make termux-notification
+// reject the notification or silently drop part of it. The returned error
+// wraps ErrInvalidNotification.
+func (n *Notification) Validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidNotification, fmt.Sprintf(format, args...))
+	}
+
+	switch n.Priority {
+	case "", "default", "high", "low", "max", "min":
+	default:
+		return invalid("priority %q is not one of default, high, low, max, min", n.Priority)
+	}
+
+	switch n.Type {
+	case "", NotificationDefault:
+		if !n.Media.isZero() {
+			return invalid("media actions need Type NotificationMedia")
+		}
+	case NotificationMedia:
+	default:
+		return invalid("type %q is not default or media", n.Type)
+	}
+
+	if len(n.Buttons) > maxNotificationButtons {
+		return invalid("%d buttons, at most %d are supported", len(n.Buttons), maxNotificationButtons)
+	}
+	for i, b := range n.Buttons {
+		if b.Label == "" {
+			return invalid("button %d has no label", i+1)
+		}
+		if !isAction(b.Action) {
+			return invalid("button %d action has no program", i+1)
+		}
+	}
+	actions := []struct {
+		name string
+		argv []string
+	}{
+		{"tap", n.Action}, {"delete", n.OnDelete},
+		{"media play", n.Media.Play}, {"media pause", n.Media.Pause},
+		{"media previous", n.Media.Previous}, {"media next", n.Media.Next},
+	}
+	for _, a := range actions {
+		if !isAction(a.argv) {
+			return invalid("%s action has no program", a.name)
+		}
+	}
+
+	if n.ImagePath != "" && !filepath.IsAbs(n.ImagePath) {
+		return invalid("image path %q is not absolute", n.ImagePath)
+	}
+
+	if n.LEDColor != "" && !isHexColor(n.LEDColor) {
+		return invalid("LED color %q is not RRGGBB", n.LEDColor)
+	}
+	if n.LEDOn < 0 || n.LEDOff < 0 {
+		return invalid("negative LED timing")
+	}
+
+	if n.Vibrate != "" {
+		for _, part := range strings.Split(n.Vibrate, ",") {
+			if !isDigits(strings.TrimSpace(part)) {
+				return invalid("vibrate pattern %q is not comma-separated milliseconds", n.Vibrate)
+			}
+		}
+	}
+
+	return nil
+}
+
+// args builds the termux-notification arguments for n, which must have
+// passed Validate.
+func (n *Notification) args() []string {
+	args := []string{"--title", n.Title, "--content", n.Content}
+
+	flag := func(name, value string) {
+		if value != "" {
+			args = append(args, name, value)
+		}
+	}
+	action := func(name string, argv []string) {
+		if len(argv) > 0 {
+			args = append(args, name, ShellCommand(argv[0], argv[1:]...))
+		}
+	}
+	toggle := func(name string, on bool) {
+		if on {
+			args = append(args, name)
+		}
+	}
+	millis := func(name string, d time.Duration) {
+		if d > 0 {
+			args = append(args, name, formatInt(int(d/time.Millisecond)))
+		}
+	}
+
+	flag("--id", n.ID)
+	toggle("--ongoing", n.Ongoing)
+	flag("--priority", n.Priority)
+	flag("--icon", n.Icon)
+	flag("--vibrate", n.Vibrate)
+	toggle("--sound", n.Sound)
+
+	for i, b := range n.Buttons {
+		if i >= maxNotificationButtons {
+			break // Unreachable: Validate rejects extra buttons
+		}
+		num := formatInt(i + 1)
+		args = append(args, "--button"+num, b.Label)
+		action("--button"+num+"-action", b.Action)
+	}
+
+	flag("--group", n.Group)
+	flag("--channel", n.Channel)
+	flag("--type", string(n.Type))
+	toggle("--alert-once", n.AlertOnce)
+	flag("--image-path", n.ImagePath)
+	flag("--led-color", strings.TrimPrefix(n.LEDColor, "#"))
+	millis("--led-on", n.LEDOn)
+	millis("--led-off", n.LEDOff)
+	action("--action", n.Action)
+	action("--on-delete", n.OnDelete)
+	action("--media-play", n.Media.Play)
+	action("--media-pause", n.Media.Pause)
+	action("--media-previous", n.Media.Previous)
+	action("--media-next", n.Media.Next)
+
+	return args
+}
+
+// PostNotification validates n and posts it. Like Notify, it goes through
+// the client's backend, so desktops show what notify-send supports.
+//
+// If not running on Termux (and not on a Linux desktop), this is a no-op.
+func PostNotification(n Notification) error {
+	return Default().PostNotification(n)
+}
+
+// PostNotificationContext is like PostNotification but takes a context.
+// The command is killed if ctx is done before it completes.
+func PostNotificationContext(ctx context.Context, n Notification) error {
+	return Default().PostNotificationContext(ctx, n)
+}
+
+// PostNotification validates n and posts it.
+func (c *Client) PostNotification(n Notification) error {
+	return c.PostNotificationContext(context.Background(), n)
+}
+
+// PostNotificationContext is like PostNotification but takes a context.
+func (c *Client) PostNotificationContext(ctx context.Context, n Notification) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	return c.NotifyContext(ctx, n.Title, n.Content, func(dst *Notification) {
+		*dst = n
+	})
+}
+
+// ShellCommand builds a shell command line that runs name with args,
+// quoting each word so spaces, quotes and metacharacters reach the program
+// literally. Notification actions are built with it.
+//
+// Example:
+//
+//	// Safe even if title contains quotes or "; rm -rf ~"
+//	script := termux.ShellCommand("termux-clipboard-set", title)
+func ShellCommand(name string, args ...string) string {
+	words := make([]string, 0, 1+len(args))
+	words = append(words, ShellQuote(name))
+	for _, arg := range args {
+		words = append(words, ShellQuote(arg))
+	}
+	return strings.Join(words, " ")
+}
+
+// isAction reports whether argv is unset or names a program.
+func isAction(argv []string) bool {
+	return len(argv) == 0 || argv[0] != ""
+}
+
+// ShellQuote quotes s as a single shell word. Words made only of safe
+// characters are returned unchanged.
+func ShellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	safe := true
+	for _, r := range s {
+		if !isShellSafe(r) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// isShellSafe reports whether r never needs quoting in a shell word.
+func isShellSafe(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("@%+=:,./_-", r)
+}
+
+// isHexColor reports whether s is an RRGGBB color, with an optional '#'.
+func isHexColor(s string) bool {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return false
+	}
+	for _, r := range strings.ToLower(s) {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package termux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// notificationCleanupTimeout bounds the removals done by
+// NotificationManager.Close.
+const notificationCleanupTimeout = 10 * time.Second
+
+// NotificationManager tracks the notifications an app posts so they can be
+// updated in place and removed together when the app exits, including on
+// a signal or panic. IDs are namespaced with Prefix, which also lets
+// Sweep find notifications left behind by a previous run that crashed.
+//
+// Example:
+//
+//	notes := termux.NewNotificationManager(termux.Default())
+//	defer notes.Close()
+//	defer notes.RemoveOnPanic()
+//	stop := notes.RemoveOnSignal()
+//	defer stop()
+//
+//	notes.Sweep(ctx) // clear leftovers from a crashed run
+//
+//	id, _ := notes.Post(ctx, termux.Notification{Title: "Sync", Content: "Starting", Ongoing: true})
+//	notes.Update(ctx, id, func(n *termux.Notification) {
+//	    n.Content = "42 of 100 files"
+//	})
+type NotificationManager struct {
+	c *Client
+
+	// Prefix is prepended to every notification ID. NewNotificationManager
+	// sets it to the executable name and a dash.
+	Prefix string
+
+	// opMu serializes Post, Update and Remove, so an Update's
+	// read-edit-post cannot interleave with another change to the same
+	// notification. It is taken before mu.
+	opMu sync.Mutex
+
+	mu     sync.Mutex
+	posted map[string]Notification // by unprefixed ID
+	nextID int
+}
+
+// NewNotificationManager creates a NotificationManager that posts through c.
+func NewNotificationManager(c *Client) *NotificationManager {
+	return &NotificationManager{
+		c:      c,
+		Prefix: filepath.Base(os.Args[0]) + "-",
+		posted: make(map[string]Notification),
+	}
+}
+
+// Post validates and posts n, returning its ID within the manager. An
+// empty n.ID is assigned automatically as "auto-1", "auto-2", ..., skipping
+// IDs already tracked; posting an ID again replaces that notification.
+func (m *NotificationManager) Post(ctx context.Context, n Notification) (string, error) {
+	m.opMu.Lock()
+	defer m.opMu.Unlock()
+	return m.post(ctx, n)
+}
+
+// post is Post with m.opMu held.
+func (m *NotificationManager) post(ctx context.Context, n Notification) (string, error) {
+	m.mu.Lock()
+	id := n.ID
+	for id == "" {
+		m.nextID++
+		id = "auto-" + formatInt(m.nextID)
+		if _, taken := m.posted[id]; taken {
+			id = ""
+		}
+	}
+	m.mu.Unlock()
+
+	n.ID = m.Prefix + id
+	if err := m.c.PostNotificationContext(ctx, n); err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	m.posted[id] = n
+	m.mu.Unlock()
+	return id, nil
+}
+
+// Update edits the notification posted as id and posts it again, replacing
+// it in place. Set AlertOnce on the notification to keep frequent updates
+// from sounding or vibrating each time.
+//
+// Updates, posts and removals run one at a time, so concurrent updates
+// each see the previous one's edit and an update never brings back a
+// removed notification. edit must not call the manager's Post, Update or
+// Remove.
+func (m *NotificationManager) Update(ctx context.Context, id string, edit func(*Notification)) error {
+	m.opMu.Lock()
+	defer m.opMu.Unlock()
+
+	m.mu.Lock()
+	n, ok := m.posted[id]
+	m.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("termux: notification %q was not posted by this manager", id)
+	}
+
+	edit(&n)
+	n.ID = id
+	_, err := m.post(ctx, n)
+	return err
+}
+
+// Get returns the notification last posted as id.
+func (m *NotificationManager) Get(id string) (Notification, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.posted[id]
+	return n, ok
+}
+
+// IDs returns the IDs of the notifications currently tracked, sorted.
+func (m *NotificationManager) IDs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]string, 0, len(m.posted))
+	for id := range m.posted {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Remove removes the notification posted as id and stops tracking it. If
+// the removal fails the notification stays tracked, so RemoveAll can try
+// again.
+func (m *NotificationManager) Remove(ctx context.Context, id string) error {
+	m.opMu.Lock()
+	defer m.opMu.Unlock()
+
+	if err := m.c.NotifyRemoveContext(ctx, m.Prefix+id); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	delete(m.posted, id)
+	m.mu.Unlock()
+	return nil
+}
+
+// forget stops tracking id without removing its notification, so it
+// outlives the app.
+func (m *NotificationManager) forget(id string) {
+	m.mu.Lock()
+	delete(m.posted, id)
+	m.mu.Unlock()
+}
+
+// RemoveAll removes every tracked notification, returning the joined
+// errors of any removals that failed.
+func (m *NotificationManager) RemoveAll(ctx context.Context) error {
+	var errs []error
+	for _, id := range m.IDs() {
+		errs = append(errs, m.Remove(ctx, id))
+	}
+	return errors.Join(errs...)
+}
+
+// Close removes every tracked notification. Defer it from main.
+func (m *NotificationManager) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationCleanupTimeout)
+	defer cancel()
+	return m.RemoveAll(ctx)
+}
+
+// RemoveOnPanic removes every tracked notification if the program is
+// panicking, then continues the panic. It must be deferred directly:
+//
+//	defer notes.RemoveOnPanic()
+func (m *NotificationManager) RemoveOnPanic() {
+	if r := recover(); r != nil {
+		m.Close()
+		panic(r)
+	}
+}
+
+// RemoveOnSignal removes every tracked notification when the process
+// receives one of sigs (default: interrupt and SIGTERM), through the
+// handler shared with other OnSignal cleanups. It does not exit; see
+// OnSignalExit for programs that don't handle the signal themselves. Call
+// the returned function to stop watching.
+func (m *NotificationManager) RemoveOnSignal(sigs ...os.Signal) (stop func()) {
+	return OnSignal(func() { m.Close() }, sigs...)
+}
+
+// Sweep removes Termux notifications whose ID carries the manager's Prefix
+// but that it is not tracking, such as ongoing notifications left by a
+// previous run that was killed. It returns how many were removed.
+func (m *NotificationManager) Sweep(ctx context.Context) (int, error) {
+	active, err := m.c.ListNotificationsContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, n := range active {
+		id, ok := strings.CutPrefix(n.Tag, m.Prefix)
+		if !ok || n.PackageName != "com.termux" {
+			continue
+		}
+		if _, tracked := m.Get(id); tracked {
+			continue
+		}
+		if err := m.c.NotifyRemoveContext(ctx, n.Tag); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
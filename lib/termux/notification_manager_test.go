@@ -0,0 +1,176 @@
+package termux_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestListNotifications(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-notification-list", `[
+  {"id":0,"tag":"demo-sync","key":"0|com.termux|0|demo-sync|10123","group":"","packageName":"com.termux","title":"Sync","content":"Running","when":"2024-05-01 09:30:00"},
+  {"id":7,"tag":"","key":"0|com.example|7|null|10200","group":"g","packageName":"com.example","title":"Hi","content":"There","when":"bogus"}
+]`)
+	client := termux.NewClient(fake)
+
+	list, err := client.ListNotifications()
+	if err != nil {
+		t.Fatalf("ListNotifications: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("got %d notifications, want 2", len(list))
+	}
+	if list[0].Tag != "demo-sync" || list[0].PackageName != "com.termux" || list[0].Posted.Hour() != 9 {
+		t.Errorf("first = %+v", list[0])
+	}
+	if list[1].ID != 7 || !list[1].Posted.IsZero() {
+		t.Errorf("second = %+v", list[1])
+	}
+}
+
+func TestNotificationManager(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+	notes := termux.NewNotificationManager(client)
+	notes.Prefix = "demo-"
+	ctx := context.Background()
+
+	id, err := notes.Post(ctx, termux.Notification{Title: "Sync", Content: "Starting", Ongoing: true})
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if _, err := notes.Post(ctx, termux.Notification{ID: "build", Title: "Build"}); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if err := notes.Update(ctx, id, func(n *termux.Notification) { n.Content = "3 of 10" }); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := notes.Update(ctx, "nope", func(*termux.Notification) {}); err == nil {
+		t.Error("Update of unknown ID succeeded")
+	}
+
+	posts := fake.CallsTo("termux-notification")
+	if len(posts) != 3 {
+		t.Fatalf("posted %d times, want 3", len(posts))
+	}
+	want := []string{"--title", "Sync", "--content", "3 of 10", "--id", "demo-" + id, "--ongoing"}
+	if !reflect.DeepEqual(posts[2].Args, want) {
+		t.Errorf("update args = %q, want %q", posts[2].Args, want)
+	}
+
+	if err := notes.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	var removed []string
+	for _, call := range fake.CallsTo("termux-notification-remove") {
+		removed = append(removed, call.Args...)
+	}
+	if !reflect.DeepEqual(removed, []string{"demo-auto-1", "demo-build"}) {
+		t.Errorf("removed %q", removed)
+	}
+	if len(notes.IDs()) != 0 {
+		t.Errorf("still tracking %q", notes.IDs())
+	}
+}
+
+func TestNotificationManagerIDs(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	notes := termux.NewNotificationManager(termux.NewClient(termuxtest.NewRunner()))
+	ctx := context.Background()
+
+	// Automatic IDs never replace one the caller chose
+	for _, id := range []string{"1", "auto-1"} {
+		if _, err := notes.Post(ctx, termux.Notification{ID: id, Title: "Mine"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	id, err := notes.Post(ctx, termux.Notification{Title: "Auto"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "auto-2" {
+		t.Errorf("automatic ID = %q, want auto-2", id)
+	}
+	if n, _ := notes.Get("1"); n.Title != "Mine" {
+		t.Errorf("caller's notification replaced by %+v", n)
+	}
+}
+
+func TestNotificationManagerUpdateConcurrent(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	notes := termux.NewNotificationManager(termux.NewClient(termuxtest.NewRunner()))
+	ctx := context.Background()
+	id, _ := notes.Post(ctx, termux.Notification{Title: "Log"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			notes.Update(ctx, id, func(n *termux.Notification) { n.Content += "x" })
+		}()
+	}
+	wg.Wait()
+	if n, _ := notes.Get(id); n.Content != strings.Repeat("x", 20) {
+		t.Errorf("Content = %q, want every edit kept", n.Content)
+	}
+
+	// An update after removal doesn't bring the notification back
+	if err := notes.Remove(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if err := notes.Update(ctx, id, func(*termux.Notification) {}); err == nil {
+		t.Error("Update after Remove succeeded")
+	}
+}
+
+func TestNotificationManagerRemoveFailure(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	fake := termuxtest.NewRunner()
+	notes := termux.NewNotificationManager(termux.NewClient(fake))
+	ctx := context.Background()
+	id, _ := notes.Post(ctx, termux.Notification{Title: "Sync"})
+
+	fake.Exit("termux-notification-remove", 1, "boom")
+	fake.Respond("termux-notification-remove", "") // Succeeds on the retry
+	if err := notes.Remove(ctx, id); err == nil {
+		t.Fatal("Remove succeeded")
+	}
+	if ids := notes.IDs(); len(ids) != 1 || ids[0] != id {
+		t.Errorf("IDs after a failed removal = %q, want %q still tracked", ids, id)
+	}
+
+	if err := notes.RemoveAll(ctx); err != nil {
+		t.Fatalf("RemoveAll: %v", err)
+	}
+	if len(notes.IDs()) != 0 {
+		t.Errorf("still tracking %q", notes.IDs())
+	}
+}
+
+func TestNotificationManagerSweep(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-notification-list", `[
+  {"tag":"demo-1","packageName":"com.termux"},
+  {"tag":"other-1","packageName":"com.termux"},
+  {"tag":"demo-2","packageName":"com.example"}
+]`)
+	notes := termux.NewNotificationManager(termux.NewClient(fake))
+	notes.Prefix = "demo-"
+
+	n, err := notes.Sweep(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("Sweep = %d, %v; want 1, nil", n, err)
+	}
+	calls := fake.CallsTo("termux-notification-remove")
+	if len(calls) != 1 || calls[0].Args[0] != "demo-1" {
+		t.Errorf("removed %+v", calls)
+	}
+}
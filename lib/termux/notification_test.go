@@ -0,0 +1,118 @@
+package termux_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestPostNotificationArgs(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	err := client.PostNotification(termux.Notification{
+		ID:        "player",
+		Title:     "Now playing",
+		Content:   "Track 1",
+		Group:     "music",
+		Channel:   "media",
+		Type:      termux.NotificationMedia,
+		AlertOnce: true,
+		ImagePath: "/sdcard/cover.png",
+		LEDColor:  "#ff8800",
+		LEDOn:     500 * time.Millisecond,
+		Action:    []string{"termux-open", "/sdcard/Music"},
+		OnDelete:  []string{"termux-media-player", "stop"},
+		Buttons:   []termux.NotificationButton{{Label: "Like", Action: []string{"echo", "like it; rm -rf ~"}}},
+		Media: termux.MediaActions{
+			Play:  []string{"termux-media-player", "play"},
+			Pause: []string{"termux-media-player", "pause"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("PostNotification: %v", err)
+	}
+
+	want := []string{
+		"--title", "Now playing",
+		"--content", "Track 1",
+		"--id", "player",
+		"--button1", "Like",
+		"--button1-action", "echo 'like it; rm -rf ~'",
+		"--group", "music",
+		"--channel", "media",
+		"--type", "media",
+		"--alert-once",
+		"--image-path", "/sdcard/cover.png",
+		"--led-color", "ff8800",
+		"--led-on", "500",
+		"--action", "termux-open /sdcard/Music",
+		"--on-delete", "termux-media-player stop",
+		"--media-play", "termux-media-player play",
+		"--media-pause", "termux-media-player pause",
+	}
+	calls := fake.CallsTo("termux-notification")
+	if len(calls) != 1 || !reflect.DeepEqual(calls[0].Args, want) {
+		t.Errorf("calls = %q\nwant args %q", calls, want)
+	}
+}
+
+func TestNotificationValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		n    termux.Notification
+		ok   bool
+	}{
+		{"minimal", termux.Notification{Title: "hi"}, true},
+		{"priority", termux.Notification{Priority: "urgent"}, false},
+		{"type", termux.Notification{Type: "big"}, false},
+		{"media without type", termux.Notification{Media: termux.MediaActions{Play: []string{"x"}}}, false},
+		{"four buttons", termux.Notification{Buttons: make([]termux.NotificationButton, 4)}, false},
+		{"unlabeled button", termux.Notification{Buttons: []termux.NotificationButton{{Action: []string{"x"}}}}, false},
+		{"button without program", termux.Notification{Buttons: []termux.NotificationButton{{Label: "x", Action: []string{"", "a"}}}}, false},
+		{"action without program", termux.Notification{OnDelete: []string{""}}, false},
+		{"relative image", termux.Notification{ImagePath: "cover.png"}, false},
+		{"led color", termux.Notification{LEDColor: "orange"}, false},
+		{"vibrate", termux.Notification{Vibrate: "100,fast"}, false},
+		{"valid vibrate", termux.Notification{Vibrate: "100, 50,100"}, true},
+	}
+	for _, tt := range tests {
+		err := tt.n.Validate()
+		if tt.ok != (err == nil) {
+			t.Errorf("%s: Validate = %v, want ok=%v", tt.name, err, tt.ok)
+		}
+		if err != nil && !errors.Is(err, termux.ErrInvalidNotification) {
+			t.Errorf("%s: %v does not wrap ErrInvalidNotification", tt.name, err)
+		}
+	}
+
+	client := termux.NewClient(termuxtest.NewRunner())
+	if err := client.PostNotification(termux.Notification{Priority: "urgent"}); !errors.Is(err, termux.ErrInvalidNotification) {
+		t.Errorf("PostNotification with bad priority = %v", err)
+	}
+	if err := client.Notify("hi", "", termux.WithPriority("urgent")); !errors.Is(err, termux.ErrInvalidNotification) {
+		t.Errorf("Notify with bad priority = %v", err)
+	}
+}
+
+func TestShellCommand(t *testing.T) {
+	tests := []struct {
+		argv []string
+		want string
+	}{
+		{[]string{"termux-open-url", "https://example.com/a?b=c"}, `termux-open-url 'https://example.com/a?b=c'`},
+		{[]string{"termux-clipboard-set", "it's; rm -rf ~"}, `termux-clipboard-set 'it'\''s; rm -rf ~'`},
+		{[]string{"echo", ""}, `echo ''`},
+		{[]string{"echo", "$HOME"}, `echo '$HOME'`},
+	}
+	for _, tt := range tests {
+		if got := termux.ShellCommand(tt.argv[0], tt.argv[1:]...); got != tt.want {
+			t.Errorf("ShellCommand(%q) = %s, want %s", tt.argv, got, tt.want)
+		}
+	}
+}
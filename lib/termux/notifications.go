@@ -1,34 +1,21 @@
 package termux
 
 import (
-	"os/exec"
+	"context"
+	"encoding/json"
 	"strings"
+	"time"
 )
 
-// NotifyOption is a functional option for configuring notifications.
-type NotifyOption func(*notifyConfig)
-
-// notifyConfig holds the configuration for a notification.
-type notifyConfig struct {
-	id       string
-	ongoing  bool
-	priority string
-	icon     string
-	vibrate  string
-	sound    bool
-	buttons  []button
-}
-
-// button represents an action button in a notification.
-type button struct {
-	text   string
-	action string
-}
+// NotifyOption is a functional option for configuring notifications. Each
+// option sets fields of the Notification being posted.
+type NotifyOption func(*Notification)
 
 // Notify displays an Android notification with the given title and content.
 // Additional options can be provided to customize the notification behavior.
 //
-// If not running on Termux, this is a no-op.
+// On a Linux desktop the notification is shown with notify-send (see
+// LinuxBackend). Otherwise, if not running on Termux, this is a no-op.
 //
 // Example:
 //
@@ -37,59 +24,44 @@ type button struct {
 //	termux.Notify("AI Worker", "Processing task #123",
 //	    termux.WithID("worker-123"),
 //	    termux.WithPriority("high"),
-//	    termux.WithButton("View", "termux-open-url https://..."),
+//	    termux.WithButton("View", []string{"termux-open-url", "https://..."}),
 //	)
 func Notify(title, content string, opts ...NotifyOption) error {
-	if !IsTermux() {
-		return nil
-	}
-
-	config := &notifyConfig{}
-	for _, opt := range opts {
-		opt(config)
-	}
-
-	args := []string{"termux-notification"}
-	args = append(args, "--title", title)
-	args = append(args, "--content", content)
-
-	if config.id != "" {
-		args = append(args, "--id", config.id)
-	}
+	return Default().Notify(title, content, opts...)
+}
 
-	if config.ongoing {
-		args = append(args, "--ongoing")
-	}
+// NotifyContext is like Notify but takes a context. The command is killed
+// if ctx is done before it completes.
+func NotifyContext(ctx context.Context, title, content string, opts ...NotifyOption) error {
+	return Default().NotifyContext(ctx, title, content, opts...)
+}
 
-	if config.priority != "" {
-		args = append(args, "--priority", config.priority)
-	}
+// Notify displays an Android notification with the given title and content.
+func (c *Client) Notify(title, content string, opts ...NotifyOption) error {
+	return c.NotifyContext(context.Background(), title, content, opts...)
+}
 
-	if config.icon != "" {
-		args = append(args, "--icon", config.icon)
-	}
+// NotifyContext is like Notify but takes a context.
+func (c *Client) NotifyContext(ctx context.Context, title, content string, opts ...NotifyOption) error {
+	return c.Backend().Notify(ctx, title, content, opts...)
+}
 
-	if config.vibrate != "" {
-		args = append(args, "--vibrate", config.vibrate)
+// termuxNotify posts a notification with termux-notification.
+func (c *Client) termuxNotify(ctx context.Context, title, content string, opts ...NotifyOption) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
 	}
 
-	if config.sound {
-		args = append(args, "--sound")
+	n := &Notification{Title: title, Content: content}
+	for _, opt := range opts {
+		opt(n)
 	}
-
-	// Add up to 3 buttons
-	buttonLabels := []string{"--button1", "--button2", "--button3"}
-	actionLabels := []string{"--button1-action", "--button2-action", "--button3-action"}
-	for i, btn := range config.buttons {
-		if i >= 3 {
-			break // Termux supports max 3 buttons
-		}
-		args = append(args, buttonLabels[i], btn.text)
-		args = append(args, actionLabels[i], btn.action)
+	if err := n.Validate(); err != nil {
+		return err
 	}
 
-	cmd := exec.Command(args[0], args[1:]...)
-	return cmd.Run()
+	_, err := c.run(ctx, "termux-notification", n.args()...)
+	return err
 }
 
 // NotifyRemove removes a notification by its ID.
@@ -100,12 +72,28 @@ func Notify(title, content string, opts ...NotifyOption) error {
 //
 //	termux.NotifyRemove("worker-123")
 func NotifyRemove(id string) error {
-	if !IsTermux() {
-		return nil
+	return Default().NotifyRemove(id)
+}
+
+// NotifyRemoveContext is like NotifyRemove but takes a context. The
+// command is killed if ctx is done before it completes.
+func NotifyRemoveContext(ctx context.Context, id string) error {
+	return Default().NotifyRemoveContext(ctx, id)
+}
+
+// NotifyRemove removes a notification by its ID.
+func (c *Client) NotifyRemove(id string) error {
+	return c.NotifyRemoveContext(context.Background(), id)
+}
+
+// NotifyRemoveContext is like NotifyRemove but takes a context.
+func (c *Client) NotifyRemoveContext(ctx context.Context, id string) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-notification-remove", id)
-	return cmd.Run()
+	_, err := c.run(ctx, "termux-notification-remove", id)
+	return err
 }
 
 // WithID sets a unique identifier for the notification.
@@ -123,8 +111,8 @@ func NotifyRemove(id string) error {
 //	// Or remove it:
 //	termux.NotifyRemove("worker-123")
 func WithID(id string) NotifyOption {
-	return func(c *notifyConfig) {
-		c.id = id
+	return func(n *Notification) {
+		n.ID = id
 	}
 }
 
@@ -136,8 +124,8 @@ func WithID(id string) NotifyOption {
 //	termux.Notify("Sync Active", "Syncing files...",
 //	    termux.WithOngoing())
 func WithOngoing() NotifyOption {
-	return func(c *notifyConfig) {
-		c.ongoing = true
+	return func(n *Notification) {
+		n.Ongoing = true
 	}
 }
 
@@ -152,8 +140,8 @@ func WithOngoing() NotifyOption {
 //	termux.Notify("Error", "Build failed",
 //	    termux.WithPriority("high"))
 func WithPriority(priority string) NotifyOption {
-	return func(c *notifyConfig) {
-		c.priority = priority
+	return func(n *Notification) {
+		n.Priority = priority
 	}
 }
 
@@ -165,8 +153,8 @@ func WithPriority(priority string) NotifyOption {
 //	termux.Notify("Syncing", "Downloading files...",
 //	    termux.WithIcon("sync"))
 func WithIcon(icon string) NotifyOption {
-	return func(c *notifyConfig) {
-		c.icon = icon
+	return func(n *Notification) {
+		n.Icon = icon
 	}
 }
 
@@ -178,8 +166,8 @@ func WithIcon(icon string) NotifyOption {
 //	termux.Notify("Alert", "Important message",
 //	    termux.WithVibrate("100,50,100"))  // Two short bursts
 func WithVibrate(pattern string) NotifyOption {
-	return func(c *notifyConfig) {
-		c.vibrate = pattern
+	return func(n *Notification) {
+		n.Vibrate = pattern
 	}
 }
 
@@ -190,45 +178,160 @@ func WithVibrate(pattern string) NotifyOption {
 //	termux.Notify("Complete", "Task finished",
 //	    termux.WithSound())
 func WithSound() NotifyOption {
-	return func(c *notifyConfig) {
-		c.sound = true
+	return func(n *Notification) {
+		n.Sound = true
 	}
 }
 
-// WithButton adds an action button to the notification.
-// Up to 3 buttons can be added. Additional buttons will be ignored.
+// WithButton adds an action button to the notification. Up to 3 buttons
+// can be added; Notify rejects more with ErrInvalidNotification.
 //
-// The action parameter is a command to execute when the button is tapped.
-// Common actions:
-//   - "termux-open-url <url>" - Open a URL
-//   - "termux-clipboard-set <text>" - Copy text to clipboard
-//   - Any shell command
+// The action is the program and arguments run when the button is tapped.
+// Each word is quoted, so text such as a URL is never interpreted by the
+// shell. Common actions:
+//   - termux-open-url <url> - Open a URL
+//   - termux-clipboard-set <text> - Copy text to clipboard
 //
 // Example:
 //
 //	termux.Notify("PR Ready", "Pull request #123 created",
-//	    termux.WithButton("View", "termux-open-url https://github.com/..."),
-//	    termux.WithButton("Copy URL", "bash -c 'echo https://... | termux-clipboard-set'"))
-func WithButton(text, action string) NotifyOption {
-	return func(c *notifyConfig) {
-		c.buttons = append(c.buttons, button{text: text, action: action})
+//	    termux.WithButton("View", []string{"termux-open-url", prURL}),
+//	    termux.WithButton("Copy URL", []string{"termux-clipboard-set", prURL}))
+func WithButton(text string, action []string) NotifyOption {
+	return func(n *Notification) {
+		n.Buttons = append(n.Buttons, NotificationButton{Label: text, Action: action})
+	}
+}
+
+// WithAction sets the program and arguments run when the notification
+// itself is tapped. See WithButton.
+func WithAction(action []string) NotifyOption {
+	return func(n *Notification) {
+		n.Action = action
+	}
+}
+
+// WithOnDelete sets the program and arguments run when the notification
+// is dismissed. See WithButton.
+func WithOnDelete(action []string) NotifyOption {
+	return func(n *Notification) {
+		n.OnDelete = action
+	}
+}
+
+// WithGroup bundles the notification with others in the same group.
+func WithGroup(group string) NotifyOption {
+	return func(n *Notification) {
+		n.Group = group
+	}
+}
+
+// WithAlertOnce keeps updates to the notification (same WithID) from
+// sounding or vibrating again. Useful for progress notifications.
+func WithAlertOnce() NotifyOption {
+	return func(n *Notification) {
+		n.AlertOnce = true
 	}
 }
 
 // NotificationList returns a list of currently active notifications.
-// The output is in JSON format.
+// The output is in JSON format; ListNotifications parses it.
 //
 // If not running on Termux, returns an empty string.
 func NotificationList() (string, error) {
-	if !IsTermux() {
-		return "", nil
+	return Default().NotificationList()
+}
+
+// NotificationListContext is like NotificationList but takes a context.
+// The command is killed if ctx is done before it completes.
+func NotificationListContext(ctx context.Context) (string, error) {
+	return Default().NotificationListContext(ctx)
+}
+
+// NotificationList returns a list of currently active notifications.
+func (c *Client) NotificationList() (string, error) {
+	return c.NotificationListContext(context.Background())
+}
+
+// NotificationListContext is like NotificationList but takes a context.
+func (c *Client) NotificationListContext(ctx context.Context) (string, error) {
+	if !c.IsTermux() {
+		return "", c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-notification-list")
-	output, err := cmd.Output()
+	output, err := c.run(ctx, "termux-notification-list")
 	if err != nil {
 		return "", err
 	}
 
 	return strings.TrimSpace(string(output)), nil
 }
+
+// ActiveNotification is a notification currently shown on the device, as
+// reported by termux-notification-list. It covers every app's
+// notifications, not just those posted through Termux.
+type ActiveNotification struct {
+	ID          int    `json:"id"`          // Android notification ID
+	Tag         string `json:"tag"`         // Tag; for Termux notifications, the ID given to WithID
+	Key         string `json:"key"`         // System-wide unique key
+	Group       string `json:"group"`       // Group key, if any
+	PackageName string `json:"packageName"` // Posting app (e.g., "com.termux")
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	When        string `json:"when"` // Post time as reported (e.g., "2024-05-01 09:30:00")
+
+	// Posted is When parsed in the local time zone, or zero if it could
+	// not be parsed.
+	Posted time.Time `json:"-"`
+}
+
+// notificationTimeLayout is the format termux-notification-list uses for
+// "when".
+const notificationTimeLayout = "2006-01-02 15:04:05"
+
+// ListNotifications returns the notifications currently shown on the
+// device.
+//
+// If not running on Termux, returns an empty list.
+//
+// Example:
+//
+//	active, err := termux.ListNotifications()
+//	for _, n := range active {
+//	    if n.PackageName == "com.termux" {
+//	        fmt.Println(n.Tag, n.Title)
+//	    }
+//	}
+func ListNotifications() ([]ActiveNotification, error) {
+	return Default().ListNotifications()
+}
+
+// ListNotificationsContext is like ListNotifications but takes a context.
+// The command is killed if ctx is done before it completes.
+func ListNotificationsContext(ctx context.Context) ([]ActiveNotification, error) {
+	return Default().ListNotificationsContext(ctx)
+}
+
+// ListNotifications returns the notifications currently shown on the device.
+func (c *Client) ListNotifications() ([]ActiveNotification, error) {
+	return c.ListNotificationsContext(context.Background())
+}
+
+// ListNotificationsContext is like ListNotifications but takes a context.
+func (c *Client) ListNotificationsContext(ctx context.Context) ([]ActiveNotification, error) {
+	output, err := c.NotificationListContext(ctx)
+	if err != nil || output == "" {
+		return nil, err
+	}
+
+	var list []ActiveNotification
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, err
+	}
+	for i := range list {
+		if t, err := time.ParseInLocation(notificationTimeLayout, list[i].When, time.Local); err == nil {
+			list[i].Posted = t
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,61 @@
+package termux
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+)
+
+// WriteOSC52 asks the terminal to copy text to the given selection using
+// the OSC 52 escape sequence. Because the terminal emulator does the
+// copying, this works over SSH and needs no clipboard tools on the host,
+// but the terminal may ignore the request (or cap its length).
+//
+// Inside tmux the sequence is wrapped in a passthrough; tmux needs
+// "set -g allow-passthrough on" (or set-clipboard) for it to reach the
+// outer terminal.
+//
+// Example:
+//
+//	termux.WriteOSC52(os.Stdout, termux.SelectionClipboard, "copied over ssh")
+func WriteOSC52(w io.Writer, sel Selection, text string) error {
+	target := "c"
+	if sel == SelectionPrimary {
+		target = "p"
+	}
+	seq := "\x1b]52;" + target + ";" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+
+	if os.Getenv("TMUX") != "" {
+		seq = "\x1bPtmux;\x1b" + seq + "\x1b\\"
+	}
+
+	_, err := io.WriteString(w, seq)
+	return err
+}
+
+// openTerminal returns the controlling terminal, or nil when the process
+// has none (CI, cron, services). The sequence carries the copied text, so
+// it is never written anywhere else, such as stderr, where it would end
+// up in logs.
+func openTerminal() io.WriteCloser {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return nil
+	}
+	return tty
+}
+
+// writeTerminal sends an OSC 52 copy to w, or to the controlling terminal
+// when w is nil. Without a terminal it degrades like a missing clipboard
+// tool: a no-op, or ErrNotTermux in c's strict mode.
+func writeTerminal(c *Client, w io.Writer, sel Selection, text string) error {
+	if w == nil {
+		tty := openTerminal()
+		if tty == nil {
+			return c.fallbackErr()
+		}
+		defer tty.Close()
+		w = tty
+	}
+	return WriteOSC52(w, sel, text)
+}
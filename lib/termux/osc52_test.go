@@ -0,0 +1,69 @@
+//go:build unix
+
+package termux_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+// noTTYHelperEnv makes TestNoTTYHelper act as a child process for
+// TestOSC52WithoutTerminal instead of a test.
+const noTTYHelperEnv = "TERMUX_NO_TTY_HELPER"
+
+// TestNoTTYHelper is the child process of TestOSC52WithoutTerminal. It
+// copies with no clipboard tools and no controlling terminal, and reports
+// the results on stdout.
+func TestNoTTYHelper(t *testing.T) {
+	if os.Getenv(noTTYHelperEnv) == "" {
+		t.Skip("helper process only")
+	}
+
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate", "wl-copy", "xclip", "xsel")
+	client := termux.NewClient(fake)
+	client.SetBackend(termux.NewLinuxBackend(client))
+
+	lax := client.ClipboardSet("secret")
+	client.SetStrict(true)
+	strict := client.ClipboardSet("secret")
+	if lax == nil && errors.Is(strict, termux.ErrNotTermux) {
+		os.Stdout.WriteString("ok\n")
+	} else {
+		os.Stdout.WriteString("lax: " + errString(lax) + ", strict: " + errString(strict) + "\n")
+	}
+	os.Exit(0)
+}
+
+func errString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
+
+func TestOSC52WithoutTerminal(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestNoTTYHelper$")
+	cmd.Env = append(os.Environ(), noTTYHelperEnv+"=1")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true} // No controlling terminal
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("helper: %v: %s", err, stderr.String())
+	}
+
+	if got := strings.TrimSpace(stdout.String()); got != "ok" {
+		t.Errorf("ClipboardSet without a terminal: %s", got)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want nothing written", stderr.String())
+	}
+}
@@ -0,0 +1,352 @@
+package termux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Progress is a snapshot of a background job's progress.
+type Progress struct {
+	Percent float64       // 0-100; negative means unknown
+	Status  string        // Short description of the current step
+	ETA     time.Duration // Estimated time remaining; zero means unknown
+}
+
+// String formats p as notification text, e.g.
+// "▓▓▓▓░░░░░░ 40% · Compiling · 2m left".
+func (p Progress) String() string {
+	var parts []string
+	if p.Percent >= 0 {
+		pct := math.Min(p.Percent, 100)
+		filled := int(pct / 10)
+		parts = append(parts, strings.Repeat("▓", filled)+strings.Repeat("░", 10-filled)+" "+formatInt(int(pct))+"%")
+	}
+	if p.Status != "" {
+		parts = append(parts, p.Status)
+	}
+	if p.ETA > 0 {
+		parts = append(parts, formatETA(p.ETA)+" left")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatETA rounds d to a readable precision: seconds under a minute,
+// minutes under an hour, and hours and minutes beyond.
+func formatETA(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return formatInt(int(d.Round(time.Second)/time.Second)) + "s"
+	case d < time.Hour:
+		return formatInt(int(d.Round(time.Minute)/time.Minute)) + "m"
+	default:
+		d = d.Round(time.Minute)
+		return formatInt(int(d/time.Hour)) + "h" + formatInt(int(d%time.Hour/time.Minute)) + "m"
+	}
+}
+
+// ProgressOptions configures StartProgress.
+type ProgressOptions struct {
+	ID    string // Notification ID; required, or nothing is posted
+	Title string // Notification title, e.g. "Building myapp"
+
+	// MinInterval is the minimum time between notification updates.
+	// Updates arriving faster are coalesced into the latest one. Zero
+	// means 2 seconds.
+	MinInterval time.Duration
+
+	// Cancelable adds a Cancel button. Tapping it cancels the context
+	// returned by ProgressNotifier.Context.
+	Cancelable bool
+
+	// Callbacks receives the Cancel button's taps. When nil, a cancelable
+	// notifier creates its own and closes it when the job finishes. Taps
+	// are handled by ID, so they don't reach Callbacks.Actions.
+	Callbacks *NotificationCallbacks
+
+	// Manager, when set, posts the notifications through it so an
+	// unfinished job's notification is cleaned up with the rest of the
+	// app's on exit. The final Done or Fail notification is left in place.
+	Manager *NotificationManager
+}
+
+// progressPostTimeout bounds each notification post made by a
+// ProgressNotifier.
+const progressPostTimeout = 10 * time.Second
+
+// ProgressNotifier mirrors a background job's progress in an ongoing
+// notification and reports the outcome when the job ends.
+//
+// The Cancel button reports taps through a NotificationCallbacks pipe, so
+// a cancelable notifier needs a Unix-like system. Elsewhere the button is
+// left out and Err reports why.
+type ProgressNotifier struct {
+	c          *Client
+	opts       ProgressOptions
+	ctx        context.Context
+	cancel     context.CancelFunc
+	cancelBtn  *NotificationButton // nil if not cancelable
+	stopCancel func()              // Stops handling Cancel taps
+	ownCb      bool                // opts.Callbacks was created by StartProgress
+
+	// postMu serializes notification posts, so they land in order and
+	// none lands after the final one. It is taken before mu.
+	postMu sync.Mutex
+
+	mu        sync.Mutex
+	latest    Progress
+	lastPost  time.Time
+	timer     *time.Timer
+	finished  bool
+	cancelled bool
+	err       error // From the most recent post
+	setupErr  error // From checking opts or adding the Cancel button
+}
+
+// StartProgress posts an ongoing progress notification and returns a
+// notifier to update it. The notifier's Context is derived from ctx and is
+// also cancelled when the user taps Cancel.
+//
+// Example:
+//
+//	p := termux.StartProgress(ctx, termux.ProgressOptions{
+//	    ID:         "build",
+//	    Title:      "Building myapp",
+//	    Cancelable: true,
+//	})
+//	err := build(p.Context(), func(pct float64, step string) {
+//	    p.Update(termux.Progress{Percent: pct, Status: step})
+//	})
+//	if err != nil {
+//	    p.Fail(err)
+//	    return
+//	}
+//	p.Done("Built in 3m", termux.NotificationButton{
+//	    Label:  "Open log",
+//	    Action: []string{"termux-open", logPath},
+//	})
+func StartProgress(ctx context.Context, opts ProgressOptions) *ProgressNotifier {
+	return Default().StartProgress(ctx, opts)
+}
+
+// StartProgress posts an ongoing progress notification and returns a
+// notifier to update it. Without opts.ID nothing is posted: every post
+// would add a new notification rather than replace the last, so Err,
+// Done and Fail report an error wrapping ErrInvalidNotification instead.
+func (c *Client) StartProgress(ctx context.Context, opts ProgressOptions) *ProgressNotifier {
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = 2 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &ProgressNotifier{
+		c:      c,
+		opts:   opts,
+		ctx:    ctx,
+		cancel: cancel,
+		latest: Progress{Percent: -1, Status: "Starting"},
+	}
+	if opts.ID == "" {
+		p.setupErr = fmt.Errorf("%w: progress notification needs an ID", ErrInvalidNotification)
+		p.finished = true
+		return p
+	}
+
+	if opts.Cancelable {
+		p.setupErr = p.watchCancel()
+	}
+	p.post()
+	return p
+}
+
+// Context returns a context that is cancelled when the user taps Cancel,
+// when the parent context is done, or when the job is reported finished.
+func (p *ProgressNotifier) Context() context.Context {
+	return p.ctx
+}
+
+// Cancelled reports whether the user tapped Cancel.
+func (p *ProgressNotifier) Cancelled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cancelled
+}
+
+// Err returns the error from the most recent notification post if it
+// failed, and otherwise the error from StartProgress checking the options
+// or adding the Cancel button, which later posts don't clear.
+func (p *ProgressNotifier) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	return p.setupErr
+}
+
+// Update records the job's progress. The notification is updated in the
+// background, right away if MinInterval has passed since the last update
+// and otherwise once it has, showing the latest progress. Update never
+// waits for the notification to be posted.
+func (p *ProgressNotifier) Update(progress Progress) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.finished {
+		return
+	}
+	p.latest = progress
+
+	if p.timer != nil {
+		return // a flush is already scheduled
+	}
+	wait := max(p.opts.MinInterval-time.Since(p.lastPost), 0)
+	p.timer = time.AfterFunc(wait, func() {
+		p.mu.Lock()
+		p.timer = nil
+		p.mu.Unlock()
+		p.post()
+	})
+}
+
+// Done replaces the progress notification with a dismissible success
+// notification showing message and buttons.
+func (p *ProgressNotifier) Done(message string, buttons ...NotificationButton) error {
+	return p.finish(Notification{
+		Title:   p.opts.Title,
+		Content: message,
+		Icon:    "done",
+		Buttons: buttons,
+	})
+}
+
+// Fail replaces the progress notification with a failure notification for
+// err and buttons. If the user cancelled the job, it says so instead.
+func (p *ProgressNotifier) Fail(err error, buttons ...NotificationButton) error {
+	content := "Failed"
+	if err != nil {
+		content = "Failed: " + err.Error()
+	}
+	if p.Cancelled() || errors.Is(err, context.Canceled) {
+		content = "Cancelled"
+	}
+	return p.finish(Notification{
+		Title:    p.opts.Title,
+		Content:  content,
+		Icon:     "error",
+		Priority: "high",
+		Buttons:  buttons,
+	})
+}
+
+// finish stops updates and posts the final notification n.
+func (p *ProgressNotifier) finish(n Notification) error {
+	p.mu.Lock()
+	if p.opts.ID == "" {
+		p.mu.Unlock()
+		p.cancel()
+		return p.setupErr
+	}
+	if p.finished {
+		p.mu.Unlock()
+		return errors.New("termux: progress already finished")
+	}
+	p.finished = true
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	p.mu.Unlock()
+
+	p.cancel()
+	if p.stopCancel != nil {
+		p.stopCancel()
+		if p.ownCb {
+			p.opts.Callbacks.Close()
+		}
+	}
+
+	// Wait out a progress post in flight so it can't replace n
+	p.postMu.Lock()
+	defer p.postMu.Unlock()
+	n.ID = p.opts.ID
+	err := p.send(n)
+	if p.opts.Manager != nil {
+		p.opts.Manager.forget(n.ID)
+	}
+	return err
+}
+
+// post shows the latest progress, unless the job has finished.
+func (p *ProgressNotifier) post() {
+	p.postMu.Lock()
+	defer p.postMu.Unlock()
+
+	p.mu.Lock()
+	if p.finished {
+		p.mu.Unlock()
+		return
+	}
+	n := Notification{
+		ID:        p.opts.ID,
+		Title:     p.opts.Title,
+		Content:   p.latest.String(),
+		Icon:      "sync",
+		Ongoing:   true,
+		AlertOnce: true,
+	}
+	if p.cancelBtn != nil {
+		n.Buttons = []NotificationButton{*p.cancelBtn}
+	}
+	p.lastPost = time.Now()
+	p.mu.Unlock()
+
+	err := p.send(n)
+	p.mu.Lock()
+	p.err = err
+	p.mu.Unlock()
+}
+
+// send posts n directly or through the configured manager.
+func (p *ProgressNotifier) send(n Notification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), progressPostTimeout)
+	defer cancel()
+
+	if p.opts.Manager != nil {
+		_, err := p.opts.Manager.Post(ctx, n)
+		return err
+	}
+	return p.c.PostNotificationContext(ctx, n)
+}
+
+// watchCancel adds the Cancel button, handling its taps through
+// opts.Callbacks.
+func (p *ProgressNotifier) watchCancel() error {
+	if p.opts.Callbacks == nil {
+		cb, err := NewNotificationCallbacks("")
+		if err != nil {
+			return err
+		}
+		p.opts.Callbacks = cb
+		p.ownCb = true
+	}
+
+	btn := p.opts.Callbacks.Button(p.opts.ID, "Cancel")
+	p.cancelBtn = &btn
+	p.stopCancel = p.opts.Callbacks.Handle(p.opts.ID, func(a NotificationAction) {
+		if a.Button != btn.Label {
+			return
+		}
+		p.mu.Lock()
+		if !p.finished {
+			p.cancelled = true
+		}
+		p.mu.Unlock()
+		p.cancel()
+	})
+	return nil
+}
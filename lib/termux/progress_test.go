@@ -0,0 +1,272 @@
+package termux_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestProgressString(t *testing.T) {
+	tests := []struct {
+		p    termux.Progress
+		want string
+	}{
+		{termux.Progress{Percent: 40, Status: "Compiling", ETA: 90 * time.Second}, "▓▓▓▓░░░░░░ 40% · Compiling · 2m left"},
+		{termux.Progress{Percent: -1, Status: "Waiting"}, "Waiting"},
+		{termux.Progress{Percent: 100, ETA: 75 * time.Minute}, "▓▓▓▓▓▓▓▓▓▓ 100% · 1h15m left"},
+	}
+	for _, tt := range tests {
+		if got := tt.p.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestProgressNotifierRateLimit(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	p := client.StartProgress(context.Background(), termux.ProgressOptions{
+		ID:          "sync",
+		Title:       "Syncing",
+		MinInterval: 50 * time.Millisecond,
+	})
+	for i := 1; i <= 10; i++ {
+		p.Update(termux.Progress{Percent: float64(i * 10)})
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	posts := fake.CallsTo("termux-notification")
+	// The initial post plus one coalesced flush of the latest update
+	if len(posts) != 2 {
+		t.Fatalf("posted %d times, want 2", len(posts))
+	}
+	if got := argAfter(posts[1].Args, "--content"); !strings.Contains(got, "100%") {
+		t.Errorf("flushed content = %q, want the latest progress", got)
+	}
+
+	if err := p.Done("Synced 10 files"); err != nil {
+		t.Fatalf("Done: %v", err)
+	}
+	final := fake.CallsTo("termux-notification")[2].Args
+	if argAfter(final, "--content") != "Synced 10 files" || contains(final, "--ongoing") {
+		t.Errorf("final args = %q", final)
+	}
+	if p.Context().Err() == nil {
+		t.Error("Context not cancelled after Done")
+	}
+}
+
+func TestProgressNotifierUpdateDoesNotWait(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-notification", "")
+	fake.RespondWith("termux-notification", termuxtest.Response{Delay: 300 * time.Millisecond})
+	fake.Respond("termux-notification", "")
+	client := termux.NewClient(fake)
+
+	p := client.StartProgress(context.Background(), termux.ProgressOptions{ID: "sync", MinInterval: time.Millisecond})
+	time.Sleep(10 * time.Millisecond)
+	go p.Update(termux.Progress{Percent: 10})
+	time.Sleep(50 * time.Millisecond) // The slow post is now running
+
+	start := time.Now()
+	for i := 2; i <= 5; i++ {
+		p.Update(termux.Progress{Percent: float64(i * 10)})
+	}
+	p.Cancelled()
+	p.Err()
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Update and Err waited %v for a post in flight", elapsed)
+	}
+
+	if err := p.Done("Synced"); err != nil {
+		t.Fatalf("Done: %v", err)
+	}
+	posts := fake.CallsTo("termux-notification")
+	if got := argAfter(posts[len(posts)-1].Args, "--content"); got != "Synced" {
+		t.Errorf("last post = %q, want the final notification", got)
+	}
+}
+
+func TestProgressNotifierManagerKeepsFinal(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	fake := termuxtest.NewRunner()
+	notes := termux.NewNotificationManager(termux.NewClient(fake))
+	notes.Prefix = "app-"
+
+	p := termux.NewClient(fake).StartProgress(context.Background(), termux.ProgressOptions{ID: "build", Manager: notes})
+	if ids := notes.IDs(); len(ids) != 1 || ids[0] != "build" {
+		t.Fatalf("tracked while running = %v, want [build]", ids)
+	}
+	if err := p.Done("Built"); err != nil {
+		t.Fatalf("Done: %v", err)
+	}
+	if got := argAfter(fake.CallsTo("termux-notification")[1].Args, "--id"); got != "app-build" {
+		t.Errorf("final notification ID = %q, want app-build", got)
+	}
+
+	notes.Close()
+	if removed := fake.CallsTo("termux-notification-remove"); len(removed) != 0 {
+		t.Errorf("Close removed %v, want the final notification kept", removed)
+	}
+}
+
+func TestProgressNotifierErrKeepsPostError(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	t.Setenv("TMPDIR", filepath.Join(t.TempDir(), "missing")) // Cancel button setup fails
+	fake := termuxtest.NewRunner()
+	fake.Exit("termux-notification", 1, "boom")
+	client := termux.NewClient(fake)
+
+	p := client.StartProgress(context.Background(), termux.ProgressOptions{
+		ID:         "build",
+		Title:      "Building",
+		Cancelable: true,
+	})
+	defer p.Done("")
+	var cmdErr *termux.CommandError
+	if err := p.Err(); !errors.As(err, &cmdErr) {
+		t.Errorf("Err = %v, want the failed post", err)
+	}
+}
+
+func TestProgressNotifierErrKeepsSetupError(t *testing.T) {
+	t.Setenv(termux.BackendEnv, "termux")
+	t.Setenv("TMPDIR", filepath.Join(t.TempDir(), "missing")) // Cancel button setup fails
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	p := client.StartProgress(context.Background(), termux.ProgressOptions{
+		ID:          "build",
+		Title:       "Building",
+		Cancelable:  true,
+		MinInterval: time.Millisecond,
+	})
+	defer p.Done("")
+	setupErr := p.Err()
+	if setupErr == nil {
+		t.Fatal("Err = nil, want the Cancel button error")
+	}
+
+	// A successful post doesn't hide it
+	p.Update(termux.Progress{Percent: 50})
+	deadline := time.Now().Add(5 * time.Second)
+	for len(fake.CallsTo("termux-notification")) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := p.Err(); err != setupErr {
+		t.Errorf("Err after a successful post = %v, want %v", err, setupErr)
+	}
+}
+
+func TestProgressNotifierNeedsID(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	p := client.StartProgress(context.Background(), termux.ProgressOptions{Title: "Building"})
+	if err := p.Err(); !errors.Is(err, termux.ErrInvalidNotification) {
+		t.Errorf("Err = %v, want ErrInvalidNotification", err)
+	}
+	p.Update(termux.Progress{Percent: 50})
+	if err := p.Done("Built"); !errors.Is(err, termux.ErrInvalidNotification) {
+		t.Errorf("Done = %v, want ErrInvalidNotification", err)
+	}
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("ran %v, want nothing posted", calls)
+	}
+}
+
+func TestProgressNotifierCancel(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell")
+	}
+	t.Setenv(termux.BackendEnv, "termux")
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+	cb, err := termux.NewNotificationCallbacks(t.TempDir())
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("NewNotificationCallbacks: %v", err)
+	}
+	defer cb.Close()
+
+	p := client.StartProgress(context.Background(), termux.ProgressOptions{
+		ID:         "build",
+		Title:      "Building",
+		Cancelable: true,
+		Callbacks:  cb,
+	})
+	if err := p.Err(); err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+
+	args := fake.CallsTo("termux-notification")[0].Args
+	if argAfter(args, "--button1") != "Cancel" {
+		t.Fatalf("args = %q, want a Cancel button", args)
+	}
+	// Tap the button: run its action the way Termux would
+	if out, err := exec.Command("sh", "-c", argAfter(args, "--button1-action")).CombinedOutput(); err != nil {
+		t.Fatalf("cancel action: %v: %s", err, out)
+	}
+	// Other notifications' buttons still reach the app
+	other := cb.Action("pr-42", "Approve")
+	if out, err := exec.Command(other[0], other[1:]...).CombinedOutput(); err != nil {
+		t.Fatalf("other action: %v: %s", err, out)
+	}
+	select {
+	case got := <-cb.Actions():
+		if got.ID != "pr-42" {
+			t.Errorf("Actions delivered %+v, want only the pr-42 tap", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("other notification's action not delivered")
+	}
+
+	select {
+	case <-p.Context().Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Context not cancelled after tapping Cancel")
+	}
+	if !p.Cancelled() {
+		t.Error("Cancelled = false")
+	}
+
+	p.Fail(p.Context().Err())
+	posts := fake.CallsTo("termux-notification")
+	if got := argAfter(posts[len(posts)-1].Args, "--content"); got != "Cancelled" {
+		t.Errorf("final content = %q, want Cancelled", got)
+	}
+	if err := p.Done("again"); err == nil || errors.Is(err, context.Canceled) {
+		t.Errorf("second finish = %v, want an error", err)
+	}
+}
+
+// argAfter returns the argument following flag, or "".
+func argAfter(args []string, flag string) string {
+	for i, arg := range args {
+		if arg == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func contains(args []string, s string) bool {
+	for _, arg := range args {
+		if arg == s {
+			return true
+		}
+	}
+	return false
+}
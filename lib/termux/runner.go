@@ -0,0 +1,109 @@
+package termux
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Command describes a single external command invocation made by a Client.
+type Command struct {
+	Name  string   // Executable name (e.g., "termux-vibrate")
+	Args  []string // Arguments passed to the executable
+	Stdin []byte   // Data piped to standard input, if any
+
+	// Stdout, when set, receives the command's output as it is produced
+	// instead of it being returned from Run. Used for long-running
+	// commands such as continuous sensor readings.
+	Stdout io.Writer
+
+	// DiscardOutput connects stdout and stderr to the null device instead
+	// of capturing them. Used for commands such as xclip that fork a
+	// process to keep serving after they exit: that process would hold a
+	// captured pipe open and stall Run until it gives up.
+	DiscardOutput bool
+}
+
+// String returns the command line as it would be typed in a shell.
+// It is intended for logging and test failure messages, not for execution.
+func (c Command) String() string {
+	if len(c.Args) == 0 {
+		return c.Name
+	}
+	return c.Name + " " + strings.Join(c.Args, " ")
+}
+
+// Runner executes external commands on behalf of a Client.
+//
+// The default implementation, ExecRunner, uses os/exec. Tests can supply
+// a fake (see the termuxtest package) to exercise every Termux code path
+// on a machine without Termux installed.
+type Runner interface {
+	// Run executes the command and returns its standard output.
+	// Implementations must stop the command and return once ctx is done,
+	// and should report a non-zero exit status as an *ExitError.
+	Run(ctx context.Context, cmd Command) ([]byte, error)
+
+	// LookPath reports where the named executable is installed.
+	// It returns an error if the executable cannot be found.
+	LookPath(name string) (string, error)
+}
+
+// ExecRunner is a Runner that executes real processes using os/exec.
+//
+// Termux API commands are shell scripts that hand off to a helper binary,
+// so on cancellation ExecRunner kills the whole process group rather than
+// just the script, and gives up waiting on leftover output after WaitDelay.
+type ExecRunner struct{}
+
+// execWaitDelay bounds how long Run waits for a killed command's I/O to close.
+const execWaitDelay = 2 * time.Second
+
+// Run executes the command and returns its standard output.
+func (ExecRunner) Run(ctx context.Context, cmd Command) ([]byte, error) {
+	c := exec.CommandContext(ctx, cmd.Name, cmd.Args...)
+	setProcessGroup(c)
+	c.Cancel = func() error {
+		return killProcessGroup(c)
+	}
+	c.WaitDelay = execWaitDelay
+	if cmd.Stdin != nil {
+		c.Stdin = bytes.NewReader(cmd.Stdin)
+	}
+
+	if cmd.Stdout != nil {
+		var stderr bytes.Buffer
+		c.Stdout = cmd.Stdout
+		c.Stderr = &stderr
+		return nil, exitError(c.Run(), stderr.Bytes())
+	}
+
+	if cmd.DiscardOutput {
+		return nil, exitError(c.Run(), nil)
+	}
+
+	output, err := c.Output()
+	return output, exitError(err, nil)
+}
+
+// exitError converts an *exec.ExitError into an *ExitError. stderr is used
+// when the exec error did not capture it itself.
+func exitError(err error, stderr []byte) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+	if exitErr.Stderr != nil {
+		stderr = exitErr.Stderr
+	}
+	return &ExitError{Code: exitErr.ExitCode(), Stderr: stderr}
+}
+
+// LookPath searches for the named executable in the system PATH.
+func (ExecRunner) LookPath(name string) (string, error) {
+	return exec.LookPath(name)
+}
@@ -0,0 +1,403 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SensorInfo describes a sensor reported by termux-sensor -l.
+type SensorInfo struct {
+	Name string // Device-specific name (e.g., "BMI160 Accelerometer")
+	Kind string // Normalized kind (e.g., "accelerometer"), empty if unknown
+}
+
+// sensorKinds maps name fragments to sensor kinds. More specific fragments
+// come first so "Linear Acceleration" isn't mistaken for an accelerometer.
+var sensorKinds = []string{
+	"linear acceleration",
+	"rotation vector",
+	"gravity",
+	"accelerometer",
+	"gyroscope",
+	"magnetic",
+	"light",
+	"proximity",
+	"pressure",
+	"step counter",
+	"step detector",
+	"orientation",
+	"humidity",
+	"temperature",
+}
+
+// sensorKind derives a SensorInfo.Kind from a sensor name.
+func sensorKind(name string) string {
+	lower := strings.ToLower(name)
+	for _, kind := range sensorKinds {
+		if strings.Contains(lower, kind) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// ListSensorInfo returns the sensors available on the device.
+//
+// If not running on Termux, returns an empty list.
+//
+// Example:
+//
+//	sensors, err := termux.ListSensorInfo()
+//	for _, s := range sensors {
+//	    if s.Kind == "accelerometer" {
+//	        fmt.Println("Shake detection available:", s.Name)
+//	    }
+//	}
+func ListSensorInfo() ([]SensorInfo, error) {
+	return Default().ListSensorInfo()
+}
+
+// ListSensorInfoContext is like ListSensorInfo but takes a context. The
+// command is killed if ctx is done before it completes.
+func ListSensorInfoContext(ctx context.Context) ([]SensorInfo, error) {
+	return Default().ListSensorInfoContext(ctx)
+}
+
+// ListSensorInfo returns the sensors available on the device.
+func (c *Client) ListSensorInfo() ([]SensorInfo, error) {
+	return c.ListSensorInfoContext(context.Background())
+}
+
+// ListSensorInfoContext is like ListSensorInfo but takes a context.
+func (c *Client) ListSensorInfoContext(ctx context.Context) ([]SensorInfo, error) {
+	if !c.IsTermux() {
+		return nil, c.fallbackErr()
+	}
+
+	output, err := c.run(ctx, "termux-sensor", "-l")
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Sensors []string `json:"sensors"`
+	}
+	if err := json.Unmarshal(output, &list); err != nil {
+		return nil, err
+	}
+
+	sensors := make([]SensorInfo, len(list.Sensors))
+	for i, name := range list.Sensors {
+		sensors[i] = SensorInfo{Name: name, Kind: sensorKind(name)}
+	}
+	return sensors, nil
+}
+
+// SensorReading is one reading from one sensor.
+type SensorReading struct {
+	Sensor string    // Device-specific sensor name
+	Values []float64 // Raw values (x, y, z for motion sensors; lux for light)
+	Time   time.Time // When the reading was received
+}
+
+// Is reports whether the reading came from a sensor whose name contains
+// kind, ignoring case (e.g., r.Is("accelerometer")).
+func (r SensorReading) Is(kind string) bool {
+	return strings.Contains(strings.ToLower(r.Sensor), strings.ToLower(kind))
+}
+
+// Magnitude returns the length of the Values vector, e.g. the total
+// acceleration in m/s² for an accelerometer.
+func (r SensorReading) Magnitude() float64 {
+	var sum float64
+	for _, v := range r.Values {
+		sum += v * v
+	}
+	return math.Sqrt(sum)
+}
+
+// SensorStreamOptions configures StreamSensors.
+type SensorStreamOptions struct {
+	Delay time.Duration // Time between readings; zero uses the termux-sensor default (1s)
+	Count int           // Readings to take before stopping; zero streams until closed
+}
+
+// sensorCleanupTimeout bounds the termux-sensor -c call that releases the
+// sensors after a stream is stopped early.
+const sensorCleanupTimeout = 5 * time.Second
+
+// SensorStream delivers readings from a running termux-sensor process.
+// Always Close a stream when done with it; closing stops the process and
+// releases the sensors.
+type SensorStream struct {
+	readings chan SensorReading
+	cancel   context.CancelFunc
+	done     chan struct{}
+	err      error
+}
+
+// Readings returns the channel readings are delivered on. It is closed
+// when the stream ends: after Count readings, on error, or on Close.
+func (s *SensorStream) Readings() <-chan SensorReading {
+	return s.readings
+}
+
+// Err returns the error that ended the stream, once Readings is closed.
+// Ending by Close, by the context being done, or by reaching Count is not
+// an error.
+func (s *SensorStream) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Close stops the termux-sensor process and waits for it to exit.
+func (s *SensorStream) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// StreamSensors starts continuous readings from the named sensors. Names
+// are matched by termux-sensor against the device's sensor names, so
+// "accelerometer" selects "BMI160 Accelerometer". Each reading is delivered
+// separately, even when several sensors report together.
+//
+// If not running on Termux, returns a stream that ends immediately.
+//
+// Example:
+//
+//	stream, err := termux.StreamSensors([]string{"accelerometer", "light"},
+//	    termux.SensorStreamOptions{Delay: 200 * time.Millisecond})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer stream.Close()
+//
+//	for r := range stream.Readings() {
+//	    fmt.Println(r.Sensor, r.Values)
+//	}
+func StreamSensors(sensors []string, opts SensorStreamOptions) (*SensorStream, error) {
+	return Default().StreamSensors(sensors, opts)
+}
+
+// StreamSensorsContext is like StreamSensors but takes a context. The
+// stream ends when ctx is done.
+func StreamSensorsContext(ctx context.Context, sensors []string, opts SensorStreamOptions) (*SensorStream, error) {
+	return Default().StreamSensorsContext(ctx, sensors, opts)
+}
+
+// StreamSensors starts continuous readings from the named sensors.
+func (c *Client) StreamSensors(sensors []string, opts SensorStreamOptions) (*SensorStream, error) {
+	return c.StreamSensorsContext(context.Background(), sensors, opts)
+}
+
+// StreamSensorsContext is like StreamSensors but takes a context.
+func (c *Client) StreamSensorsContext(ctx context.Context, sensors []string, opts SensorStreamOptions) (*SensorStream, error) {
+	if len(sensors) == 0 {
+		return nil, errors.New("termux: no sensors to stream")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &SensorStream{
+		readings: make(chan SensorReading),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			cancel()
+			return nil, err
+		}
+		close(s.readings)
+		close(s.done)
+		return s, nil
+	}
+
+	args := []string{"-s", strings.Join(sensors, ",")}
+	if opts.Delay > 0 {
+		args = append(args, "-d", formatInt(int(opts.Delay/time.Millisecond)))
+	}
+	if opts.Count > 0 {
+		args = append(args, "-n", formatInt(opts.Count))
+	}
+
+	go c.streamSensors(ctx, s, args)
+	return s, nil
+}
+
+// streamSensors runs termux-sensor, decoding its output into s until the
+// process exits or ctx is done.
+func (c *Client) streamSensors(ctx context.Context, s *SensorStream, args []string) {
+	// Deferred calls run in reverse: done closes before readings so Err
+	// is settled by the time a reader sees the channel close.
+	defer close(s.readings)
+	defer close(s.done)
+	defer s.cancel()
+
+	killed, err := c.streamJSON(ctx, func(dec *json.Decoder) error {
+		return decodeSensorSample(ctx, dec, s.readings)
+	}, "termux-sensor", args...)
+
+	if killed {
+		// Killing termux-sensor can leave the sensors registered with the
+		// Termux:API service; -c releases them.
+		cleanup, cancel := context.WithTimeout(context.Background(), sensorCleanupTimeout)
+		defer cancel()
+		c.run(cleanup, "termux-sensor", "-c")
+	}
+	if ctx.Err() == nil {
+		s.err = err
+	}
+}
+
+// decodeSensorSample decodes one JSON object written by termux-sensor and
+// sends a reading for each sensor in it. Each object maps sensor names to
+// {"values": [...]}.
+func decodeSensorSample(ctx context.Context, dec *json.Decoder, out chan<- SensorReading) error {
+	var sample map[string]struct {
+		Values []float64 `json:"values"`
+	}
+	if err := dec.Decode(&sample); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	names := make([]string, 0, len(sample))
+	for name := range sample {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		reading := SensorReading{Sensor: name, Values: sample[name].Values, Time: now}
+		select {
+		case out <- reading:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// standardGravity is one g in m/s².
+const standardGravity = 9.80665
+
+// ShakeDetector recognizes shakes in accelerometer readings.
+//
+// Example (shake to refresh):
+//
+//	shake := &termux.ShakeDetector{}
+//	for r := range stream.Readings() {
+//	    if shake.Observe(r) {
+//	        refresh()
+//	    }
+//	}
+type ShakeDetector struct {
+	// Threshold is the total acceleration, in g, that counts as a shake.
+	// Zero means 2.5.
+	Threshold float64
+
+	// Cooldown is the minimum time between reported shakes. Zero means
+	// one second.
+	Cooldown time.Duration
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Observe reports whether r completes a shake. Readings from sensors other
+// than accelerometers are ignored.
+func (d *ShakeDetector) Observe(r SensorReading) bool {
+	if !r.Is("accelerometer") || len(r.Values) < 3 {
+		return false
+	}
+
+	threshold := d.Threshold
+	if threshold <= 0 {
+		threshold = 2.5
+	}
+	cooldown := d.Cooldown
+	if cooldown <= 0 {
+		cooldown = time.Second
+	}
+
+	if r.Magnitude()/standardGravity < threshold {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.last.IsZero() && r.Time.Sub(d.last) < cooldown {
+		return false
+	}
+	d.last = r.Time
+	return true
+}
+
+// DarkModeDetector picks a light or dark theme from ambient light readings.
+// The two thresholds form a dead band so the theme doesn't flicker when
+// the light level hovers around a single value.
+//
+// Example (auto light/dark theme):
+//
+//	auto := &termux.DarkModeDetector{}
+//	for r := range stream.Readings() {
+//	    if dark, changed := auto.Observe(r); changed {
+//	        setTheme(dark)
+//	    }
+//	}
+type DarkModeDetector struct {
+	DarkBelow  float64 // Switch to dark below this many lux; zero means 10
+	LightAbove float64 // Switch to light above this many lux; zero means 50
+
+	mu    sync.Mutex
+	dark  bool
+	known bool
+}
+
+// Observe reports whether the theme should be dark after r, and whether
+// that changed. The first light reading always reports a change. Readings
+// from sensors other than light sensors are ignored.
+func (d *DarkModeDetector) Observe(r SensorReading) (dark, changed bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !r.Is("light") || len(r.Values) == 0 {
+		return d.dark, false
+	}
+
+	darkBelow := d.DarkBelow
+	if darkBelow <= 0 {
+		darkBelow = 10
+	}
+	lightAbove := d.LightAbove
+	if lightAbove <= 0 {
+		lightAbove = 50
+	}
+
+	lux := r.Values[0]
+	next := d.dark
+	switch {
+	case !d.known:
+		next = lux < (darkBelow+lightAbove)/2
+	case lux < darkBelow:
+		next = true
+	case lux > lightAbove:
+		next = false
+	}
+
+	changed = !d.known || next != d.dark
+	d.dark, d.known = next, true
+	return d.dark, changed
+}
@@ -0,0 +1,168 @@
+package termux_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestListSensorInfo(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-sensor", `{"sensors":["BMI160 Accelerometer","TMD4903 Light Sensor","Linear Acceleration","Mystery"]}`)
+	client := termux.NewClient(fake)
+
+	sensors, err := client.ListSensorInfo()
+	if err != nil {
+		t.Fatalf("ListSensorInfo: %v", err)
+	}
+	want := []termux.SensorInfo{
+		{Name: "BMI160 Accelerometer", Kind: "accelerometer"},
+		{Name: "TMD4903 Light Sensor", Kind: "light"},
+		{Name: "Linear Acceleration", Kind: "linear acceleration"},
+		{Name: "Mystery"},
+	}
+	if !reflect.DeepEqual(sensors, want) {
+		t.Errorf("ListSensorInfo = %+v, want %+v", sensors, want)
+	}
+}
+
+func TestStreamSensorsCount(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-sensor", `{
+  "BMI160 Accelerometer": {"values": [0.1, 9.8, 0.2]},
+  "TMD4903 Light Sensor": {"values": [120]}
+}
+{
+  "BMI160 Accelerometer": {"values": [0.3, 9.7, 0.1]},
+  "TMD4903 Light Sensor": {"values": [118]}
+}`)
+	client := termux.NewClient(fake)
+
+	stream, err := client.StreamSensors([]string{"accelerometer", "light"},
+		termux.SensorStreamOptions{Delay: 250 * time.Millisecond, Count: 2})
+	if err != nil {
+		t.Fatalf("StreamSensors: %v", err)
+	}
+	defer stream.Close()
+
+	var got []string
+	for r := range stream.Readings() {
+		got = append(got, r.Sensor)
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("Err = %v", err)
+	}
+	if len(got) != 4 || got[0] != "BMI160 Accelerometer" || got[1] != "TMD4903 Light Sensor" {
+		t.Errorf("readings from %q", got)
+	}
+
+	calls := fake.CallsTo("termux-sensor")
+	want := []string{"-s", "accelerometer,light", "-d", "250", "-n", "2"}
+	if len(calls) != 1 || !reflect.DeepEqual(calls[0].Args, want) {
+		t.Errorf("calls = %+v, want one with args %q", calls, want)
+	}
+}
+
+func TestStreamSensorsClose(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.RespondWith("termux-sensor", termuxtest.Response{
+		Output: `{"TMD4903 Light Sensor": {"values": [3]}}`,
+		Delay:  time.Hour,
+	})
+	fake.Respond("termux-sensor", "")
+	client := termux.NewClient(fake)
+
+	stream, err := client.StreamSensors([]string{"light"}, termux.SensorStreamOptions{})
+	if err != nil {
+		t.Fatalf("StreamSensors: %v", err)
+	}
+
+	r := <-stream.Readings()
+	if !r.Is("light") || r.Values[0] != 3 {
+		t.Errorf("reading = %+v", r)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		stream.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not stop the stream")
+	}
+
+	if err := stream.Err(); err != nil {
+		t.Errorf("Err after Close = %v", err)
+	}
+	calls := fake.CallsTo("termux-sensor")
+	if len(calls) != 2 || !reflect.DeepEqual(calls[1].Args, []string{"-c"}) {
+		t.Errorf("calls = %+v, want cleanup with -c", calls)
+	}
+}
+
+func TestStreamSensorsFailure(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Exit("termux-sensor", 1, "SecurityException: permission not granted")
+	client := termux.NewClient(fake)
+
+	stream, err := client.StreamSensors([]string{"light"}, termux.SensorStreamOptions{})
+	if err != nil {
+		t.Fatalf("StreamSensors: %v", err)
+	}
+	for range stream.Readings() {
+	}
+	if !errors.Is(stream.Err(), termux.ErrPermissionDenied) {
+		t.Errorf("Err = %v, want ErrPermissionDenied", stream.Err())
+	}
+}
+
+func TestShakeDetector(t *testing.T) {
+	var d termux.ShakeDetector
+	start := time.Now()
+	accel := func(after time.Duration, x float64) termux.SensorReading {
+		return termux.SensorReading{Sensor: "BMI160 Accelerometer", Values: []float64{x, 9.8, 0}, Time: start.Add(after)}
+	}
+
+	if d.Observe(accel(0, 0)) {
+		t.Error("resting device reported as shake")
+	}
+	if !d.Observe(accel(100*time.Millisecond, 30)) {
+		t.Error("shake not detected")
+	}
+	if d.Observe(accel(200*time.Millisecond, 30)) {
+		t.Error("shake reported again within cooldown")
+	}
+	if !d.Observe(accel(2*time.Second, 30)) {
+		t.Error("shake after cooldown not detected")
+	}
+}
+
+func TestDarkModeDetector(t *testing.T) {
+	var d termux.DarkModeDetector
+	light := func(lux float64) termux.SensorReading {
+		return termux.SensorReading{Sensor: "TMD4903 Light Sensor", Values: []float64{lux}}
+	}
+
+	steps := []struct {
+		lux           float64
+		dark, changed bool
+	}{
+		{200, false, true},
+		{30, false, false}, // inside the dead band
+		{5, true, true},
+		{30, true, false},
+		{80, false, true},
+	}
+	for _, step := range steps {
+		dark, changed := d.Observe(light(step.lux))
+		if dark != step.dark || changed != step.changed {
+			t.Errorf("Observe(%v lux) = %v, %v; want %v, %v", step.lux, dark, changed, step.dark, step.changed)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package termux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ShareAction is what the receiving app is asked to do with shared
+// content.
+type ShareAction string
+
+const (
+	ShareView ShareAction = "view" // Open it (termux-share's default)
+	ShareSend ShareAction = "send" // Send it somewhere, e.g. by mail or chat
+	ShareEdit ShareAction = "edit" // Open it for editing
+)
+
+// ShareOptions configures Share and ShareText. The zero value views the
+// content with an app the user picks.
+type ShareOptions struct {
+	Action      ShareAction
+	ContentType string // MIME type, e.g. "text/csv"; guessed from the file name if empty
+	Title       string // Title of the shared content
+	Default     bool   // Use the default app for the content instead of asking
+}
+
+// args builds the termux-share flags for o.
+func (o ShareOptions) args() []string {
+	var args []string
+	if o.Action != "" {
+		args = append(args, "-a", string(o.Action))
+	}
+	if o.ContentType != "" {
+		args = append(args, "-c", o.ContentType)
+	}
+	if o.Default {
+		args = append(args, "-d")
+	}
+	if o.Title != "" {
+		args = append(args, "-t", o.Title)
+	}
+	return args
+}
+
+// Share hands the file at path to another Android app, chosen by the
+// user from the share sheet unless opts.Default is set.
+//
+// If not running on Termux, this is a no-op.
+//
+// Example:
+//
+//	// "Export" hands the report to mail, Drive, chat...
+//	err := termux.Share(reportPath, termux.ShareOptions{
+//	    Action:      termux.ShareSend,
+//	    ContentType: "text/csv",
+//	})
+func Share(path string, opts ShareOptions) error {
+	return Default().Share(path, opts)
+}
+
+// ShareContext is like Share but takes a context. The command is killed
+// if ctx is done before it completes.
+func ShareContext(ctx context.Context, path string, opts ShareOptions) error {
+	return Default().ShareContext(ctx, path, opts)
+}
+
+// Share hands the file at path to another Android app.
+func (c *Client) Share(path string, opts ShareOptions) error {
+	return c.ShareContext(context.Background(), path, opts)
+}
+
+// ShareContext is like Share but takes a context.
+func (c *Client) ShareContext(ctx context.Context, path string, opts ShareOptions) error {
+	if path == "" {
+		return errors.New("termux: Share needs a file")
+	}
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	_, err = c.run(ctx, "termux-share", append(opts.args(), abs)...)
+	return err
+}
+
+// ShareText hands text to another Android app, as Share does for files.
+// The text is piped through stdin, so it may be of any length.
+//
+// If not running on Termux, this is a no-op.
+//
+// Example:
+//
+//	termux.ShareText(summary, termux.ShareOptions{Action: termux.ShareSend, Title: "Daily summary"})
+func ShareText(text string, opts ShareOptions) error {
+	return Default().ShareText(text, opts)
+}
+
+// ShareTextContext is like ShareText but takes a context. The command is
+// killed if ctx is done before it completes.
+func ShareTextContext(ctx context.Context, text string, opts ShareOptions) error {
+	return Default().ShareTextContext(ctx, text, opts)
+}
+
+// ShareText hands text to another Android app.
+func (c *Client) ShareText(text string, opts ShareOptions) error {
+	return c.ShareTextContext(context.Background(), text, opts)
+}
+
+// ShareTextContext is like ShareText but takes a context.
+func (c *Client) ShareTextContext(ctx context.Context, text string, opts ShareOptions) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	_, err := c.runInput(ctx, []byte(text), "termux-share", opts.args()...)
+	return err
+}
+
+// Open opens a file or URL with the Android app registered for it.
+// mimeType overrides the type guessed from the name ("" to guess), and
+// chooser always asks the user which app to use.
+//
+// On a Linux desktop the target is opened with xdg-open, ignoring mimeType
+// and chooser. Otherwise, if not running on Termux, this is a no-op.
+//
+// Example:
+//
+//	termux.Open("export/report.pdf", "", true)
+func Open(path, mimeType string, chooser bool) error {
+	return Default().Open(path, mimeType, chooser)
+}
+
+// OpenContext is like Open but takes a context. The command is killed if
+// ctx is done before it completes.
+func OpenContext(ctx context.Context, path, mimeType string, chooser bool) error {
+	return Default().OpenContext(ctx, path, mimeType, chooser)
+}
+
+// Open opens a file or URL with the Android app registered for it.
+func (c *Client) Open(path, mimeType string, chooser bool) error {
+	return c.OpenContext(context.Background(), path, mimeType, chooser)
+}
+
+// OpenContext is like Open but takes a context.
+func (c *Client) OpenContext(ctx context.Context, path, mimeType string, chooser bool) error {
+	if path == "" {
+		return errors.New("termux: Open needs a file or URL")
+	}
+	if !c.IsTermux() {
+		return c.desktopOpen(ctx, path)
+	}
+
+	var args []string
+	if chooser {
+		args = append(args, "--chooser")
+	}
+	if mimeType != "" {
+		args = append(args, "--content-type", mimeType)
+	}
+	_, err := c.run(ctx, "termux-open", append(args, path)...)
+	return err
+}
+
+// OpenURL opens url in the default browser (or the app that handles it).
+//
+// On a Linux desktop the URL is opened with xdg-open. Otherwise, if not
+// running on Termux, this is a no-op.
+//
+// Example:
+//
+//	termux.OpenURL("https://github.com/GGPrompts/TUITemplate/pull/42")
+func OpenURL(url string) error {
+	return Default().OpenURL(url)
+}
+
+// OpenURLContext is like OpenURL but takes a context. The command is
+// killed if ctx is done before it completes.
+func OpenURLContext(ctx context.Context, url string) error {
+	return Default().OpenURLContext(ctx, url)
+}
+
+// OpenURL opens url in the default browser.
+func (c *Client) OpenURL(url string) error {
+	return c.OpenURLContext(context.Background(), url)
+}
+
+// OpenURLContext is like OpenURL but takes a context.
+func (c *Client) OpenURLContext(ctx context.Context, url string) error {
+	if url == "" {
+		return errors.New("termux: OpenURL needs a URL")
+	}
+	if !c.IsTermux() {
+		return c.desktopOpen(ctx, url)
+	}
+
+	_, err := c.run(ctx, "termux-open-url", url)
+	return err
+}
+
+// desktopOpen opens target through the Linux backend, if that is in use.
+func (c *Client) desktopOpen(ctx context.Context, target string) error {
+	if b, ok := c.Backend().(*LinuxBackend); ok {
+		return b.Open(ctx, target)
+	}
+	return c.fallbackErr()
+}
+
+// storagePickTimeout bounds how long StorageGet waits for the user to
+// pick a file.
+const storagePickTimeout = 5 * time.Minute
+
+// storagePollInterval is how often StorageGet checks the picked file.
+const storagePollInterval = 250 * time.Millisecond
+
+// DefaultStorageSettle is how long the picked file's size must stay the
+// same before StorageGet considers the copy complete, unless
+// StorageGetOptions.Settle says otherwise.
+const DefaultStorageSettle = time.Second
+
+// StorageGetOptions configures StorageGet.
+type StorageGetOptions struct {
+	// Settle is how long the picked file's size must stay the same
+	// before the copy is considered complete. Raise it for files picked
+	// from slow sources such as cloud storage. Zero means
+	// DefaultStorageSettle.
+	Settle time.Duration
+}
+
+// StorageGet lets the user pick a file from Android storage (Downloads,
+// Drive, other apps' documents, ...) with the system file picker and
+// copies it to dest. An existing file at dest is only replaced once a
+// file has been picked.
+//
+// The picker gives no signal when the user backs out, so StorageGet waits
+// up to 5 minutes for a file and then returns ErrCancelled. Use
+// StorageGetContext to choose the deadline.
+//
+// Nor does it signal when the copy is done, so completion is a
+// best-effort heuristic: the file is taken once it is non-empty and its
+// size has not changed for opts.Settle. A slow picker or source that
+// stalls for longer still yields a truncated file, and picking an empty
+// file is indistinguishable from backing out.
+//
+// If not running on Termux, this is a no-op and dest is not touched.
+//
+// Example:
+//
+//	err := termux.StorageGet(filepath.Join(dataDir, "import.csv"), termux.StorageGetOptions{
+//	    Settle: 5 * time.Second, // Imports come from Drive
+//	})
+//	if errors.Is(err, termux.ErrCancelled) {
+//	    return nil
+//	}
+func StorageGet(dest string, opts StorageGetOptions) error {
+	return Default().StorageGet(dest, opts)
+}
+
+// StorageGetContext is like StorageGet but waits for the pick until ctx
+// is done, then returns ErrCancelled.
+func StorageGetContext(ctx context.Context, dest string, opts StorageGetOptions) error {
+	return Default().StorageGetContext(ctx, dest, opts)
+}
+
+// StorageGet lets the user pick a file and copies it to dest.
+func (c *Client) StorageGet(dest string, opts StorageGetOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), storagePickTimeout)
+	defer cancel()
+	return c.StorageGetContext(ctx, dest, opts)
+}
+
+// StorageGetContext is like StorageGet but takes a context.
+func (c *Client) StorageGetContext(ctx context.Context, dest string, opts StorageGetOptions) error {
+	if dest == "" {
+		return errors.New("termux: StorageGet needs a destination")
+	}
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	abs, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	// Pick into a temporary file next to dest so a cancelled pick leaves
+	// dest alone, and the final rename stays on one filesystem.
+	tmp := filepath.Join(filepath.Dir(abs), "."+filepath.Base(abs)+".picking")
+	os.Remove(tmp)
+	defer os.Remove(tmp)
+
+	// termux-storage-get returns once the picker is shown; the picked file
+	// is copied to tmp afterwards.
+	output, err := c.run(ctx, "termux-storage-get", tmp)
+	if err != nil {
+		return err
+	}
+	if msg := strings.TrimSpace(string(output)); msg != "" {
+		return fmt.Errorf("termux: termux-storage-get: %s", msg)
+	}
+
+	settle := opts.Settle
+	if settle <= 0 {
+		settle = DefaultStorageSettle
+	}
+	if err := waitForStableFile(ctx, tmp, settle); err != nil {
+		return err
+	}
+	return os.Rename(tmp, abs)
+}
+
+// waitForStableFile waits until path is non-empty and its size has not
+// changed for settle, or returns ErrCancelled once ctx is done.
+func waitForStableFile(ctx context.Context, path string, settle time.Duration) error {
+	ticker := time.NewTicker(min(storagePollInterval, settle))
+	defer ticker.Stop()
+
+	var lastSize int64
+	var since time.Time // When the file reached lastSize
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: no file picked: %w", ErrCancelled, ctx.Err())
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil || info.Size() == 0 {
+			continue // Not picked yet, or the copy has not started
+		}
+		if info.Size() != lastSize {
+			lastSize, since = info.Size(), time.Now()
+			continue
+		}
+		if time.Since(since) >= settle {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package termux_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestShare(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	err := client.Share("report.csv", termux.ShareOptions{Action: termux.ShareSend, ContentType: "text/csv", Title: "Report"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	abs, _ := filepath.Abs("report.csv")
+	if got, want := strings.Join(fake.CallsTo("termux-share")[0].Args, " "), "-a send -c text/csv -t Report "+abs; got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+
+	if err := client.ShareText("-n not a flag", termux.ShareOptions{Default: true}); err != nil {
+		t.Fatal(err)
+	}
+	call := fake.CallsTo("termux-share")[1]
+	if strings.Join(call.Args, " ") != "-d" || string(call.Stdin) != "-n not a flag" {
+		t.Errorf("ShareText call = %+v", call)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	if err := client.Open("notes.md", "text/plain", true); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(fake.CallsTo("termux-open")[0].Args, " "); got != "--chooser --content-type text/plain notes.md" {
+		t.Errorf("termux-open args = %q", got)
+	}
+
+	if err := client.OpenURL("https://example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.CallsTo("termux-open-url")[0].Args; len(got) != 1 || got[0] != "https://example.com" {
+		t.Errorf("termux-open-url args = %q", got)
+	}
+}
+
+func TestOpenOnDesktop(t *testing.T) {
+	fake, client, _ := newDesktop(t)
+
+	if err := client.OpenURL("https://example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := fake.CallsTo("xdg-open"); len(calls) != 1 || calls[0].Args[0] != "https://example.com" {
+		t.Errorf("xdg-open calls = %v", calls)
+	}
+	if calls := fake.CallsTo("termux-open-url"); len(calls) != 0 {
+		t.Errorf("termux-open-url ran off Termux: %v", calls)
+	}
+}
+
+// pickingRunner writes picked into the file termux-storage-get is asked
+// to fill, as the Android picker does after the command returns. It
+// creates the file empty and fills it after delay.
+type pickingRunner struct {
+	*termuxtest.Runner
+	picked string
+	delay  time.Duration
+}
+
+func (r *pickingRunner) Run(ctx context.Context, cmd termux.Command) ([]byte, error) {
+	out, err := r.Runner.Run(ctx, cmd)
+	if cmd.Name == "termux-storage-get" {
+		go func() {
+			os.WriteFile(cmd.Args[0], nil, 0o600)
+			time.Sleep(r.delay)
+			os.WriteFile(cmd.Args[0], []byte(r.picked), 0o600)
+		}()
+	}
+	return out, err
+}
+
+func TestStorageGet(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "import.csv")
+	os.WriteFile(dest, []byte("old"), 0o600)
+
+	// The copy starts well after the settle window
+	runner := &pickingRunner{Runner: termuxtest.NewRunner(), picked: "a,b\n1,2\n", delay: 300 * time.Millisecond}
+	client := termux.NewClient(runner)
+	if err := client.StorageGet(dest, termux.StorageGetOptions{Settle: 50 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != runner.picked {
+		t.Errorf("dest = %q, want the picked file", data)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(dest))
+	if len(entries) != 1 {
+		t.Errorf("left behind %d files, want only dest", len(entries))
+	}
+}
+
+func TestStorageGetCancelled(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "import.csv")
+	os.WriteFile(dest, []byte("old"), 0o600)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := termux.NewClient(termuxtest.NewRunner()).StorageGetContext(ctx, dest, termux.StorageGetOptions{})
+	if !errors.Is(err, termux.ErrCancelled) {
+		t.Errorf("err = %v, want ErrCancelled", err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "old" {
+		t.Errorf("dest = %q after a cancelled pick, want it untouched", data)
+	}
+}
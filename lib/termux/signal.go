@@ -0,0 +1,154 @@
+package termux
+
+import (
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+)
+
+// signalCleanup is a cleanup registered with OnSignal.
+type signalCleanup struct {
+	run  func()
+	sigs []os.Signal
+	exit bool // Exit once the cleanups for a signal have run
+}
+
+// signals is the state of the one handler shared by every OnSignal
+// registration.
+var signals struct {
+	mu       sync.Mutex
+	ch       chan os.Signal // nil until the first registration
+	watched  []os.Signal    // Signals ch is registered for
+	cleanups map[int]signalCleanup
+	next     int
+}
+
+// OnSignal runs cleanup when the process receives one of sigs (default:
+// interrupt and SIGTERM). Call the returned function to stop watching.
+//
+// All registrations share one handler. On a signal it runs every cleanup
+// registered for it, most recent first, and leaves the process running:
+// other handlers registered with signal.Notify, such as Bubble Tea's, see
+// the signal too and shut the program down their own way. Because a
+// watched signal no longer has its default action, a program with no
+// handler of its own should register with OnSignalExit instead.
+//
+// NotificationManager.RemoveOnSignal and WakeLocks.ReleaseOnSignal
+// register through OnSignal, so using both is safe.
+//
+// Example:
+//
+//	stop := termux.OnSignal(func() { store.Flush() })
+//	defer stop()
+func OnSignal(cleanup func(), sigs ...os.Signal) (stop func()) {
+	return registerSignal(cleanup, sigs, false)
+}
+
+// OnSignalExit is OnSignal for programs that do not handle the signal
+// themselves: once every cleanup for the signal has run, the process exits
+// with status 128 plus the signal number, as if the signal had killed it.
+//
+// Example:
+//
+//	// A plain CLI with no Bubble Tea program
+//	stop := termux.OnSignalExit(func() { notes.Close() })
+//	defer stop()
+func OnSignalExit(cleanup func(), sigs ...os.Signal) (stop func()) {
+	return registerSignal(cleanup, sigs, true)
+}
+
+// registerSignal adds a cleanup to the shared handler.
+func registerSignal(cleanup func(), sigs []os.Signal, exit bool) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	signals.mu.Lock()
+	defer signals.mu.Unlock()
+	if signals.ch == nil {
+		signals.ch = make(chan os.Signal, 1)
+		signals.cleanups = map[int]signalCleanup{}
+		go handleSignals(signals.ch)
+	}
+	id := signals.next
+	signals.next++
+	signals.cleanups[id] = signalCleanup{run: cleanup, sigs: sigs, exit: exit}
+	watchSignals()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signals.mu.Lock()
+			defer signals.mu.Unlock()
+			delete(signals.cleanups, id)
+			watchSignals()
+		})
+	}
+}
+
+// watchSignals registers the handler for exactly the signals some cleanup
+// is watching, so the rest keep their default action. signals.mu must be
+// held.
+func watchSignals() {
+	var want []os.Signal
+	for _, c := range signals.cleanups {
+		for _, sig := range c.sigs {
+			if !slices.Contains(want, sig) {
+				want = append(want, sig)
+			}
+		}
+	}
+
+	if !slices.ContainsFunc(signals.watched, func(sig os.Signal) bool { return !slices.Contains(want, sig) }) {
+		// Only additions: extend the registration without a gap
+		if len(want) > len(signals.watched) {
+			signal.Notify(signals.ch, want...)
+		}
+	} else {
+		signal.Stop(signals.ch)
+		if len(want) > 0 {
+			signal.Notify(signals.ch, want...)
+		}
+	}
+	signals.watched = want
+}
+
+// handleSignals runs the cleanups for each signal received on ch.
+func handleSignals(ch <-chan os.Signal) {
+	for sig := range ch {
+		signals.mu.Lock()
+		ids := make([]int, 0, len(signals.cleanups))
+		for id, c := range signals.cleanups {
+			if slices.Contains(c.sigs, sig) {
+				ids = append(ids, id)
+			}
+		}
+		slices.Sort(ids)
+		run := make([]func(), 0, len(ids))
+		exit := false
+		for i := len(ids) - 1; i >= 0; i-- {
+			c := signals.cleanups[ids[i]]
+			run = append(run, c.run)
+			exit = exit || c.exit
+		}
+		signals.mu.Unlock()
+
+		for _, cleanup := range run {
+			cleanup()
+		}
+		if exit {
+			os.Exit(signalExitCode(sig))
+		}
+	}
+}
+
+// signalExitCode is the exit status of a process killed by sig, as shells
+// report it.
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
@@ -0,0 +1,154 @@
+//go:build unix
+
+package termux_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+func TestOnSignal(t *testing.T) {
+	// Keep SIGUSR1 from killing the test binary whatever happens
+	guard := make(chan os.Signal, 1)
+	signal.Notify(guard, syscall.SIGUSR1)
+	defer signal.Stop(guard)
+
+	var (
+		mu  sync.Mutex
+		ran []string
+	)
+	done := make(chan struct{}, 3)
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			ran = append(ran, name)
+			mu.Unlock()
+			done <- struct{}{}
+		}
+	}
+	stopFirst := termux.OnSignal(record("first"), syscall.SIGUSR1)
+	stopSecond := termux.OnSignal(record("second"), syscall.SIGUSR1)
+	stopOther := termux.OnSignal(record("other"), syscall.SIGUSR2)
+	defer stopOther()
+
+	raise := func() {
+		t.Helper()
+		if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wait := func(n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("cleanup %d did not run", i+1)
+			}
+		}
+	}
+
+	// Both helpers' cleanups run, most recent first; the SIGUSR2 one does not
+	raise()
+	wait(2)
+	mu.Lock()
+	if want := []string{"second", "first"}; !slices.Equal(ran, want) {
+		t.Errorf("ran = %v, want %v", ran, want)
+	}
+	ran = nil
+	mu.Unlock()
+
+	stopSecond()
+	stopSecond() // Stopping twice is harmless
+	raise()
+	wait(1)
+	mu.Lock()
+	if want := []string{"first"}; !slices.Equal(ran, want) {
+		t.Errorf("after stop, ran = %v, want %v", ran, want)
+	}
+	mu.Unlock()
+	stopFirst()
+}
+
+// signalHelperEnv makes TestSignalHelper act as a child process for the
+// exit tests instead of a test.
+const signalHelperEnv = "TERMUX_SIGNAL_HELPER"
+
+// TestSignalHelper is the child process of TestOnSignalExitStatus. It
+// registers a cleanup, optionally handles SIGTERM itself, signals itself
+// and reports on stdout what happened.
+func TestSignalHelper(t *testing.T) {
+	mode := os.Getenv(signalHelperEnv)
+	if mode == "" {
+		t.Skip("helper process only")
+	}
+
+	own := make(chan os.Signal, 1)
+	cleaned := make(chan struct{})
+	switch mode {
+	case "handled":
+		signal.Notify(own, syscall.SIGTERM)
+		termux.OnSignal(func() { close(cleaned) }, syscall.SIGTERM)
+	case "exit":
+		termux.OnSignalExit(func() { os.Stdout.WriteString("cleanup\n") }, syscall.SIGTERM)
+	}
+	syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+
+	select {
+	case <-own:
+	case <-time.After(5 * time.Second):
+		os.Stdout.WriteString("no signal\n")
+		os.Exit(3)
+	}
+	select {
+	case <-cleaned:
+	case <-time.After(5 * time.Second):
+		os.Stdout.WriteString("no cleanup\n")
+		os.Exit(3)
+	}
+	os.Stdout.WriteString("handled\n")
+	os.Exit(0)
+}
+
+func TestOnSignalExitStatus(t *testing.T) {
+	tests := []struct {
+		mode     string
+		wantCode int
+		wantOut  string
+	}{
+		// A cleanup never kills a program that handles the signal itself
+		{"handled", 0, "handled"},
+		{"exit", 128 + int(syscall.SIGTERM), "cleanup"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestSignalHelper$")
+			cmd.Env = append(os.Environ(), signalHelperEnv+"="+tt.mode)
+			out, err := cmd.Output()
+
+			code := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (output %q)", code, tt.wantCode, out)
+			}
+			if got := strings.TrimSpace(string(out)); got != tt.wantOut {
+				t.Errorf("output = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
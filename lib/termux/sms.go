@@ -0,0 +1,180 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SMSType selects the folder listed by ListSMS.
+type SMSType string
+
+const (
+	SMSAll    SMSType = "all"
+	SMSInbox  SMSType = "inbox" // termux-sms-list's default
+	SMSSent   SMSType = "sent"
+	SMSDraft  SMSType = "draft"
+	SMSOutbox SMSType = "outbox" // Queued to be sent
+	SMSFailed SMSType = "failed" // Reported in SMS.Type only
+)
+
+// SMSListOptions filters ListSMS. The zero value lists the 10 newest
+// inbox messages.
+type SMSListOptions struct {
+	Type   SMSType // Folder to list; "" for the inbox
+	Limit  int     // Maximum number of messages; 0 for termux-sms-list's default of 10
+	Offset int     // Number of newest messages to skip, for paging
+	From   string  // Only messages to or from this number (Termux:API 0.50+)
+}
+
+// args builds the termux-sms-list flags for o.
+func (o SMSListOptions) args() []string {
+	args := []string{"-d", "-n"}
+	if o.Type != "" {
+		args = append(args, "-t", string(o.Type))
+	}
+	if o.Limit > 0 {
+		args = append(args, "-l", formatInt(o.Limit))
+	}
+	if o.Offset > 0 {
+		args = append(args, "-o", formatInt(o.Offset))
+	}
+	if o.From != "" {
+		args = append(args, "-f", o.From)
+	}
+	return args
+}
+
+// SMS is a text message, as reported by termux-sms-list.
+type SMS struct {
+	ID       int     `json:"_id"`
+	ThreadID int     `json:"threadid"` // Conversation the message belongs to
+	Type     SMSType `json:"type"`     // Folder: inbox, sent, draft, outbox or failed
+	Read     bool    `json:"read"`
+	Number   string  `json:"number"` // The other party's phone number
+	Sender   string  `json:"sender"` // Contact name for Number, if there is one
+	Body     string  `json:"body"`
+	Received string  `json:"received"` // Date as reported (e.g., "2024-05-01 09:30:00")
+
+	// Time is Received parsed in the local time zone, or zero if it could
+	// not be parsed.
+	Time time.Time `json:"-"`
+}
+
+// Name returns the sender's contact name, or their number if they are not
+// a contact.
+func (m SMS) Name() string {
+	if m.Sender != "" {
+		return m.Sender
+	}
+	return m.Number
+}
+
+// termuxTimeLayout is the date format of termux-sms-list and
+// termux-call-log.
+const termuxTimeLayout = "2006-01-02 15:04:05"
+
+// ListSMS returns text messages, newest first. Termux:API needs the SMS
+// permission; the first call asks for it.
+//
+// If not running on Termux, returns an empty list.
+//
+// Example:
+//
+//	// Second page of the inbox, 20 per page
+//	msgs, err := termux.ListSMS(termux.SMSListOptions{Limit: 20, Offset: 20})
+//	for _, m := range msgs {
+//	    fmt.Printf("%s  %-20s %s\n", m.Time.Format("Jan 2 15:04"), m.Name(), m.Body)
+//	}
+func ListSMS(opts SMSListOptions) ([]SMS, error) {
+	return Default().ListSMS(opts)
+}
+
+// ListSMSContext is like ListSMS but takes a context. The command is
+// killed if ctx is done before it completes.
+func ListSMSContext(ctx context.Context, opts SMSListOptions) ([]SMS, error) {
+	return Default().ListSMSContext(ctx, opts)
+}
+
+// ListSMS returns text messages, newest first.
+func (c *Client) ListSMS(opts SMSListOptions) ([]SMS, error) {
+	return c.ListSMSContext(context.Background(), opts)
+}
+
+// ListSMSContext is like ListSMS but takes a context.
+func (c *Client) ListSMSContext(ctx context.Context, opts SMSListOptions) ([]SMS, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return []SMS{}, nil
+	}
+
+	output, err := c.run(ctx, "termux-sms-list", opts.args()...)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []SMS
+	if err := json.Unmarshal(output, &msgs); err != nil {
+		return nil, err
+	}
+	for i := range msgs {
+		if t, err := time.ParseInLocation(termuxTimeLayout, msgs[i].Received, time.Local); err == nil {
+			msgs[i].Time = t
+		}
+	}
+	return msgs, nil
+}
+
+// SendSMS sends text as a text message to each number in to, from the
+// default SIM. Long texts are sent as multipart messages. Termux:API needs
+// the SMS permission; the first call asks for it.
+//
+// If not running on Termux, this is a no-op.
+//
+// Example:
+//
+//	err := termux.SendSMS([]string{"+15551234567"}, "Build passed ✅")
+func SendSMS(to []string, text string) error {
+	return Default().SendSMS(to, text)
+}
+
+// SendSMSContext is like SendSMS but takes a context. The command is
+// killed if ctx is done before it completes.
+func SendSMSContext(ctx context.Context, to []string, text string) error {
+	return Default().SendSMSContext(ctx, to, text)
+}
+
+// SendSMS sends text as a text message to each number in to.
+func (c *Client) SendSMS(to []string, text string) error {
+	return c.SendSMSContext(context.Background(), to, text)
+}
+
+// SendSMSContext is like SendSMS but takes a context.
+func (c *Client) SendSMSContext(ctx context.Context, to []string, text string) error {
+	if len(to) == 0 {
+		return errors.New("termux: SendSMS needs a recipient")
+	}
+	for _, number := range to {
+		if strings.TrimSpace(number) == "" || strings.Contains(number, ",") {
+			return fmt.Errorf("termux: SendSMS: bad recipient %q", number)
+		}
+	}
+	if text == "" {
+		return errors.New("termux: SendSMS needs a message")
+	}
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	// The text goes through stdin so it is never parsed as a flag
+	output, err := c.runInput(ctx, []byte(text), "termux-sms-send", "-n", strings.Join(to, ","))
+	if err != nil {
+		return err
+	}
+	return deviceOutputErr("termux-sms-send", output)
+}
@@ -0,0 +1,401 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// TTSStream is the Android audio stream speech is played on, which
+// decides the volume slider that controls it.
+type TTSStream string
+
+const (
+	TTSStreamAlarm        TTSStream = "ALARM"
+	TTSStreamMusic        TTSStream = "MUSIC"
+	TTSStreamNotification TTSStream = "NOTIFICATION" // termux-tts-speak's default
+	TTSStreamRing         TTSStream = "RING"
+	TTSStreamSystem       TTSStream = "SYSTEM"
+	TTSStreamVoiceCall    TTSStream = "VOICE_CALL"
+)
+
+// Voice selects how text is spoken. Zero fields use the engine's defaults.
+type Voice struct {
+	Engine   string    // Engine package name; see ListTTSEngines
+	Language string    // Language code, e.g. "en"
+	Region   string    // Region code, e.g. "GB"
+	Variant  string    // Engine-specific voice variant
+	Pitch    float64   // 1 is normal; higher is higher-pitched
+	Rate     float64   // 1 is normal, 2 is twice as fast
+	Stream   TTSStream // Audio stream to play on
+}
+
+// args builds the termux-tts-speak arguments to speak with v. The text
+// itself goes on stdin, so text starting with "-" isn't read as a flag.
+func (v Voice) args() []string {
+	var args []string
+	flag := func(name, value string) {
+		if value != "" {
+			args = append(args, name, value)
+		}
+	}
+	flag("-e", v.Engine)
+	flag("-l", v.Language)
+	flag("-n", v.Region)
+	flag("-v", v.Variant)
+	if v.Pitch != 0 {
+		args = append(args, "-p", formatFloat(v.Pitch))
+	}
+	if v.Rate != 0 {
+		args = append(args, "-r", formatFloat(v.Rate))
+	}
+	flag("-s", string(v.Stream))
+	return args
+}
+
+// SpeakVoice speaks text with voice and returns once it has been spoken.
+//
+// On a Linux desktop the voice is ignored and text is spoken through the
+// backend as with Speak. Otherwise, if not running on Termux, this is a
+// no-op.
+//
+// Example:
+//
+//	termux.SpeakVoice("Build finished", termux.Voice{Language: "en", Rate: 1.25})
+func SpeakVoice(text string, voice Voice) error {
+	return Default().SpeakVoice(text, voice)
+}
+
+// SpeakVoiceContext is like SpeakVoice but takes a context. The command is
+// killed if ctx is done before it completes, which cuts the speech short.
+func SpeakVoiceContext(ctx context.Context, text string, voice Voice) error {
+	return Default().SpeakVoiceContext(ctx, text, voice)
+}
+
+// SpeakVoice speaks text with voice.
+func (c *Client) SpeakVoice(text string, voice Voice) error {
+	return c.SpeakVoiceContext(context.Background(), text, voice)
+}
+
+// SpeakVoiceContext is like SpeakVoice but takes a context.
+func (c *Client) SpeakVoiceContext(ctx context.Context, text string, voice Voice) error {
+	if !c.IsTermux() {
+		return c.Backend().Speak(ctx, text)
+	}
+
+	_, err := c.runInput(ctx, []byte(text), "termux-tts-speak", voice.args()...)
+	return err
+}
+
+// TTSEngine is an installed text-to-speech engine.
+type TTSEngine struct {
+	Name    string `json:"name"`    // Package name, used as Voice.Engine
+	Label   string `json:"label"`   // Display name
+	Default bool   `json:"default"` // Used when Voice.Engine is empty
+}
+
+// ListTTSEngines returns the installed text-to-speech engines.
+//
+// If not running on Termux, returns an empty list.
+//
+// Example:
+//
+//	engines, _ := termux.ListTTSEngines()
+//	for _, e := range engines {
+//	    fmt.Println(e.Label, e.Name, e.Default)
+//	}
+func ListTTSEngines() ([]TTSEngine, error) {
+	return Default().ListTTSEngines()
+}
+
+// ListTTSEnginesContext is like ListTTSEngines but takes a context. The
+// command is killed if ctx is done before it completes.
+func ListTTSEnginesContext(ctx context.Context) ([]TTSEngine, error) {
+	return Default().ListTTSEnginesContext(ctx)
+}
+
+// ListTTSEngines returns the installed text-to-speech engines.
+func (c *Client) ListTTSEngines() ([]TTSEngine, error) {
+	return c.ListTTSEnginesContext(context.Background())
+}
+
+// ListTTSEnginesContext is like ListTTSEngines but takes a context.
+func (c *Client) ListTTSEnginesContext(ctx context.Context) ([]TTSEngine, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return []TTSEngine{}, nil
+	}
+
+	output, err := c.run(ctx, "termux-tts-engines")
+	if err != nil {
+		return nil, err
+	}
+
+	var engines []TTSEngine
+	if err := json.Unmarshal(output, &engines); err != nil {
+		return nil, err
+	}
+	return engines, nil
+}
+
+// Utterance is a piece of text queued on a Speaker.
+type Utterance struct {
+	ID    int // Assigned by the Speaker, increasing from 1
+	Text  string
+	Voice Voice
+}
+
+// SpeechEventKind identifies what happened to an utterance.
+type SpeechEventKind int
+
+const (
+	SpeechStarted   SpeechEventKind = iota + 1 // Began speaking
+	SpeechFinished                             // Spoken to the end
+	SpeechCancelled                            // Cut short or dropped from the queue
+	SpeechFailed                               // The TTS command failed; see Err
+)
+
+// String returns the event name (e.g., "started", "finished").
+func (k SpeechEventKind) String() string {
+	switch k {
+	case SpeechStarted:
+		return "started"
+	case SpeechFinished:
+		return "finished"
+	case SpeechCancelled:
+		return "cancelled"
+	case SpeechFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// SpeechEvent reports progress of an utterance on a Speaker.
+type SpeechEvent struct {
+	Kind      SpeechEventKind
+	Utterance Utterance
+	Err       error // Set for SpeechFailed
+}
+
+// speechEventBuffer is how many events Events holds for a slow reader
+// before newer ones are dropped.
+const speechEventBuffer = 16
+
+// Speaker speaks utterances one at a time from a FIFO queue, so spoken
+// status updates never talk over each other. Interrupt jumps the queue
+// and cuts off whatever is being said; Skip and Clear cancel speech.
+//
+// Example:
+//
+//	speaker := termux.NewSpeaker(termux.Default(), termux.Voice{Rate: 1.25})
+//	defer speaker.Close()
+//
+//	speaker.Say("Sync started")
+//	speaker.Say("42 files to upload")
+//	speaker.Interrupt("Battery critical") // spoken right away
+//
+//	for ev := range speaker.Events() {
+//	    log.Println(ev.Kind, ev.Utterance.Text)
+//	}
+type Speaker struct {
+	c     *Client
+	voice Voice
+
+	mu      sync.Mutex
+	queue   []Utterance
+	current *Utterance
+	cancel  context.CancelFunc // Cancels the current utterance
+	nextID  int
+	closed  bool
+
+	wake   chan struct{} // Signals the worker that the queue changed
+	events chan SpeechEvent
+	stop   chan struct{} // closed by Close
+	done   chan struct{} // closed when the worker returns
+
+	closeOnce sync.Once
+}
+
+// NewSpeaker returns a Speaker that speaks through c with voice unless an
+// utterance has its own.
+func NewSpeaker(c *Client, voice Voice) *Speaker {
+	s := &Speaker{
+		c:      c,
+		voice:  voice,
+		wake:   make(chan struct{}, 1),
+		events: make(chan SpeechEvent, speechEventBuffer),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Say queues text with the speaker's voice and returns the utterance ID.
+func (s *Speaker) Say(text string) int {
+	return s.enqueue(text, s.voice, false)
+}
+
+// SayWith queues text with voice and returns the utterance ID.
+func (s *Speaker) SayWith(text string, voice Voice) int {
+	return s.enqueue(text, voice, false)
+}
+
+// Interrupt cuts off the current utterance and speaks text next, ahead of
+// the queue. The rest of the queue is spoken afterwards.
+func (s *Speaker) Interrupt(text string) int {
+	return s.enqueue(text, s.voice, true)
+}
+
+// Skip cuts off the current utterance and moves on to the next.
+func (s *Speaker) Skip() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Clear drops every queued utterance, reporting each as cancelled. The
+// current utterance is left to finish; call Skip as well to stop it.
+func (s *Speaker) Clear() {
+	s.mu.Lock()
+	dropped := s.queue
+	s.queue = nil
+	s.mu.Unlock()
+
+	for _, u := range dropped {
+		s.emit(SpeechEvent{Kind: SpeechCancelled, Utterance: u})
+	}
+}
+
+// Speaking returns the utterance being spoken, if any.
+func (s *Speaker) Speaking() (Utterance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == nil {
+		return Utterance{}, false
+	}
+	return *s.current, true
+}
+
+// Pending returns how many utterances are waiting to be spoken.
+func (s *Speaker) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// Events delivers a SpeechEvent as each utterance starts and ends. If the
+// reader falls behind, newer events are dropped. It is closed by Close.
+func (s *Speaker) Events() <-chan SpeechEvent {
+	return s.events
+}
+
+// Close cuts off the current utterance, drops the queue and stops the
+// speaker. Say and Interrupt do nothing afterwards.
+func (s *Speaker) Close() error {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		s.closed = true
+		if s.cancel != nil {
+			s.cancel()
+		}
+		s.mu.Unlock()
+
+		close(s.stop)
+		<-s.done
+	})
+	return nil
+}
+
+// enqueue adds an utterance at the back of the queue, or at the front
+// cutting off the current one if urgent.
+func (s *Speaker) enqueue(text string, voice Voice, urgent bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0
+	}
+	s.nextID++
+	u := Utterance{ID: s.nextID, Text: text, Voice: voice}
+	if urgent {
+		s.queue = append([]Utterance{u}, s.queue...)
+		if s.cancel != nil {
+			s.cancel()
+		}
+	} else {
+		s.queue = append(s.queue, u)
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return u.ID
+}
+
+// run speaks queued utterances until Close.
+func (s *Speaker) run() {
+	defer close(s.done)
+	defer close(s.events)
+
+	for {
+		u, ctx, ok := s.next()
+		if !ok {
+			select {
+			case <-s.wake:
+				continue
+			case <-s.stop:
+				s.Clear()
+				return
+			}
+		}
+
+		s.emit(SpeechEvent{Kind: SpeechStarted, Utterance: u})
+		err := s.c.SpeakVoiceContext(ctx, u.Text, u.Voice)
+
+		s.mu.Lock()
+		interrupted := ctx.Err() != nil
+		s.cancel()
+		s.current, s.cancel = nil, nil
+		s.mu.Unlock()
+
+		switch {
+		case interrupted:
+			s.emit(SpeechEvent{Kind: SpeechCancelled, Utterance: u})
+		case err != nil:
+			s.emit(SpeechEvent{Kind: SpeechFailed, Utterance: u, Err: err})
+		default:
+			s.emit(SpeechEvent{Kind: SpeechFinished, Utterance: u})
+		}
+	}
+}
+
+// next pops the first queued utterance and makes it current.
+func (s *Speaker) next() (Utterance, context.Context, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queue) == 0 || s.closed {
+		return Utterance{}, nil, false
+	}
+	u := s.queue[0]
+	s.queue = s.queue[1:]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.current, s.cancel = &u, cancel
+	return u, ctx, true
+}
+
+// emit sends ev without blocking, dropping it if the buffer is full.
+func (s *Speaker) emit(ev SpeechEvent) {
+	select {
+	case s.events <- ev:
+	default:
+	}
+}
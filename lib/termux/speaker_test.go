@@ -0,0 +1,134 @@
+package termux_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+// nextSpeechEvent returns the speaker's next event, failing after a second.
+func nextSpeechEvent(t *testing.T, s *termux.Speaker) termux.SpeechEvent {
+	t.Helper()
+	select {
+	case ev := <-s.Events():
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("no speech event")
+		return termux.SpeechEvent{}
+	}
+}
+
+func TestSpeakWithOptionsFormatsFloats(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	if err := client.SpeakWithOptions("hi", "", "en", 1.25, 0.9, "MUSIC"); err != nil {
+		t.Fatal(err)
+	}
+	call := fake.CallsTo("termux-tts-speak")[0]
+	got := strings.Join(call.Args, " ")
+	if want := "-l en -p 1.25 -r 0.9 -s MUSIC"; got != want || string(call.Stdin) != "hi" {
+		t.Errorf("args = %q, stdin = %q, want %q, hi", got, call.Stdin, want)
+	}
+}
+
+func TestSpeakVoiceTextOnStdin(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	if err := termux.NewClient(fake).SpeakVoice("-5 degrees", termux.Voice{Language: "en"}); err != nil {
+		t.Fatal(err)
+	}
+	call := fake.CallsTo("termux-tts-speak")[0]
+	if got := strings.Join(call.Args, " "); got != "-l en" || string(call.Stdin) != "-5 degrees" {
+		t.Errorf("args = %q, stdin = %q, want text on stdin", got, call.Stdin)
+	}
+}
+
+func TestListTTSEngines(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-tts-engines", `[
+		{"name": "com.google.android.tts", "label": "Speech Services by Google", "default": true},
+		{"name": "com.reecedunn.espeak", "label": "eSpeak", "default": false}
+	]`)
+
+	engines, err := termux.NewClient(fake).ListTTSEngines()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(engines) != 2 || !engines[0].Default || engines[1].Name != "com.reecedunn.espeak" {
+		t.Errorf("engines = %+v", engines)
+	}
+}
+
+func TestSpeakerQueue(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	s := termux.NewSpeaker(termux.NewClient(fake), termux.Voice{Language: "en"})
+	defer s.Close()
+
+	s.Say("one")
+	s.SayWith("two", termux.Voice{Rate: 1.5})
+
+	var got []string
+	for range 4 {
+		ev := nextSpeechEvent(t, s)
+		got = append(got, ev.Utterance.Text+":"+ev.Kind.String())
+	}
+	if want := "one:started one:finished two:started two:finished"; strings.Join(got, " ") != want {
+		t.Errorf("events = %v, want %s", got, want)
+	}
+
+	calls := fake.CallsTo("termux-tts-speak")
+	if len(calls) != 2 || strings.Join(calls[0].Args, " ") != "-l en" || string(calls[0].Stdin) != "one" ||
+		strings.Join(calls[1].Args, " ") != "-r 1.5" || string(calls[1].Stdin) != "two" {
+		t.Errorf("calls = %+v", calls)
+	}
+}
+
+func TestSpeakerInterrupt(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.RespondWith("termux-tts-speak", termuxtest.Response{Delay: time.Hour})
+	fake.Respond("termux-tts-speak", "")
+	s := termux.NewSpeaker(termux.NewClient(fake), termux.Voice{})
+	defer s.Close()
+
+	s.Say("long report")
+	if ev := nextSpeechEvent(t, s); ev.Kind != termux.SpeechStarted {
+		t.Fatalf("first event = %v", ev.Kind)
+	}
+	s.Say("queued")
+	s.Interrupt("battery critical")
+
+	var got []string
+	for range 5 {
+		ev := nextSpeechEvent(t, s)
+		got = append(got, ev.Utterance.Text+":"+ev.Kind.String())
+	}
+	want := "long report:cancelled battery critical:started battery critical:finished queued:started queued:finished"
+	if strings.Join(got, " ") != want {
+		t.Errorf("events = %v, want %s", got, want)
+	}
+}
+
+func TestSpeakerClose(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.RespondWith("termux-tts-speak", termuxtest.Response{Delay: time.Hour})
+	s := termux.NewSpeaker(termux.NewClient(fake), termux.Voice{})
+
+	s.Say("first")
+	nextSpeechEvent(t, s)
+	s.Say("second")
+	s.Close()
+
+	var kinds []termux.SpeechEventKind
+	for ev := range s.Events() {
+		kinds = append(kinds, ev.Kind)
+	}
+	if len(kinds) != 2 || kinds[0] != termux.SpeechCancelled || kinds[1] != termux.SpeechCancelled {
+		t.Errorf("events after Close = %v", kinds)
+	}
+	if id := s.Say("late"); id != 0 {
+		t.Errorf("Say after Close = %d, want 0", id)
+	}
+}
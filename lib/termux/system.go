@@ -1,19 +1,20 @@
 package termux
 
 import (
+	"context"
 	"encoding/json"
-	"os/exec"
+	"time"
 )
 
 // BatteryStatus represents the current battery state of the device.
 type BatteryStatus struct {
-	Health      string  `json:"health"`       // Battery health status (e.g., "GOOD")
-	Percentage  int     `json:"percentage"`   // Battery percentage (0-100)
-	Plugged     string  `json:"plugged"`      // Plugged state (e.g., "PLUGGED_AC", "UNPLUGGED")
-	Status      string  `json:"status"`       // Charging status (e.g., "CHARGING", "DISCHARGING")
-	Temperature float64 `json:"temperature"`  // Battery temperature in Celsius
-	Current     int     `json:"current"`      // Battery current in microamperes
-	Voltage     int     `json:"voltage"`      // Battery voltage in millivolts
+	Health      string  `json:"health"`      // Battery health status (e.g., "GOOD")
+	Percentage  int     `json:"percentage"`  // Battery percentage (0-100)
+	Plugged     string  `json:"plugged"`     // Plugged state (e.g., "PLUGGED_AC", "UNPLUGGED")
+	Status      string  `json:"status"`      // Charging status (e.g., "CHARGING", "DISCHARGING")
+	Temperature float64 `json:"temperature"` // Battery temperature in Celsius
+	Current     int     `json:"current"`     // Battery current in microamperes
+	Voltage     int     `json:"voltage"`     // Battery voltage in millivolts
 }
 
 // GetBatteryStatus retrieves the current battery status.
@@ -34,7 +35,26 @@ type BatteryStatus struct {
 //	    return
 //	}
 func GetBatteryStatus() (*BatteryStatus, error) {
-	if !IsTermux() {
+	return Default().GetBatteryStatus()
+}
+
+// GetBatteryStatusContext is like GetBatteryStatus but takes a context.
+// The command is killed if ctx is done before it completes.
+func GetBatteryStatusContext(ctx context.Context) (*BatteryStatus, error) {
+	return Default().GetBatteryStatusContext(ctx)
+}
+
+// GetBatteryStatus retrieves the current battery status.
+func (c *Client) GetBatteryStatus() (*BatteryStatus, error) {
+	return c.GetBatteryStatusContext(context.Background())
+}
+
+// GetBatteryStatusContext is like GetBatteryStatus but takes a context.
+func (c *Client) GetBatteryStatusContext(ctx context.Context) (*BatteryStatus, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
 		return &BatteryStatus{
 			Health:     "GOOD",
 			Percentage: 100,
@@ -43,8 +63,7 @@ func GetBatteryStatus() (*BatteryStatus, error) {
 		}, nil
 	}
 
-	cmd := exec.Command("termux-battery-status")
-	output, err := cmd.Output()
+	output, err := c.run(ctx, "termux-battery-status")
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +80,23 @@ func GetBatteryStatus() (*BatteryStatus, error) {
 //
 // If not running on Termux, returns false.
 func IsCharging() (bool, error) {
-	status, err := GetBatteryStatus()
+	return Default().IsCharging()
+}
+
+// IsChargingContext is like IsCharging but takes a context. The command is
+// killed if ctx is done before it completes.
+func IsChargingContext(ctx context.Context) (bool, error) {
+	return Default().IsChargingContext(ctx)
+}
+
+// IsCharging reports whether the device is charging.
+func (c *Client) IsCharging() (bool, error) {
+	return c.IsChargingContext(context.Background())
+}
+
+// IsChargingContext is like IsCharging but takes a context.
+func (c *Client) IsChargingContext(ctx context.Context) (bool, error) {
+	status, err := c.GetBatteryStatusContext(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -79,7 +114,23 @@ func IsCharging() (bool, error) {
 //	    termux.Toast("Battery low - conserving power")
 //	}
 func IsBatteryLow(threshold int) (bool, error) {
-	status, err := GetBatteryStatus()
+	return Default().IsBatteryLow(threshold)
+}
+
+// IsBatteryLowContext is like IsBatteryLow but takes a context. The
+// command is killed if ctx is done before it completes.
+func IsBatteryLowContext(ctx context.Context, threshold int) (bool, error) {
+	return Default().IsBatteryLowContext(ctx, threshold)
+}
+
+// IsBatteryLow checks if the battery is below the specified percentage.
+func (c *Client) IsBatteryLow(threshold int) (bool, error) {
+	return c.IsBatteryLowContext(context.Background(), threshold)
+}
+
+// IsBatteryLowContext is like IsBatteryLow but takes a context.
+func (c *Client) IsBatteryLowContext(ctx context.Context, threshold int) (bool, error) {
+	status, err := c.GetBatteryStatusContext(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -88,13 +139,17 @@ func IsBatteryLow(threshold int) (bool, error) {
 
 // Location represents GPS coordinates and location metadata.
 type Location struct {
-	Latitude  float64 `json:"latitude"`   // Latitude in degrees
-	Longitude float64 `json:"longitude"`  // Longitude in degrees
-	Altitude  float64 `json:"altitude"`   // Altitude in meters above sea level
-	Accuracy  float64 `json:"accuracy"`   // Horizontal accuracy in meters
-	Bearing   float64 `json:"bearing"`    // Direction of travel in degrees (0-360)
-	Speed     float64 `json:"speed"`      // Speed in meters per second
-	Provider  string  `json:"provider"`   // Location provider (e.g., "gps", "network")
+	Latitude  float64 `json:"latitude"`  // Latitude in degrees
+	Longitude float64 `json:"longitude"` // Longitude in degrees
+	Altitude  float64 `json:"altitude"`  // Altitude in meters above sea level
+	Accuracy  float64 `json:"accuracy"`  // Horizontal accuracy in meters
+	Bearing   float64 `json:"bearing"`   // Direction of travel in degrees (0-360)
+	Speed     float64 `json:"speed"`     // Speed in meters per second
+	Provider  string  `json:"provider"`  // Location provider (e.g., "gps", "network")
+	ElapsedMs int64   `json:"elapsedMs"` // Age of the fix in milliseconds when reported
+
+	// Time is when the fix was taken, derived from ElapsedMs.
+	Time time.Time `json:"-"`
 }
 
 // GetLocation retrieves the current GPS location.
@@ -111,22 +166,32 @@ type Location struct {
 //	fmt.Printf("Location: %.4f, %.4f (accuracy: %.1fm)\n",
 //	    loc.Latitude, loc.Longitude, loc.Accuracy)
 func GetLocation() (*Location, error) {
-	if !IsTermux() {
-		return &Location{}, nil
-	}
+	return Default().GetLocation()
+}
 
-	cmd := exec.Command("termux-location")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, err
-	}
+// GetLocationContext is like GetLocation but takes a context. The command
+// is killed if ctx is done before it completes.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	loc, err := termux.GetLocationContext(ctx)
+//	if errors.Is(err, termux.ErrTimeout) {
+//	    termux.Toast("No GPS fix - try again outdoors")
+//	}
+func GetLocationContext(ctx context.Context) (*Location, error) {
+	return Default().GetLocationContext(ctx)
+}
 
-	var loc Location
-	if err := json.Unmarshal(output, &loc); err != nil {
-		return nil, err
-	}
+// GetLocation retrieves the current GPS location.
+func (c *Client) GetLocation() (*Location, error) {
+	return c.GetLocationContext(context.Background())
+}
 
-	return &loc, nil
+// GetLocationContext is like GetLocation but takes a context.
+func (c *Client) GetLocationContext(ctx context.Context) (*Location, error) {
+	return c.location(ctx)
 }
 
 // GetLocationWithProvider retrieves location using a specific provider.
@@ -142,22 +207,24 @@ func GetLocation() (*Location, error) {
 //
 //	loc, err := termux.GetLocationWithProvider("network")  // Faster than GPS
 func GetLocationWithProvider(provider string) (*Location, error) {
-	if !IsTermux() {
-		return &Location{}, nil
-	}
+	return Default().GetLocationWithProvider(provider)
+}
 
-	cmd := exec.Command("termux-location", "-p", provider)
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, err
-	}
+// GetLocationWithProviderContext is like GetLocationWithProvider but takes
+// a context. The command is killed if ctx is done before it completes.
+func GetLocationWithProviderContext(ctx context.Context, provider string) (*Location, error) {
+	return Default().GetLocationWithProviderContext(ctx, provider)
+}
 
-	var loc Location
-	if err := json.Unmarshal(output, &loc); err != nil {
-		return nil, err
-	}
+// GetLocationWithProvider retrieves location using a specific provider.
+func (c *Client) GetLocationWithProvider(provider string) (*Location, error) {
+	return c.GetLocationWithProviderContext(context.Background(), provider)
+}
 
-	return &loc, nil
+// GetLocationWithProviderContext is like GetLocationWithProvider but takes
+// a context.
+func (c *Client) GetLocationWithProviderContext(ctx context.Context, provider string) (*Location, error) {
+	return c.location(ctx, "-p", provider)
 }
 
 // SensorData represents data from a device sensor.
@@ -188,12 +255,30 @@ type SensorData struct {
 //	}
 //	// Check light level for automatic brightness adjustment
 func GetSensor(sensorName string) (*SensorData, error) {
-	if !IsTermux() {
+	return Default().GetSensor(sensorName)
+}
+
+// GetSensorContext is like GetSensor but takes a context. The command is
+// killed if ctx is done before it completes.
+func GetSensorContext(ctx context.Context, sensorName string) (*SensorData, error) {
+	return Default().GetSensorContext(ctx, sensorName)
+}
+
+// GetSensor retrieves data from a specific sensor.
+func (c *Client) GetSensor(sensorName string) (*SensorData, error) {
+	return c.GetSensorContext(context.Background(), sensorName)
+}
+
+// GetSensorContext is like GetSensor but takes a context.
+func (c *Client) GetSensorContext(ctx context.Context, sensorName string) (*SensorData, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
 		return &SensorData{Sensor: sensorName, Values: map[string]interface{}{}}, nil
 	}
 
-	cmd := exec.Command("termux-sensor", "-s", sensorName, "-n", "1")
-	output, err := cmd.Output()
+	output, err := c.run(ctx, "termux-sensor", "-s", sensorName, "-n", "1")
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +291,8 @@ func GetSensor(sensorName string) (*SensorData, error) {
 	return &data, nil
 }
 
-// ListSensors returns a list of available sensors on the device.
+// ListSensors returns a list of available sensors on the device as the
+// raw JSON printed by termux-sensor. ListSensorInfo parses it.
 //
 // If not running on Termux, returns an empty string.
 //
@@ -215,12 +301,27 @@ func GetSensor(sensorName string) (*SensorData, error) {
 //	sensors, err := termux.ListSensors()
 //	fmt.Println("Available sensors:", sensors)
 func ListSensors() (string, error) {
-	if !IsTermux() {
-		return "", nil
+	return Default().ListSensors()
+}
+
+// ListSensorsContext is like ListSensors but takes a context. The command
+// is killed if ctx is done before it completes.
+func ListSensorsContext(ctx context.Context) (string, error) {
+	return Default().ListSensorsContext(ctx)
+}
+
+// ListSensors returns a list of available sensors on the device.
+func (c *Client) ListSensors() (string, error) {
+	return c.ListSensorsContext(context.Background())
+}
+
+// ListSensorsContext is like ListSensors but takes a context.
+func (c *Client) ListSensorsContext(ctx context.Context) (string, error) {
+	if !c.IsTermux() {
+		return "", c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-sensor", "-l")
-	output, err := cmd.Output()
+	output, err := c.run(ctx, "termux-sensor", "-l")
 	if err != nil {
 		return "", err
 	}
@@ -253,12 +354,31 @@ type WiFiConnectionInfo struct {
 //	    // Safe to run automation at home
 //	}
 func GetWiFiConnectionInfo() (*WiFiConnectionInfo, error) {
-	if !IsTermux() {
+	return Default().GetWiFiConnectionInfo()
+}
+
+// GetWiFiConnectionInfoContext is like GetWiFiConnectionInfo but takes a
+// context. The command is killed if ctx is done before it completes.
+func GetWiFiConnectionInfoContext(ctx context.Context) (*WiFiConnectionInfo, error) {
+	return Default().GetWiFiConnectionInfoContext(ctx)
+}
+
+// GetWiFiConnectionInfo retrieves information about the current WiFi connection.
+func (c *Client) GetWiFiConnectionInfo() (*WiFiConnectionInfo, error) {
+	return c.GetWiFiConnectionInfoContext(context.Background())
+}
+
+// GetWiFiConnectionInfoContext is like GetWiFiConnectionInfo but takes a
+// context.
+func (c *Client) GetWiFiConnectionInfoContext(ctx context.Context) (*WiFiConnectionInfo, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
 		return &WiFiConnectionInfo{}, nil
 	}
 
-	cmd := exec.Command("termux-wifi-connectioninfo")
-	output, err := cmd.Output()
+	output, err := c.run(ctx, "termux-wifi-connectioninfo")
 	if err != nil {
 		return nil, err
 	}
@@ -290,12 +410,30 @@ type WiFiScanResult struct {
 //	    fmt.Printf("%s: %d dBm\n", net.SSID, net.RSSI)
 //	}
 func ScanWiFi() ([]WiFiScanResult, error) {
-	if !IsTermux() {
+	return Default().ScanWiFi()
+}
+
+// ScanWiFiContext is like ScanWiFi but takes a context. The command is
+// killed if ctx is done before it completes.
+func ScanWiFiContext(ctx context.Context) ([]WiFiScanResult, error) {
+	return Default().ScanWiFiContext(ctx)
+}
+
+// ScanWiFi scans for available WiFi networks.
+func (c *Client) ScanWiFi() ([]WiFiScanResult, error) {
+	return c.ScanWiFiContext(context.Background())
+}
+
+// ScanWiFiContext is like ScanWiFi but takes a context.
+func (c *Client) ScanWiFiContext(ctx context.Context) ([]WiFiScanResult, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
 		return []WiFiScanResult{}, nil
 	}
 
-	cmd := exec.Command("termux-wifi-scaninfo")
-	output, err := cmd.Output()
+	output, err := c.run(ctx, "termux-wifi-scaninfo")
 	if err != nil {
 		return nil, err
 	}
@@ -317,8 +455,24 @@ func ScanWiFi() ([]WiFiScanResult, error) {
 //	termux.SetWiFiEnabled(true)   // Enable WiFi
 //	termux.SetWiFiEnabled(false)  // Disable WiFi
 func SetWiFiEnabled(enabled bool) error {
-	if !IsTermux() {
-		return nil
+	return Default().SetWiFiEnabled(enabled)
+}
+
+// SetWiFiEnabledContext is like SetWiFiEnabled but takes a context. The
+// command is killed if ctx is done before it completes.
+func SetWiFiEnabledContext(ctx context.Context, enabled bool) error {
+	return Default().SetWiFiEnabledContext(ctx, enabled)
+}
+
+// SetWiFiEnabled enables or disables WiFi.
+func (c *Client) SetWiFiEnabled(enabled bool) error {
+	return c.SetWiFiEnabledContext(context.Background(), enabled)
+}
+
+// SetWiFiEnabledContext is like SetWiFiEnabled but takes a context.
+func (c *Client) SetWiFiEnabledContext(ctx context.Context, enabled bool) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
 	}
 
 	value := "false"
@@ -326,44 +480,87 @@ func SetWiFiEnabled(enabled bool) error {
 		value = "true"
 	}
 
-	cmd := exec.Command("termux-wifi-enable", value)
-	return cmd.Run()
+	_, err := c.run(ctx, "termux-wifi-enable", value)
+	return err
 }
 
 // ClipboardSet copies text to the Android clipboard.
-// This uses the heredoc pattern from TFE's clipboard implementation
-// to avoid exit status 2 errors with direct StdinPipe.
+// The text is piped to termux-clipboard-set on stdin, so it is copied
+// verbatim whatever it contains and no shell is involved.
 //
-// If not running on Termux, this is a no-op.
+// On a Linux desktop the text is copied with wl-copy, xclip or xsel, or
+// an OSC 52 escape sequence (see LinuxBackend). Otherwise, if not running
+// on Termux, this is a no-op.
 //
 // Example:
 //
 //	termux.ClipboardSet("https://github.com/user/repo/pull/123")
 func ClipboardSet(text string) error {
-	if !IsTermux() {
-		return nil
+	return Default().ClipboardSet(text)
+}
+
+// ClipboardSetContext is like ClipboardSet but takes a context. The
+// command is killed if ctx is done before it completes.
+func ClipboardSetContext(ctx context.Context, text string) error {
+	return Default().ClipboardSetContext(ctx, text)
+}
+
+// ClipboardSet copies text to the Android clipboard.
+func (c *Client) ClipboardSet(text string) error {
+	return c.ClipboardSetContext(context.Background(), text)
+}
+
+// ClipboardSetContext is like ClipboardSet but takes a context.
+func (c *Client) ClipboardSetContext(ctx context.Context, text string) error {
+	return c.Backend().ClipboardSet(ctx, text)
+}
+
+// termuxClipboardSet copies text with termux-clipboard-set.
+func (c *Client) termuxClipboardSet(ctx context.Context, text string) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
 	}
 
-	// Use heredoc pattern from TFE editor.go:115
-	cmd := exec.Command("bash", "-c", "termux-clipboard-set <<'CLIPBOARD_EOF'\n"+text+"\nCLIPBOARD_EOF")
-	return cmd.Run()
+	_, err := c.runInput(ctx, []byte(text), "termux-clipboard-set")
+	return err
 }
 
 // ClipboardGet retrieves text from the Android clipboard.
 //
-// If not running on Termux, returns an empty string.
+// On a Linux desktop the text is read with wl-paste, xclip or xsel.
+// Otherwise, if not running on Termux, returns an empty string.
 //
 // Example:
 //
 //	text, err := termux.ClipboardGet()
 //	fmt.Println("Clipboard:", text)
 func ClipboardGet() (string, error) {
-	if !IsTermux() {
-		return "", nil
+	return Default().ClipboardGet()
+}
+
+// ClipboardGetContext is like ClipboardGet but takes a context. The
+// command is killed if ctx is done before it completes.
+func ClipboardGetContext(ctx context.Context) (string, error) {
+	return Default().ClipboardGetContext(ctx)
+}
+
+// ClipboardGet retrieves text from the Android clipboard.
+func (c *Client) ClipboardGet() (string, error) {
+	return c.ClipboardGetContext(context.Background())
+}
+
+// ClipboardGetContext is like ClipboardGet but takes a context.
+func (c *Client) ClipboardGetContext(ctx context.Context) (string, error) {
+	return c.Backend().ClipboardGet(ctx)
+}
+
+// termuxClipboardGet reads the clipboard with termux-clipboard-get.
+func (c *Client) termuxClipboardGet(ctx context.Context) (string, error) {
+	if !c.IsTermux() {
+		return "", c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-clipboard-get")
-	output, err := cmd.Output()
+	output, err := c.run(ctx, "termux-clipboard-get")
 	if err != nil {
 		return "", err
 	}
@@ -377,6 +574,10 @@ func ClipboardGet() (string, error) {
 // IMPORTANT: Always release the wake lock when done using WakeUnlock()
 // or defer it to ensure it's released even on error.
 //
+// The lock is a single global toggle, so concurrent jobs unlock each
+// other's lock. Use AcquireWakeLock (or Client.WakeLocks) for a
+// reference-counted hold instead.
+//
 // If not running on Termux, this is a no-op.
 //
 // Example:
@@ -385,22 +586,54 @@ func ClipboardGet() (string, error) {
 //	defer termux.WakeUnlock()
 //	// Do long-running work...
 func WakeLock() error {
-	if !IsTermux() {
-		return nil
+	return Default().WakeLock()
+}
+
+// WakeLockContext is like WakeLock but takes a context. The command is
+// killed if ctx is done before it completes.
+func WakeLockContext(ctx context.Context) error {
+	return Default().WakeLockContext(ctx)
+}
+
+// WakeLock acquires a wake lock to prevent the device from sleeping.
+func (c *Client) WakeLock() error {
+	return c.WakeLockContext(context.Background())
+}
+
+// WakeLockContext is like WakeLock but takes a context.
+func (c *Client) WakeLockContext(ctx context.Context) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-wake-lock")
-	return cmd.Run()
+	_, err := c.run(ctx, "termux-wake-lock")
+	return err
 }
 
 // WakeUnlock releases the wake lock, allowing the device to sleep normally.
 //
 // If not running on Termux, this is a no-op.
 func WakeUnlock() error {
-	if !IsTermux() {
-		return nil
+	return Default().WakeUnlock()
+}
+
+// WakeUnlockContext is like WakeUnlock but takes a context. The command is
+// killed if ctx is done before it completes.
+func WakeUnlockContext(ctx context.Context) error {
+	return Default().WakeUnlockContext(ctx)
+}
+
+// WakeUnlock releases the wake lock, allowing the device to sleep normally.
+func (c *Client) WakeUnlock() error {
+	return c.WakeUnlockContext(context.Background())
+}
+
+// WakeUnlockContext is like WakeUnlock but takes a context.
+func (c *Client) WakeUnlockContext(ctx context.Context) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-wake-unlock")
-	return cmd.Run()
+	_, err := c.run(ctx, "termux-wake-unlock")
+	return err
 }
@@ -0,0 +1,38 @@
+package teacmd
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// Authenticate returns a command that shows the fingerprint prompt,
+// delivering a FingerprintMsg.
+func Authenticate(opts termux.FingerprintOptions) tea.Cmd {
+	return defaultCommands.Authenticate(opts)
+}
+
+// Authenticate returns a command that shows the fingerprint prompt.
+func (c *Commands) Authenticate(opts termux.FingerprintOptions) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		res, err := c.target().AuthenticateContext(ctx, opts)
+		return FingerprintMsg{Result: res, Err: err}
+	}
+}
+
+// Unlock returns a command that authenticates the user with their
+// fingerprint, falling back to a password dialog, and delivers an
+// UnlockMsg. See termux.Unlock.
+func Unlock(opts termux.UnlockOptions) tea.Cmd { return defaultCommands.Unlock(opts) }
+
+// Unlock returns a command that authenticates the user.
+func (c *Commands) Unlock(opts termux.UnlockOptions) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		res, err := c.target().UnlockContext(ctx, opts)
+		return UnlockMsg{Result: res, Err: err}
+	}
+}
@@ -0,0 +1,32 @@
+package teacmd
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// WaitForNotificationAction returns a command that waits for the next
+// notification button tapped through cb, delivering a
+// NotificationActionMsg. Re-issue it after each NotificationActionMsg:
+//
+//	func (m model) Init() tea.Cmd {
+//	    return teacmd.WaitForNotificationAction(m.callbacks)
+//	}
+//
+//	case teacmd.NotificationActionMsg:
+//	    if msg.ID == "pr-42" && msg.Button == "Approve" {
+//	        m.approved = true
+//	    }
+//	    return m, teacmd.WaitForNotificationAction(m.callbacks)
+//
+// Once cb is closed the command delivers nothing.
+func WaitForNotificationAction(cb *termux.NotificationCallbacks) tea.Cmd {
+	return func() tea.Msg {
+		action, ok := <-cb.Actions()
+		if !ok {
+			return nil
+		}
+		return NotificationActionMsg{ID: action.ID, Button: action.Button}
+	}
+}
@@ -0,0 +1,83 @@
+package teacmd
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// Volumes returns a command that queries every stream's volume,
+// delivering a VolumeMsg.
+func Volumes() tea.Cmd { return defaultCommands.Volumes() }
+
+// Volumes returns a command that queries every stream's volume.
+func (c *Commands) Volumes() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		volumes, err := c.target().GetVolumesContext(ctx)
+		return VolumeMsg{Volumes: volumes, Err: err}
+	}
+}
+
+// SetVolume returns a command that sets a stream's volume and reports the
+// outcome as a DoneMsg with Op "volume".
+func SetVolume(stream termux.VolumeStream, level int) tea.Cmd {
+	return defaultCommands.SetVolume(stream, level)
+}
+
+// SetVolume returns a command that sets a stream's volume.
+func (c *Commands) SetVolume(stream termux.VolumeStream, level int) tea.Cmd {
+	return c.do("volume", func(ctx context.Context, t *termux.Client) error {
+		return t.SetVolumeContext(ctx, stream, level)
+	})
+}
+
+// Brightness returns a command that queries the screen brightness,
+// delivering a BrightnessMsg.
+func Brightness() tea.Cmd { return defaultCommands.Brightness() }
+
+// Brightness returns a command that queries the screen brightness.
+func (c *Commands) Brightness() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		brightness, err := c.target().GetBrightnessContext(ctx)
+		return BrightnessMsg{Brightness: brightness, Err: err}
+	}
+}
+
+// SetBrightness returns a command that sets the screen brightness
+// (0-255) and reports the outcome as a DoneMsg with Op "brightness".
+func SetBrightness(level int) tea.Cmd { return defaultCommands.SetBrightness(level) }
+
+// SetBrightness returns a command that sets the screen brightness.
+func (c *Commands) SetBrightness(level int) tea.Cmd {
+	return c.do("brightness", func(ctx context.Context, t *termux.Client) error {
+		return t.SetBrightnessContext(ctx, level)
+	})
+}
+
+// SetAutoBrightness returns a command that turns adaptive brightness on
+// and reports the outcome as a DoneMsg with Op "brightness".
+func SetAutoBrightness() tea.Cmd { return defaultCommands.SetAutoBrightness() }
+
+// SetAutoBrightness returns a command that turns adaptive brightness on.
+func (c *Commands) SetAutoBrightness() tea.Cmd {
+	return c.do("brightness", func(ctx context.Context, t *termux.Client) error {
+		return t.SetAutoBrightnessContext(ctx)
+	})
+}
+
+// SetTorch returns a command that turns the flashlight on or off and
+// reports the outcome as a DoneMsg with Op "torch".
+func SetTorch(on bool) tea.Cmd { return defaultCommands.SetTorch(on) }
+
+// SetTorch returns a command that turns the flashlight on or off.
+func (c *Commands) SetTorch(on bool) tea.Cmd {
+	return c.do("torch", func(ctx context.Context, t *termux.Client) error {
+		return t.SetTorchContext(ctx, on)
+	})
+}
@@ -0,0 +1,164 @@
+package teacmd
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// ConfirmDialog returns a command that asks a yes/no question. Use
+// DialogResultMsg.Confirmed to read the answer.
+func ConfirmDialog(id, title, message string) tea.Cmd {
+	return defaultCommands.ConfirmDialog(id, title, message)
+}
+
+// ConfirmDialog returns a command that asks a yes/no question.
+func (c *Commands) ConfirmDialog(id, title, message string) tea.Cmd {
+	return c.dialog(id, func(ctx context.Context, t *termux.Client) (DialogResultMsg, error) {
+		ok, err := t.ConfirmDialogContext(ctx, title, message)
+		text := "no"
+		if ok {
+			text = "yes"
+		}
+		return DialogResultMsg{Text: text}, err
+	})
+}
+
+// TextDialog returns a command that asks for a line of text.
+func TextDialog(id, title, hint string) tea.Cmd {
+	return defaultCommands.TextDialog(id, title, hint)
+}
+
+// TextDialog returns a command that asks for a line of text.
+func (c *Commands) TextDialog(id, title, hint string) tea.Cmd {
+	return c.textDialog(id, func(ctx context.Context, t *termux.Client) (string, error) {
+		return t.TextDialogContext(ctx, title, hint)
+	})
+}
+
+// PasswordDialog returns a command that asks for hidden text input.
+func PasswordDialog(id, title, hint string) tea.Cmd {
+	return defaultCommands.PasswordDialog(id, title, hint)
+}
+
+// PasswordDialog returns a command that asks for hidden text input.
+func (c *Commands) PasswordDialog(id, title, hint string) tea.Cmd {
+	return c.textDialog(id, func(ctx context.Context, t *termux.Client) (string, error) {
+		return t.PasswordDialogContext(ctx, title, hint)
+	})
+}
+
+// RadioDialog returns a command that asks for a single choice from a
+// comma-separated list of values.
+func RadioDialog(id, title, values string) tea.Cmd {
+	return defaultCommands.RadioDialog(id, title, values)
+}
+
+// RadioDialog returns a command that asks for a single choice.
+func (c *Commands) RadioDialog(id, title, values string) tea.Cmd {
+	return c.textDialog(id, func(ctx context.Context, t *termux.Client) (string, error) {
+		return t.RadioDialogContext(ctx, title, values)
+	})
+}
+
+// CheckboxDialog returns a command that asks for any number of choices
+// from a comma-separated list. The selection is in DialogResultMsg.Values.
+func CheckboxDialog(id, title, values string) tea.Cmd {
+	return defaultCommands.CheckboxDialog(id, title, values)
+}
+
+// CheckboxDialog returns a command that asks for any number of choices.
+func (c *Commands) CheckboxDialog(id, title, values string) tea.Cmd {
+	return c.dialog(id, func(ctx context.Context, t *termux.Client) (DialogResultMsg, error) {
+		selected, err := t.CheckboxDialogContext(ctx, title, values)
+		return DialogResultMsg{Values: selected}, err
+	})
+}
+
+// SpinnerDialog returns a command that asks for a choice from a dropdown.
+func SpinnerDialog(id, title, values string) tea.Cmd {
+	return defaultCommands.SpinnerDialog(id, title, values)
+}
+
+// SpinnerDialog returns a command that asks for a choice from a dropdown.
+func (c *Commands) SpinnerDialog(id, title, values string) tea.Cmd {
+	return c.textDialog(id, func(ctx context.Context, t *termux.Client) (string, error) {
+		return t.SpinnerDialogContext(ctx, title, values)
+	})
+}
+
+// DateDialog returns a command that asks for a date (YYYY-MM-DD).
+func DateDialog(id, title string) tea.Cmd {
+	return defaultCommands.DateDialog(id, title)
+}
+
+// DateDialog returns a command that asks for a date.
+func (c *Commands) DateDialog(id, title string) tea.Cmd {
+	return c.textDialog(id, func(ctx context.Context, t *termux.Client) (string, error) {
+		return t.DateDialogContext(ctx, title)
+	})
+}
+
+// TimeDialog returns a command that asks for a time (HH:MM).
+func TimeDialog(id, title string) tea.Cmd {
+	return defaultCommands.TimeDialog(id, title)
+}
+
+// TimeDialog returns a command that asks for a time.
+func (c *Commands) TimeDialog(id, title string) tea.Cmd {
+	return c.textDialog(id, func(ctx context.Context, t *termux.Client) (string, error) {
+		return t.TimeDialogContext(ctx, title)
+	})
+}
+
+// CounterDialog returns a command that asks for a number between min and max.
+func CounterDialog(id, title string, min, max int) tea.Cmd {
+	return defaultCommands.CounterDialog(id, title, min, max)
+}
+
+// CounterDialog returns a command that asks for a number between min and max.
+func (c *Commands) CounterDialog(id, title string, min, max int) tea.Cmd {
+	return c.textDialog(id, func(ctx context.Context, t *termux.Client) (string, error) {
+		return t.CounterDialogContext(ctx, title, min, max)
+	})
+}
+
+// ShowDialog returns a command that shows the dialog described by spec.
+// The typed result is in DialogResultMsg.Result, and Text and Values are
+// filled in as for the other dialog commands.
+func ShowDialog(id string, spec termux.DialogSpec) tea.Cmd {
+	return defaultCommands.ShowDialog(id, spec)
+}
+
+// ShowDialog returns a command that shows the dialog described by spec.
+func (c *Commands) ShowDialog(id string, spec termux.DialogSpec) tea.Cmd {
+	return c.dialog(id, func(ctx context.Context, t *termux.Client) (DialogResultMsg, error) {
+		result, err := t.ShowDialogContext(ctx, spec)
+		if err != nil {
+			return DialogResultMsg{}, err
+		}
+		return DialogResultMsg{Text: result.Text, Values: result.Values, Result: result}, nil
+	})
+}
+
+// dialog runs fn and stamps the resulting message with id and error.
+func (c *Commands) dialog(id string, fn func(context.Context, *termux.Client) (DialogResultMsg, error)) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		msg, err := fn(ctx, c.target())
+		msg.ID = id
+		msg.Err = err
+		return msg
+	}
+}
+
+// textDialog adapts a dialog helper that returns plain text.
+func (c *Commands) textDialog(id string, fn func(context.Context, *termux.Client) (string, error)) tea.Cmd {
+	return c.dialog(id, func(ctx context.Context, t *termux.Client) (DialogResultMsg, error) {
+		text, err := fn(ctx, t)
+		return DialogResultMsg{Text: text}, err
+	})
+}
@@ -0,0 +1,45 @@
+package teacmd
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// TrackLocation returns a command that starts live location updates and
+// delivers the tracker as a LocationTrackerMsg. Follow it with
+// WaitForLocation, and Close the tracker when done:
+//
+//	case teacmd.LocationTrackerMsg:
+//	    m.tracker = msg.Tracker
+//	    return m, teacmd.WaitForLocation(msg.Tracker)
+//
+//	case teacmd.LocationUpdateMsg:
+//	    m.position = msg.Location
+//	    return m, teacmd.WaitForLocation(msg.Tracker)
+//
+// The tracker is not bounded by the Commands timeout; it runs until closed.
+func TrackLocation(opts termux.TrackOptions) tea.Cmd {
+	return defaultCommands.TrackLocation(opts)
+}
+
+// TrackLocation returns a command that starts a location tracker.
+func (c *Commands) TrackLocation(opts termux.TrackOptions) tea.Cmd {
+	return func() tea.Msg {
+		tracker, err := c.target().TrackLocation(opts)
+		return LocationTrackerMsg{Tracker: tracker, Err: err}
+	}
+}
+
+// WaitForLocation returns a command that waits for the next fix from
+// tracker, delivering a LocationUpdateMsg, or a LocationTrackEndMsg once
+// the tracker has stopped. Re-issue it after each LocationUpdateMsg.
+func WaitForLocation(tracker *termux.LocationTracker) tea.Cmd {
+	return func() tea.Msg {
+		loc, ok := <-tracker.Updates()
+		if !ok {
+			return LocationTrackEndMsg{Tracker: tracker, Err: tracker.Err()}
+		}
+		return LocationUpdateMsg{Tracker: tracker, Location: loc}
+	}
+}
@@ -0,0 +1,151 @@
+package teacmd
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// TakePhoto returns a command that takes a photo with the camera cameraID
+// ("" for the default) and saves it to path, delivering a PhotoMsg.
+func TakePhoto(path, cameraID string) tea.Cmd {
+	return defaultCommands.TakePhoto(path, cameraID)
+}
+
+// TakePhoto returns a command that takes a photo and saves it to path.
+func (c *Commands) TakePhoto(path, cameraID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		err := c.target().TakePhotoContext(ctx, path, cameraID)
+		return PhotoMsg{Path: path, Err: err}
+	}
+}
+
+// StartRecording returns a command that starts recording from the
+// microphone to path, delivering a RecordingMsg. Follow it with
+// PollRecording to notice when the recording stops on its own at
+// opts.Limit:
+//
+//	case teacmd.RecordingMsg:
+//	    m.recording = msg.State
+//	    if msg.State.Recording {
+//	        return m, teacmd.PollRecording(time.Second)
+//	    }
+func StartRecording(path string, opts termux.RecordingOptions) tea.Cmd {
+	return defaultCommands.StartRecording(path, opts)
+}
+
+// StartRecording returns a command that starts recording to path.
+func (c *Commands) StartRecording(path string, opts termux.RecordingOptions) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		if err := c.target().StartRecordingContext(ctx, path, opts); err != nil {
+			return RecordingMsg{Err: err}
+		}
+		return RecordingMsg{State: termux.RecordingState{Recording: true, File: path}, Started: true}
+	}
+}
+
+// StopRecording returns a command that stops the current recording,
+// delivering a RecordingMsg whose State.File is the finished file.
+func StopRecording() tea.Cmd { return defaultCommands.StopRecording() }
+
+// StopRecording returns a command that stops the current recording.
+func (c *Commands) StopRecording() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		file, err := c.target().StopRecordingContext(ctx)
+		return RecordingMsg{State: termux.RecordingState{File: file}, Stopped: err == nil, Err: err}
+	}
+}
+
+// Recording returns a command that queries the recording state,
+// delivering a RecordingMsg.
+func Recording() tea.Cmd { return defaultCommands.Recording() }
+
+// Recording returns a command that queries the recording state.
+func (c *Commands) Recording() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		state, err := c.target().GetRecordingStateContext(ctx)
+		return RecordingMsg{State: state, Err: err}
+	}
+}
+
+// PollRecording returns a command that waits for interval and then
+// queries the recording state, delivering a RecordingMsg. Re-issue it
+// while State.Recording is true to follow the recording until it ends.
+func PollRecording(interval time.Duration) tea.Cmd {
+	return defaultCommands.PollRecording(interval)
+}
+
+// PollRecording returns a command that queries the recording state after
+// interval.
+func (c *Commands) PollRecording(interval time.Duration) tea.Cmd {
+	return c.after(interval, c.Recording())
+}
+
+// PlayMedia returns a command that starts playing the audio file at path
+// and reports the outcome as a DoneMsg with Op "play".
+func PlayMedia(path string) tea.Cmd { return defaultCommands.PlayMedia(path) }
+
+// PlayMedia returns a command that starts playing the audio file at path.
+func (c *Commands) PlayMedia(path string) tea.Cmd {
+	return c.do("play", func(ctx context.Context, t *termux.Client) error {
+		return t.PlayMediaContext(ctx, path)
+	})
+}
+
+// PauseMedia returns a command that pauses playback and reports the
+// outcome as a DoneMsg with Op "pause".
+func PauseMedia() tea.Cmd { return defaultCommands.PauseMedia() }
+
+// PauseMedia returns a command that pauses playback.
+func (c *Commands) PauseMedia() tea.Cmd {
+	return c.do("pause", func(ctx context.Context, t *termux.Client) error {
+		return t.PauseMediaContext(ctx)
+	})
+}
+
+// ResumeMedia returns a command that resumes playback and reports the
+// outcome as a DoneMsg with Op "resume".
+func ResumeMedia() tea.Cmd { return defaultCommands.ResumeMedia() }
+
+// ResumeMedia returns a command that resumes playback.
+func (c *Commands) ResumeMedia() tea.Cmd {
+	return c.do("resume", func(ctx context.Context, t *termux.Client) error {
+		return t.ResumeMediaContext(ctx)
+	})
+}
+
+// StopMedia returns a command that stops playback and reports the outcome
+// as a DoneMsg with Op "stop".
+func StopMedia() tea.Cmd { return defaultCommands.StopMedia() }
+
+// StopMedia returns a command that stops playback.
+func (c *Commands) StopMedia() tea.Cmd {
+	return c.do("stop", func(ctx context.Context, t *termux.Client) error {
+		return t.StopMediaContext(ctx)
+	})
+}
+
+// Player returns a command that queries the media player, delivering a
+// PlayerMsg. Use tea.Tick to refresh a position display.
+func Player() tea.Cmd { return defaultCommands.Player() }
+
+// Player returns a command that queries the media player.
+func (c *Commands) Player() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		state, err := c.target().GetPlayerStateContext(ctx)
+		return PlayerMsg{State: state, Err: err}
+	}
+}
@@ -0,0 +1,208 @@
+package teacmd
+
+import "github.com/GGPrompts/TUITemplate/lib/termux"
+
+// BatteryMsg carries the result of a battery status query.
+type BatteryMsg struct {
+	Status *termux.BatteryStatus
+	Err    error
+}
+
+// BatteryEventMsg carries an event from a termux.BatteryMonitor.
+type BatteryEventMsg struct {
+	Event termux.BatteryEvent
+}
+
+// BatteryMonitorEndMsg reports that a termux.BatteryMonitor stopped
+// because the context passed to Run was done.
+type BatteryMonitorEndMsg struct {
+	Monitor *termux.BatteryMonitor
+}
+
+// LocationMsg carries the result of a location query.
+type LocationMsg struct {
+	Location *termux.Location
+	Err      error
+}
+
+// LocationTrackerMsg carries a tracker started by TrackLocation.
+type LocationTrackerMsg struct {
+	Tracker *termux.LocationTracker
+	Err     error
+}
+
+// LocationUpdateMsg carries one fix from a location tracker.
+type LocationUpdateMsg struct {
+	Tracker  *termux.LocationTracker
+	Location termux.Location
+}
+
+// LocationTrackEndMsg reports that a location tracker stopped. Err is nil
+// when it was closed.
+type LocationTrackEndMsg struct {
+	Tracker *termux.LocationTracker
+	Err     error
+}
+
+// WiFiMsg carries the result of a WiFi connection info query.
+type WiFiMsg struct {
+	Info *termux.WiFiConnectionInfo
+	Err  error
+}
+
+// WiFiScanMsg carries the networks found by a WiFi scan.
+type WiFiScanMsg struct {
+	Networks []termux.WiFiScanResult
+	Err      error
+}
+
+// SensorMsg carries a single sensor reading.
+type SensorMsg struct {
+	Data *termux.SensorData
+	Err  error
+}
+
+// SensorStreamMsg carries a sensor stream started by StreamSensors.
+type SensorStreamMsg struct {
+	Stream *termux.SensorStream
+	Err    error
+}
+
+// SensorReadingMsg carries one reading from a sensor stream.
+type SensorReadingMsg struct {
+	Stream  *termux.SensorStream
+	Reading termux.SensorReading
+}
+
+// SensorStreamEndMsg reports that a sensor stream ended. Err is nil when
+// it was closed or reached its reading count.
+type SensorStreamEndMsg struct {
+	Stream *termux.SensorStream
+	Err    error
+}
+
+// ClipboardMsg carries the text read from the clipboard.
+type ClipboardMsg struct {
+	Text string
+	Err  error
+}
+
+// SpeechMsg carries the text recognized by speech-to-text.
+type SpeechMsg struct {
+	Text string
+	Err  error
+}
+
+// SpeechEventMsg carries an event from a termux.Speaker. See
+// WaitForSpeechEvent.
+type SpeechEventMsg struct {
+	Event termux.SpeechEvent
+}
+
+// PhotoMsg reports a photo taken by TakePhoto.
+type PhotoMsg struct {
+	Path string // Where the photo was saved
+	Err  error
+}
+
+// RecordingMsg carries the microphone recording state. Started and Stopped
+// mark the messages from StartRecording and StopRecording; a message from
+// PollRecording with State.Recording false means the recording ended,
+// possibly on its own at the time limit.
+type RecordingMsg struct {
+	State   termux.RecordingState
+	Started bool
+	Stopped bool
+	Err     error
+}
+
+// PlayerMsg carries the media player state.
+type PlayerMsg struct {
+	State termux.PlayerState
+	Err   error
+}
+
+// StorageMsg reports a file picked by StorageGet. Err wraps
+// termux.ErrCancelled if no file was picked.
+type StorageMsg struct {
+	Path string // Where the picked file was saved
+	Err  error
+}
+
+// VolumeMsg carries the volume of every audio stream.
+type VolumeMsg struct {
+	Volumes []termux.StreamVolume
+	Err     error
+}
+
+// BrightnessMsg carries the screen brightness setting.
+type BrightnessMsg struct {
+	Brightness termux.Brightness
+	Err        error
+}
+
+// SMSMsg carries text messages listed by SMS, along with the options
+// they were listed with so a model can request the next page.
+type SMSMsg struct {
+	Messages []termux.SMS
+	Options  termux.SMSListOptions
+	Err      error
+}
+
+// ContactsMsg carries the address book.
+type ContactsMsg struct {
+	Contacts []termux.Contact
+	Err      error
+}
+
+// CallsMsg carries call log entries.
+type CallsMsg struct {
+	Calls []termux.Call
+	Err   error
+}
+
+// FingerprintMsg carries the outcome of a fingerprint prompt. Err is set
+// only if the prompt could not be shown; check Result.Success.
+type FingerprintMsg struct {
+	Result termux.FingerprintResult
+	Err    error
+}
+
+// UnlockMsg reports how Unlock authenticated the user. Err is nil only
+// when the user was authenticated by fingerprint or entered a password,
+// which the model still has to check.
+type UnlockMsg struct {
+	Result termux.UnlockResult
+	Err    error
+}
+
+// DialogResultMsg carries the user's response to a dialog.
+//
+// ID is the identifier passed to the dialog constructor, so a model with
+// several dialogs in flight can tell their results apart.
+type DialogResultMsg struct {
+	ID     string
+	Text   string               // Entered or selected text ("yes"/"no" for confirm dialogs)
+	Values []string             // Selected values (checkbox dialogs only)
+	Result *termux.DialogResult // Typed result (ShowDialog only)
+	Err    error
+}
+
+// Confirmed reports whether the user answered "yes" to a confirm dialog.
+func (m DialogResultMsg) Confirmed() bool {
+	return m.Err == nil && m.Text == "yes"
+}
+
+// NotificationActionMsg reports a notification button tapped while the
+// program is running. See WaitForNotificationAction.
+type NotificationActionMsg struct {
+	ID     string // Notification ID
+	Button string // Button token, usually its label
+}
+
+// DoneMsg reports the completion of a fire-and-forget action such as
+// Notify, Toast or Speak. Op names the action (e.g., "notify").
+type DoneMsg struct {
+	Op  string
+	Err error
+}
@@ -0,0 +1,570 @@
+package teacmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// Dialogs shows dialogs from a Bubble Tea program: the native termux-dialog
+// on Termux, and a modal drawn in the terminal everywhere else. Either way
+// the answer arrives as a DialogResultMsg with the same fields, so apps
+// behave the same on a phone and on a desktop.
+//
+// Keep a *Dialogs in the model, offer it every message first, and draw
+// the program's view through View:
+//
+//	func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+//	    if cmd, handled := m.dialogs.Update(msg); handled {
+//	        return m, cmd
+//	    }
+//	    switch msg := msg.(type) {
+//	    case tea.KeyMsg:
+//	        if msg.String() == "d" {
+//	            return m, m.dialogs.ConfirmDialog("delete", "Delete", "Delete 3 files?")
+//	        }
+//	    case teacmd.DialogResultMsg:
+//	        if msg.ID == "delete" && msg.Confirmed() {
+//	            m.deleteSelected()
+//	        }
+//	    }
+//	    return m, nil
+//	}
+//
+//	func (m model) View() string {
+//	    return m.dialogs.View(m.render())
+//	}
+//
+// In the terminal, Enter accepts and Esc or Ctrl+C cancels, reporting
+// termux.ErrCancelled like a dismissed native dialog. Speech dialogs are
+// shown as text input.
+type Dialogs struct {
+	cmds          *Commands
+	width, height int
+	modal         *modal
+}
+
+// NewDialogs returns Dialogs that show native dialogs through cmds on
+// Termux. A nil cmds means the package-level defaults.
+func NewDialogs(cmds *Commands) *Dialogs {
+	if cmds == nil {
+		cmds = defaultCommands
+	}
+	return &Dialogs{cmds: cmds}
+}
+
+// Active reports whether a terminal modal is open.
+func (d *Dialogs) Active() bool {
+	return d.modal != nil
+}
+
+// Show returns a command that shows the dialog described by spec. On
+// Termux this is ShowDialog; elsewhere Show opens the terminal modal and
+// the command delivers the result once the user answers. An invalid spec
+// is reported in DialogResultMsg.Err without showing anything.
+//
+// Opening a dialog while another modal is open cancels the first one.
+func (d *Dialogs) Show(id string, spec termux.DialogSpec) tea.Cmd {
+	return d.show(id, spec, nil)
+}
+
+// show is Show with a hook to set the modal's initial value.
+func (d *Dialogs) show(id string, spec termux.DialogSpec, init func(*modal)) tea.Cmd {
+	if d.cmds.target().IsTermux() {
+		return d.cmds.ShowDialog(id, spec)
+	}
+	if err := spec.Validate(); err != nil {
+		return dialogResult(DialogResultMsg{ID: id, Err: err})
+	}
+
+	var cancelled tea.Cmd
+	if d.modal != nil {
+		cancelled = dialogResult(DialogResultMsg{ID: d.modal.id, Err: termux.ErrCancelled})
+	}
+	d.modal = newModal(id, spec)
+	if init != nil {
+		init(d.modal)
+	}
+	return cancelled
+}
+
+// Update tracks the window size and, while a modal is open, handles its
+// keys. handled reports whether msg was a key the modal consumed, which
+// the model should then ignore; cmd delivers the DialogResultMsg once the
+// modal closes.
+func (d *Dialogs) Update(msg tea.Msg) (cmd tea.Cmd, handled bool) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		d.width, d.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		if d.modal == nil {
+			return nil, false
+		}
+		result, done := d.modal.update(msg)
+		if done {
+			d.modal = nil
+			return dialogResult(result), true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// View draws the open modal centered over background, which is returned
+// unchanged when no modal is open. Rows covered by the modal are replaced.
+func (d *Dialogs) View(background string) string {
+	if d.modal == nil {
+		return background
+	}
+	box := d.modal.view(d.width)
+	if d.width == 0 || d.height == 0 {
+		return box
+	}
+
+	lines := strings.Split(background, "\n")
+	for len(lines) < d.height {
+		lines = append(lines, "")
+	}
+	boxLines := strings.Split(box, "\n")
+	top := max((d.height-len(boxLines))/2, 0)
+	for i, line := range boxLines {
+		if top+i >= len(lines) {
+			break
+		}
+		lines[top+i] = lipgloss.PlaceHorizontal(d.width, lipgloss.Center, line)
+	}
+	return strings.Join(lines[:d.height], "\n")
+}
+
+// ConfirmDialog is like the ConfirmDialog command, shown in the terminal
+// when not on Termux.
+func (d *Dialogs) ConfirmDialog(id, title, message string) tea.Cmd {
+	return d.Show(id, termux.DialogSpec{Widget: termux.DialogConfirm, Title: title, Hint: message})
+}
+
+// TextDialog is like the TextDialog command, shown in the terminal when
+// not on Termux.
+func (d *Dialogs) TextDialog(id, title, hint string) tea.Cmd {
+	return d.Show(id, termux.DialogSpec{Widget: termux.DialogText, Title: title, Hint: hint})
+}
+
+// PasswordDialog is like the PasswordDialog command, shown in the terminal
+// when not on Termux.
+func (d *Dialogs) PasswordDialog(id, title, hint string) tea.Cmd {
+	return d.Show(id, termux.DialogSpec{Widget: termux.DialogText, Title: title, Hint: hint, Password: true})
+}
+
+// RadioDialog is like the RadioDialog command, shown in the terminal when
+// not on Termux.
+func (d *Dialogs) RadioDialog(id, title, values string) tea.Cmd {
+	return d.Show(id, termux.DialogSpec{Widget: termux.DialogRadio, Title: title, Values: termux.SplitDialogValues(values)})
+}
+
+// CheckboxDialog is like the CheckboxDialog command, shown in the terminal
+// when not on Termux.
+func (d *Dialogs) CheckboxDialog(id, title, values string) tea.Cmd {
+	return d.Show(id, termux.DialogSpec{Widget: termux.DialogCheckbox, Title: title, Values: termux.SplitDialogValues(values)})
+}
+
+// SpinnerDialog is like the SpinnerDialog command, shown in the terminal
+// when not on Termux.
+func (d *Dialogs) SpinnerDialog(id, title, values string) tea.Cmd {
+	return d.Show(id, termux.DialogSpec{Widget: termux.DialogSpinner, Title: title, Values: termux.SplitDialogValues(values)})
+}
+
+// DateDialog is like the DateDialog command, shown in the terminal when
+// not on Termux. The terminal modal starts at defaultDate (YYYY-MM-DD) if
+// it parses, and today otherwise; the Termux picker always starts at today.
+func (d *Dialogs) DateDialog(id, title, defaultDate string) tea.Cmd {
+	return d.show(id, termux.DialogSpec{Widget: termux.DialogDate, Title: title}, func(m *modal) {
+		if t, err := time.ParseInLocation(modalDateLayout, defaultDate, time.Local); err == nil {
+			m.when = t
+		}
+	})
+}
+
+// TimeDialog is like the TimeDialog command, shown in the terminal when
+// not on Termux. The terminal modal starts at defaultTime (HH:MM) if it
+// parses, and the current time otherwise; the Termux picker always starts
+// at the current time.
+func (d *Dialogs) TimeDialog(id, title, defaultTime string) tea.Cmd {
+	return d.show(id, termux.DialogSpec{Widget: termux.DialogTime, Title: title}, func(m *modal) {
+		if t, err := time.Parse(modalTimeLayout, defaultTime); err == nil {
+			m.when = atClock(m.when, t.Hour(), t.Minute())
+		}
+	})
+}
+
+// CounterDialog is like the CounterDialog command, shown in the terminal
+// when not on Termux.
+func (d *Dialogs) CounterDialog(id, title string, min, max int) tea.Cmd {
+	return d.Show(id, termux.DialogSpec{Widget: termux.DialogCounter, Title: title, Min: min, Max: max, Start: min})
+}
+
+// dialogResult returns a command that delivers msg.
+func dialogResult(msg DialogResultMsg) tea.Cmd {
+	return func() tea.Msg { return msg }
+}
+
+// Date and time formats of the text reported by the terminal modal, the
+// same as termux-dialog's.
+const (
+	modalDateLayout = "2006-01-02"
+	modalTimeLayout = "15:04"
+)
+
+// Modal styles.
+var (
+	modalBoxStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#61AFEF")).
+			Padding(1, 2)
+	modalTitleStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#61AFEF")).Bold(true)
+	modalSelectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#98C379")).Bold(true)
+	modalDimmedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#5C6370"))
+	modalErrorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#E06C75"))
+)
+
+// modalMinWidth is the narrowest the modal's content is drawn.
+const modalMinWidth = 30
+
+// modal is the terminal rendering of one dialog.
+type modal struct {
+	id     string
+	spec   termux.DialogSpec
+	widget termux.DialogWidget
+
+	input  []rune // Text entered (text, speech)
+	cursor int    // Rune offset of the cursor in input
+
+	selected int    // Highlighted value, or 0 for "yes" (choices, confirm)
+	checked  []bool // Ticked values (checkbox)
+
+	number int       // Current value (counter)
+	when   time.Time // Current value (date, time)
+	field  int       // Edited field: year/month/day or hour/minute
+
+	problem string // Why the last Enter was refused
+}
+
+// newModal returns a modal for spec, which must be valid.
+func newModal(id string, spec termux.DialogSpec) *modal {
+	m := &modal{id: id, spec: spec, widget: spec.Widget, when: time.Now()}
+	if m.widget == "" {
+		m.widget = termux.DialogText
+	}
+
+	switch m.widget {
+	case termux.DialogCheckbox:
+		m.checked = make([]bool, len(spec.Values))
+	case termux.DialogCounter:
+		if spec.Min == 0 && spec.Max == 0 {
+			m.spec.Max = 100 // termux-dialog's default range
+		}
+		m.number = min(max(spec.Start, m.spec.Min), m.spec.Max)
+	case termux.DialogDate:
+		y, mo, day := m.when.Date()
+		m.when = time.Date(y, mo, day, 0, 0, 0, 0, time.Local)
+	case termux.DialogTime:
+		m.when = atClock(m.when, m.when.Hour(), m.when.Minute())
+	}
+	return m
+}
+
+// update handles a key. It returns the result and true once the modal
+// closes.
+func (m *modal) update(msg tea.KeyMsg) (DialogResultMsg, bool) {
+	key := msg.String()
+	switch key {
+	case "esc", "ctrl+c":
+		return DialogResultMsg{ID: m.id, Err: termux.ErrCancelled}, true
+	case "ctrl+s":
+		return m.submit()
+	case "enter":
+		if m.widget == termux.DialogText && m.spec.Multiline {
+			m.insert([]rune{'\n'})
+			return DialogResultMsg{}, false
+		}
+		return m.submit()
+	}
+	m.problem = ""
+
+	switch m.widget {
+	case termux.DialogConfirm:
+		switch key {
+		case "left", "right", "tab", "shift+tab", "h", "l":
+			m.selected = 1 - m.selected
+		case "y":
+			m.selected = 0
+			return m.submit()
+		case "n":
+			m.selected = 1
+			return m.submit()
+		}
+
+	case termux.DialogText, termux.DialogSpeech:
+		m.edit(msg)
+
+	case termux.DialogRadio, termux.DialogSheet, termux.DialogSpinner, termux.DialogCheckbox:
+		switch key {
+		case "up", "k", "shift+tab":
+			m.selected = (m.selected + len(m.spec.Values) - 1) % len(m.spec.Values)
+		case "down", "j", "tab":
+			m.selected = (m.selected + 1) % len(m.spec.Values)
+		case " ", "x":
+			if m.checked != nil {
+				m.checked[m.selected] = !m.checked[m.selected]
+			}
+		}
+
+	case termux.DialogCounter:
+		step := map[string]int{"up": 1, "k": 1, "+": 1, "down": -1, "j": -1, "-": -1, "pgup": 10, "pgdown": -10}[key]
+		m.number = min(max(m.number+step, m.spec.Min), m.spec.Max)
+
+	case termux.DialogDate, termux.DialogTime:
+		fields := 3
+		if m.widget == termux.DialogTime {
+			fields = 2
+		}
+		switch key {
+		case "left", "h", "shift+tab":
+			m.field = (m.field + fields - 1) % fields
+		case "right", "l", "tab":
+			m.field = (m.field + 1) % fields
+		case "up", "k":
+			m.step(1)
+		case "down", "j":
+			m.step(-1)
+		}
+	}
+	return DialogResultMsg{}, false
+}
+
+// edit applies a text editing key to the input.
+func (m *modal) edit(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "left":
+		m.cursor = max(m.cursor-1, 0)
+	case "right":
+		m.cursor = min(m.cursor+1, len(m.input))
+	case "home", "ctrl+a":
+		m.cursor = 0
+	case "end", "ctrl+e":
+		m.cursor = len(m.input)
+	case "backspace":
+		if m.cursor > 0 {
+			m.input = append(m.input[:m.cursor-1], m.input[m.cursor:]...)
+			m.cursor--
+		}
+	case "delete":
+		if m.cursor < len(m.input) {
+			m.input = append(m.input[:m.cursor], m.input[m.cursor+1:]...)
+		}
+	case "ctrl+u":
+		m.input = m.input[m.cursor:]
+		m.cursor = 0
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.insert(msg.Runes)
+		}
+	}
+}
+
+// insert adds runes at the cursor. Numeric dialogs only accept the
+// characters of a number.
+func (m *modal) insert(runes []rune) {
+	for _, r := range runes {
+		if m.spec.Numeric && !strings.ContainsRune("0123456789.-", r) {
+			continue
+		}
+		m.input = append(m.input[:m.cursor], append([]rune{r}, m.input[m.cursor:]...)...)
+		m.cursor++
+	}
+}
+
+// step moves the edited date or time field by delta, wrapping within the
+// day for times.
+func (m *modal) step(delta int) {
+	if m.widget == termux.DialogDate {
+		switch m.field {
+		case 0:
+			m.when = m.when.AddDate(delta, 0, 0)
+		case 1:
+			m.when = m.when.AddDate(0, delta, 0)
+		default:
+			m.when = m.when.AddDate(0, 0, delta)
+		}
+		return
+	}
+	hour, minute := m.when.Hour(), m.when.Minute()
+	if m.field == 0 {
+		hour = (hour + delta + 24) % 24
+	} else {
+		minute = (minute + delta + 60) % 60
+	}
+	m.when = atClock(m.when, hour, minute)
+}
+
+// submit builds the result for the current value, filled in the way
+// ShowDialog fills in termux-dialog's answer.
+func (m *modal) submit() (DialogResultMsg, bool) {
+	result := &termux.DialogResult{Index: -1}
+
+	switch m.widget {
+	case termux.DialogConfirm:
+		result.Confirmed = m.selected == 0
+		result.Text = "no"
+		if result.Confirmed {
+			result.Text = "yes"
+		}
+	case termux.DialogText, termux.DialogSpeech:
+		result.Text = string(m.input)
+		if m.spec.Numeric && result.Text != "" {
+			if _, err := strconv.ParseFloat(result.Text, 64); err != nil {
+				m.problem = "Enter a number"
+				return DialogResultMsg{}, false
+			}
+		}
+	case termux.DialogRadio, termux.DialogSheet, termux.DialogSpinner:
+		result.Index = m.selected
+		result.Text = m.spec.Values[m.selected]
+	case termux.DialogCheckbox:
+		for i, on := range m.checked {
+			if on {
+				result.Indices = append(result.Indices, i)
+				result.Values = append(result.Values, m.spec.Values[i])
+			}
+		}
+		result.Text = "[" + strings.Join(result.Values, ", ") + "]" // as termux-dialog reports it
+	case termux.DialogCounter:
+		result.Number = m.number
+		result.Text = strconv.Itoa(m.number)
+	case termux.DialogDate:
+		result.Time = m.when
+		result.Text = m.when.Format(modalDateLayout)
+	case termux.DialogTime:
+		result.Time = m.when
+		result.Text = m.when.Format(modalTimeLayout)
+	}
+
+	return DialogResultMsg{ID: m.id, Text: result.Text, Values: result.Values, Result: result}, true
+}
+
+// view renders the modal box for a window width wide (0 if unknown).
+func (m *modal) view(width int) string {
+	inner := modalMinWidth
+	if width > 0 {
+		inner = max(min(width-8, 60), modalMinWidth)
+	}
+
+	var b strings.Builder
+	if m.spec.Title != "" {
+		b.WriteString(modalTitleStyle.Render(m.spec.Title) + "\n\n")
+	}
+	if m.spec.Hint != "" && m.widget == termux.DialogConfirm {
+		b.WriteString(m.spec.Hint + "\n\n")
+	}
+	b.WriteString(m.body())
+	if m.problem != "" {
+		b.WriteString("\n" + modalErrorStyle.Render(m.problem))
+	}
+	b.WriteString("\n\n" + modalDimmedStyle.Render(m.help()))
+
+	return modalBoxStyle.Width(inner + 4).Render(b.String())
+}
+
+// body renders the widget itself.
+func (m *modal) body() string {
+	switch m.widget {
+	case termux.DialogConfirm:
+		yes, no := "  Yes  ", "  No  "
+		if m.selected == 0 {
+			return modalSelectedStyle.Render("[ Yes ]") + " " + no
+		}
+		return yes + " " + modalSelectedStyle.Render("[ No ]")
+
+	case termux.DialogText, termux.DialogSpeech:
+		if len(m.input) == 0 && m.spec.Hint != "" {
+			return "> █" + modalDimmedStyle.Render(m.spec.Hint)
+		}
+		shown := m.input
+		if m.spec.Password {
+			shown = []rune(strings.Repeat("•", len(m.input)))
+		}
+		return "> " + string(shown[:m.cursor]) + "█" + string(shown[m.cursor:])
+
+	case termux.DialogRadio, termux.DialogSheet, termux.DialogSpinner, termux.DialogCheckbox:
+		lines := make([]string, len(m.spec.Values))
+		for i, v := range m.spec.Values {
+			mark := "( )"
+			switch {
+			case m.checked != nil && m.checked[i]:
+				mark = "[x]"
+			case m.checked != nil:
+				mark = "[ ]"
+			case i == m.selected:
+				mark = "(•)"
+			}
+			line := mark + " " + v
+			if i == m.selected {
+				line = modalSelectedStyle.Render("› " + line)
+			} else {
+				line = "  " + line
+			}
+			lines[i] = line
+		}
+		return strings.Join(lines, "\n")
+
+	case termux.DialogCounter:
+		return "◂ " + modalSelectedStyle.Render(strconv.Itoa(m.number)) + " ▸  " +
+			modalDimmedStyle.Render(strconv.Itoa(m.spec.Min)+"–"+strconv.Itoa(m.spec.Max))
+
+	case termux.DialogDate:
+		parts := []string{m.when.Format("2006"), m.when.Format("01"), m.when.Format("02")}
+		return m.fields(parts, "-") + "  " + modalDimmedStyle.Render(m.when.Format("Monday"))
+
+	case termux.DialogTime:
+		return m.fields([]string{m.when.Format("15"), m.when.Format("04")}, ":")
+	}
+	return ""
+}
+
+// fields joins date or time parts, highlighting the one being edited.
+func (m *modal) fields(parts []string, sep string) string {
+	for i := range parts {
+		if i == m.field {
+			parts[i] = modalSelectedStyle.Underline(true).Render(parts[i])
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// help returns the key hints for the widget.
+func (m *modal) help() string {
+	switch m.widget {
+	case termux.DialogConfirm:
+		return "y/n · ←/→ choose · enter ok · esc cancel"
+	case termux.DialogText, termux.DialogSpeech:
+		if m.spec.Multiline {
+			return "ctrl+s ok · esc cancel"
+		}
+		return "enter ok · esc cancel"
+	case termux.DialogCheckbox:
+		return "↑/↓ move · space toggle · enter ok · esc cancel"
+	case termux.DialogCounter:
+		return "↑/↓ ±1 · pgup/pgdn ±10 · enter ok · esc cancel"
+	case termux.DialogDate, termux.DialogTime:
+		return "←/→ field · ↑/↓ change · enter ok · esc cancel"
+	}
+	return "↑/↓ move · enter ok · esc cancel"
+}
+
+// atClock returns t's day at hour:minute in local time.
+func atClock(t time.Time, hour, minute int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, time.Local)
+}
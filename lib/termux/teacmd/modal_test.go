@@ -0,0 +1,143 @@
+package teacmd_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/teacmd"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+// desktopDialogs returns Dialogs whose client cannot see Termux.
+func desktopDialogs() *teacmd.Dialogs {
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate")
+	return teacmd.NewDialogs(teacmd.New(termux.NewClient(fake), 0))
+}
+
+// press sends keys to d and returns the result once the modal closes.
+func press(t *testing.T, d *teacmd.Dialogs, keys ...tea.KeyMsg) teacmd.DialogResultMsg {
+	t.Helper()
+	for _, key := range keys {
+		cmd, handled := d.Update(key)
+		if !handled {
+			t.Fatalf("key %q not handled", key.String())
+		}
+		if cmd != nil {
+			return cmd().(teacmd.DialogResultMsg)
+		}
+	}
+	t.Fatal("modal still open after all keys")
+	return teacmd.DialogResultMsg{}
+}
+
+func key(t tea.KeyType) tea.KeyMsg { return tea.KeyMsg{Type: t} }
+
+func runes(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+func TestDialogsNativeOnTermux(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-dialog", `{"code":-1,"text":"yes"}`)
+	d := teacmd.NewDialogs(teacmd.New(termux.NewClient(fake), 0))
+
+	msg := d.ConfirmDialog("merge", "Merge", "Merge PR #1?")().(teacmd.DialogResultMsg)
+	if !msg.Confirmed() || d.Active() {
+		t.Errorf("msg = %+v, active = %v", msg, d.Active())
+	}
+	if len(fake.CallsTo("termux-dialog")) != 1 {
+		t.Error("termux-dialog not called")
+	}
+}
+
+func TestDialogsModalCheckbox(t *testing.T) {
+	d := desktopDialogs()
+	if cmd := d.CheckboxDialog("envs", "Deploy to", "staging,prod,dev"); cmd != nil {
+		t.Fatalf("Show returned a command: %v", cmd())
+	}
+	if !d.Active() {
+		t.Fatal("modal not active")
+	}
+	if view := d.View("background"); !strings.Contains(view, "Deploy to") || !strings.Contains(view, "staging") {
+		t.Errorf("View = %q", view)
+	}
+
+	msg := press(t, d, key(tea.KeySpace), key(tea.KeyDown), key(tea.KeyDown), runes("x"), key(tea.KeyEnter))
+	if msg.ID != "envs" || msg.Err != nil {
+		t.Fatalf("msg = %+v", msg)
+	}
+	if got := strings.Join(msg.Values, ","); got != "staging,dev" {
+		t.Errorf("Values = %q", got)
+	}
+	if got := msg.Result.Indices; len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Errorf("Indices = %v", got)
+	}
+	if d.Active() || d.View("background") != "background" {
+		t.Error("modal still shown after closing")
+	}
+}
+
+func TestDialogsModalKeepsEmptyValues(t *testing.T) {
+	// Indices must match what termux-dialog reports for the same list
+	d := desktopDialogs()
+	d.CheckboxDialog("envs", "Deploy to", "a,,b")
+	msg := press(t, d, key(tea.KeyDown), key(tea.KeyDown), key(tea.KeySpace), key(tea.KeyEnter))
+	if got := msg.Result.Indices; len(got) != 1 || got[0] != 2 {
+		t.Errorf("Indices = %v, want [2]", got)
+	}
+	if got := strings.Join(msg.Values, ","); got != "b" {
+		t.Errorf("Values = %q, want b", got)
+	}
+}
+
+func TestDialogsModalText(t *testing.T) {
+	d := desktopDialogs()
+	d.PasswordDialog("key", "API key", "")
+
+	msg := press(t, d, runes("secreT"), key(tea.KeyBackspace), runes("t"), key(tea.KeyEnter))
+	if msg.Text != "secret" {
+		t.Errorf("Text = %q, want secret", msg.Text)
+	}
+}
+
+func TestDialogsModalCounterAndDate(t *testing.T) {
+	d := desktopDialogs()
+	d.CounterDialog("jobs", "Jobs", 1, 8)
+	msg := press(t, d, key(tea.KeyUp), key(tea.KeyPgUp), key(tea.KeyEnter))
+	if msg.Result.Number != 8 || msg.Text != "8" {
+		t.Errorf("counter = %d %q, want clamped to 8", msg.Result.Number, msg.Text)
+	}
+
+	d.DateDialog("due", "Due", "2024-01-31")
+	msg = press(t, d, key(tea.KeyRight), key(tea.KeyUp), key(tea.KeyEnter))
+	if msg.Text != "2024-03-02" {
+		t.Errorf("date = %q, want 2024-03-02 (one month after Jan 31)", msg.Text)
+	}
+}
+
+func TestDialogsModalCancel(t *testing.T) {
+	d := desktopDialogs()
+	d.TextDialog("first", "Name", "")
+
+	// Opening a second dialog cancels the first
+	msg := d.ConfirmDialog("second", "Sure?", "")().(teacmd.DialogResultMsg)
+	if msg.ID != "first" || !errors.Is(msg.Err, termux.ErrCancelled) {
+		t.Errorf("replaced dialog msg = %+v", msg)
+	}
+
+	msg = press(t, d, key(tea.KeyEsc))
+	if msg.ID != "second" || !errors.Is(msg.Err, termux.ErrCancelled) {
+		t.Errorf("esc msg = %+v", msg)
+	}
+}
+
+func TestDialogsInvalidSpec(t *testing.T) {
+	d := desktopDialogs()
+	msg := d.Show("bad", termux.DialogSpec{Widget: termux.DialogRadio})().(teacmd.DialogResultMsg)
+	if !errors.Is(msg.Err, termux.ErrInvalidDialog) || d.Active() {
+		t.Errorf("msg = %+v, active = %v", msg, d.Active())
+	}
+}
@@ -0,0 +1,82 @@
+package teacmd
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// PollBattery returns a command that waits for interval and then queries
+// the battery status, delivering a BatteryMsg.
+//
+// Return it again from Update whenever a BatteryMsg arrives to keep the
+// reading fresh; stop returning it to stop polling:
+//
+//	case teacmd.BatteryMsg:
+//	    m.battery = msg.Status
+//	    return m, teacmd.PollBattery(time.Minute)
+func PollBattery(interval time.Duration) tea.Cmd {
+	return defaultCommands.PollBattery(interval)
+}
+
+// PollBattery returns a command that queries the battery after interval.
+func (c *Commands) PollBattery(interval time.Duration) tea.Cmd {
+	return c.after(interval, c.Battery())
+}
+
+// PollWiFi returns a command that waits for interval and then queries the
+// current WiFi connection, delivering a WiFiMsg. Re-issue it from Update
+// the same way as PollBattery.
+func PollWiFi(interval time.Duration) tea.Cmd {
+	return defaultCommands.PollWiFi(interval)
+}
+
+// PollWiFi returns a command that queries the WiFi connection after interval.
+func (c *Commands) PollWiFi(interval time.Duration) tea.Cmd {
+	return c.after(interval, c.WiFi())
+}
+
+// PollWiFiScan returns a command that waits for interval and then scans
+// for WiFi networks, delivering a WiFiScanMsg.
+func PollWiFiScan(interval time.Duration) tea.Cmd {
+	return defaultCommands.PollWiFiScan(interval)
+}
+
+// PollWiFiScan returns a command that scans for WiFi networks after interval.
+func (c *Commands) PollWiFiScan(interval time.Duration) tea.Cmd {
+	return c.after(interval, c.ScanWiFi())
+}
+
+// WaitForBatteryEvent returns a command that waits for the next event from
+// a running termux.BatteryMonitor, delivering a BatteryEventMsg, or a
+// BatteryMonitorEndMsg once the monitor has stopped and its buffered
+// events are read. Re-issue it after each BatteryEventMsg to keep
+// listening:
+//
+//	case teacmd.BatteryEventMsg:
+//	    m.fps = msg.Event.Policy.FPS(60)
+//	    return m, teacmd.WaitForBatteryEvent(m.monitor)
+func WaitForBatteryEvent(monitor *termux.BatteryMonitor) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case event := <-monitor.Events():
+			return BatteryEventMsg{Event: event}
+		case <-monitor.Done():
+		}
+		select {
+		case event := <-monitor.Events():
+			return BatteryEventMsg{Event: event}
+		default:
+			return BatteryMonitorEndMsg{Monitor: monitor}
+		}
+	}
+}
+
+// after runs query once interval has elapsed.
+func (c *Commands) after(interval time.Duration, query tea.Cmd) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return query()
+	})
+}
@@ -0,0 +1,56 @@
+package teacmd
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// StreamSensors returns a command that starts continuous readings from the
+// named sensors and delivers the stream as a SensorStreamMsg. Follow it
+// with WaitForSensor to receive readings, and Close the stream when the
+// model no longer needs it:
+//
+//	func (m model) Init() tea.Cmd {
+//	    return teacmd.StreamSensors([]string{"accelerometer", "light"},
+//	        termux.SensorStreamOptions{Delay: 200 * time.Millisecond})
+//	}
+//
+//	case teacmd.SensorStreamMsg:
+//	    if msg.Err != nil {
+//	        return m, nil
+//	    }
+//	    m.sensors = msg.Stream
+//	    return m, teacmd.WaitForSensor(msg.Stream)
+//
+//	case teacmd.SensorReadingMsg:
+//	    if m.shake.Observe(msg.Reading) {
+//	        cmd = refresh()
+//	    }
+//	    return m, tea.Batch(cmd, teacmd.WaitForSensor(msg.Stream))
+//
+// The stream is not bounded by the Commands timeout; it runs until closed.
+func StreamSensors(sensors []string, opts termux.SensorStreamOptions) tea.Cmd {
+	return defaultCommands.StreamSensors(sensors, opts)
+}
+
+// StreamSensors returns a command that starts a sensor stream.
+func (c *Commands) StreamSensors(sensors []string, opts termux.SensorStreamOptions) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := c.target().StreamSensors(sensors, opts)
+		return SensorStreamMsg{Stream: stream, Err: err}
+	}
+}
+
+// WaitForSensor returns a command that waits for the next reading from
+// stream, delivering a SensorReadingMsg, or a SensorStreamEndMsg once the
+// stream has ended. Re-issue it after each SensorReadingMsg.
+func WaitForSensor(stream *termux.SensorStream) tea.Cmd {
+	return func() tea.Msg {
+		reading, ok := <-stream.Readings()
+		if !ok {
+			return SensorStreamEndMsg{Stream: stream, Err: stream.Err()}
+		}
+		return SensorReadingMsg{Stream: stream, Reading: reading}
+	}
+}
@@ -0,0 +1,79 @@
+package teacmd
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// Share returns a command that hands the file at path to another app and
+// reports the outcome as a DoneMsg with Op "share".
+func Share(path string, opts termux.ShareOptions) tea.Cmd {
+	return defaultCommands.Share(path, opts)
+}
+
+// Share returns a command that hands the file at path to another app.
+func (c *Commands) Share(path string, opts termux.ShareOptions) tea.Cmd {
+	return c.do("share", func(ctx context.Context, t *termux.Client) error {
+		return t.ShareContext(ctx, path, opts)
+	})
+}
+
+// ShareText returns a command that hands text to another app and reports
+// the outcome as a DoneMsg with Op "share".
+func ShareText(text string, opts termux.ShareOptions) tea.Cmd {
+	return defaultCommands.ShareText(text, opts)
+}
+
+// ShareText returns a command that hands text to another app.
+func (c *Commands) ShareText(text string, opts termux.ShareOptions) tea.Cmd {
+	return c.do("share", func(ctx context.Context, t *termux.Client) error {
+		return t.ShareTextContext(ctx, text, opts)
+	})
+}
+
+// Open returns a command that opens a file or URL with the app registered
+// for it and reports the outcome as a DoneMsg with Op "open".
+func Open(path, mimeType string, chooser bool) tea.Cmd {
+	return defaultCommands.Open(path, mimeType, chooser)
+}
+
+// Open returns a command that opens a file or URL.
+func (c *Commands) Open(path, mimeType string, chooser bool) tea.Cmd {
+	return c.do("open", func(ctx context.Context, t *termux.Client) error {
+		return t.OpenContext(ctx, path, mimeType, chooser)
+	})
+}
+
+// OpenURL returns a command that opens url in the browser and reports the
+// outcome as a DoneMsg with Op "open-url".
+func OpenURL(url string) tea.Cmd { return defaultCommands.OpenURL(url) }
+
+// OpenURL returns a command that opens url in the browser.
+func (c *Commands) OpenURL(url string) tea.Cmd {
+	return c.do("open-url", func(ctx context.Context, t *termux.Client) error {
+		return t.OpenURLContext(ctx, url)
+	})
+}
+
+// StorageGet returns a command that lets the user pick a file from
+// Android storage and copies it to dest, delivering a StorageMsg. Without
+// a timeout configured on the Commands, the pick is abandoned after
+// termux.StorageGet's default of 5 minutes.
+func StorageGet(dest string, opts termux.StorageGetOptions) tea.Cmd {
+	return defaultCommands.StorageGet(dest, opts)
+}
+
+// StorageGet returns a command that lets the user pick a file into dest.
+func (c *Commands) StorageGet(dest string, opts termux.StorageGetOptions) tea.Cmd {
+	return func() tea.Msg {
+		if c.timeout <= 0 {
+			return StorageMsg{Path: dest, Err: c.target().StorageGet(dest, opts)}
+		}
+		ctx, cancel := c.newContext()
+		defer cancel()
+		return StorageMsg{Path: dest, Err: c.target().StorageGetContext(ctx, dest, opts)}
+	}
+}
@@ -0,0 +1,26 @@
+package teacmd
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// WaitForSpeechEvent returns a command that waits for the next event from
+// speaker, delivering a SpeechEventMsg as each utterance starts and ends.
+// Re-issue it after each SpeechEventMsg:
+//
+//	case teacmd.SpeechEventMsg:
+//	    m.speaking = msg.Event.Kind == termux.SpeechStarted
+//	    return m, teacmd.WaitForSpeechEvent(m.speaker)
+//
+// Once speaker is closed the command delivers nothing.
+func WaitForSpeechEvent(speaker *termux.Speaker) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-speaker.Events()
+		if !ok {
+			return nil
+		}
+		return SpeechEventMsg{Event: ev}
+	}
+}
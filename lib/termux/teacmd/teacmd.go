@@ -0,0 +1,248 @@
+// Package teacmd adapts the termux package to Bubble Tea.
+//
+// Each constructor returns a tea.Cmd that runs a Termux command off the
+// UI goroutine and delivers a typed result message (BatteryMsg,
+// LocationMsg, DialogResultMsg, SpeechMsg, ...) that carries any error:
+//
+//	func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+//	    switch msg := msg.(type) {
+//	    case tea.KeyMsg:
+//	        if msg.String() == "c" {
+//	            return m, teacmd.TextDialog("commit", "Commit Message", "Enter message:")
+//	        }
+//
+//	    case teacmd.DialogResultMsg:
+//	        if msg.Err != nil {
+//	            m.statusMsg = "Dialog failed: " + msg.Err.Error()
+//	            return m, nil
+//	        }
+//	        m.commitMessage = msg.Text
+//
+//	    case teacmd.BatteryMsg:
+//	        if msg.Err == nil {
+//	            m.battery = msg.Status
+//	        }
+//	        return m, teacmd.PollBattery(time.Minute) // keep polling
+//	    }
+//	    return m, nil
+//	}
+//
+// The package-level constructors use termux.Default() with no deadline.
+// Use New to bind commands to a specific client or bound each command
+// with a timeout.
+package teacmd
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// Commands builds tea.Cmds that run against a particular termux.Client.
+type Commands struct {
+	client  *termux.Client
+	timeout time.Duration
+}
+
+// New returns Commands that run on client and give each command at most
+// timeout to complete. A nil client means termux.Default(); a zero timeout
+// means no deadline.
+//
+// Example:
+//
+//	cmds := teacmd.New(nil, 30*time.Second)
+//	return m, cmds.Location() // LocationMsg.Err matches termux.ErrTimeout after 30s
+func New(client *termux.Client, timeout time.Duration) *Commands {
+	return &Commands{client: client, timeout: timeout}
+}
+
+// defaultCommands backs the package-level constructors.
+var defaultCommands = New(nil, 0)
+
+// target returns the client commands should run on.
+func (c *Commands) target() *termux.Client {
+	if c.client != nil {
+		return c.client
+	}
+	return termux.Default()
+}
+
+// newContext returns a context bounded by the configured timeout.
+func (c *Commands) newContext() (context.Context, context.CancelFunc) {
+	if c.timeout > 0 {
+		return context.WithTimeout(context.Background(), c.timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// Battery returns a command that queries the battery status.
+func Battery() tea.Cmd { return defaultCommands.Battery() }
+
+// Battery returns a command that queries the battery status.
+func (c *Commands) Battery() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		status, err := c.target().GetBatteryStatusContext(ctx)
+		return BatteryMsg{Status: status, Err: err}
+	}
+}
+
+// Location returns a command that acquires the current location.
+func Location() tea.Cmd { return defaultCommands.Location() }
+
+// Location returns a command that acquires the current location.
+func (c *Commands) Location() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		loc, err := c.target().GetLocationContext(ctx)
+		return LocationMsg{Location: loc, Err: err}
+	}
+}
+
+// LocationWithProvider returns a command that acquires the location from
+// a specific provider ("gps", "network" or "passive").
+func LocationWithProvider(provider string) tea.Cmd {
+	return defaultCommands.LocationWithProvider(provider)
+}
+
+// LocationWithProvider returns a command that acquires the location from
+// a specific provider.
+func (c *Commands) LocationWithProvider(provider string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		loc, err := c.target().GetLocationWithProviderContext(ctx, provider)
+		return LocationMsg{Location: loc, Err: err}
+	}
+}
+
+// LastLocation returns a command that fetches the last known location
+// without waiting for a new fix.
+func LastLocation(provider string) tea.Cmd {
+	return defaultCommands.LastLocation(provider)
+}
+
+// LastLocation returns a command that fetches the last known location.
+func (c *Commands) LastLocation(provider string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		loc, err := c.target().GetLastLocationContext(ctx, provider)
+		return LocationMsg{Location: loc, Err: err}
+	}
+}
+
+// WiFi returns a command that queries the current WiFi connection.
+func WiFi() tea.Cmd { return defaultCommands.WiFi() }
+
+// WiFi returns a command that queries the current WiFi connection.
+func (c *Commands) WiFi() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		info, err := c.target().GetWiFiConnectionInfoContext(ctx)
+		return WiFiMsg{Info: info, Err: err}
+	}
+}
+
+// ScanWiFi returns a command that scans for WiFi networks.
+func ScanWiFi() tea.Cmd { return defaultCommands.ScanWiFi() }
+
+// ScanWiFi returns a command that scans for WiFi networks.
+func (c *Commands) ScanWiFi() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		networks, err := c.target().ScanWiFiContext(ctx)
+		return WiFiScanMsg{Networks: networks, Err: err}
+	}
+}
+
+// Sensor returns a command that takes one reading from the named sensor.
+func Sensor(name string) tea.Cmd { return defaultCommands.Sensor(name) }
+
+// Sensor returns a command that takes one reading from the named sensor.
+func (c *Commands) Sensor(name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		data, err := c.target().GetSensorContext(ctx, name)
+		return SensorMsg{Data: data, Err: err}
+	}
+}
+
+// ClipboardGet returns a command that reads the clipboard.
+func ClipboardGet() tea.Cmd { return defaultCommands.ClipboardGet() }
+
+// ClipboardGet returns a command that reads the clipboard.
+func (c *Commands) ClipboardGet() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		text, err := c.target().ClipboardGetContext(ctx)
+		return ClipboardMsg{Text: text, Err: err}
+	}
+}
+
+// SpeechToText returns a command that listens for speech and delivers
+// the recognized text as a SpeechMsg.
+func SpeechToText() tea.Cmd { return defaultCommands.SpeechToText() }
+
+// SpeechToText returns a command that listens for speech.
+func (c *Commands) SpeechToText() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		text, err := c.target().SpeechToTextContext(ctx)
+		return SpeechMsg{Text: text, Err: err}
+	}
+}
+
+// Notify returns a command that posts a notification and reports the
+// outcome as a DoneMsg with Op "notify".
+func Notify(title, content string, opts ...termux.NotifyOption) tea.Cmd {
+	return defaultCommands.Notify(title, content, opts...)
+}
+
+// Notify returns a command that posts a notification.
+func (c *Commands) Notify(title, content string, opts ...termux.NotifyOption) tea.Cmd {
+	return c.do("notify", func(ctx context.Context, t *termux.Client) error {
+		return t.NotifyContext(ctx, title, content, opts...)
+	})
+}
+
+// Toast returns a command that shows a toast and reports the outcome as
+// a DoneMsg with Op "toast".
+func Toast(message string) tea.Cmd { return defaultCommands.Toast(message) }
+
+// Toast returns a command that shows a toast.
+func (c *Commands) Toast(message string) tea.Cmd {
+	return c.do("toast", func(ctx context.Context, t *termux.Client) error {
+		return t.ToastContext(ctx, message)
+	})
+}
+
+// Speak returns a command that speaks text and reports the outcome as a
+// DoneMsg with Op "speak".
+func Speak(text string) tea.Cmd { return defaultCommands.Speak(text) }
+
+// Speak returns a command that speaks text.
+func (c *Commands) Speak(text string) tea.Cmd {
+	return c.do("speak", func(ctx context.Context, t *termux.Client) error {
+		return t.SpeakContext(ctx, text)
+	})
+}
+
+// do wraps an action that only reports an error into a DoneMsg command.
+func (c *Commands) do(op string, fn func(context.Context, *termux.Client) error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		return DoneMsg{Op: op, Err: fn(ctx, c.target())}
+	}
+}
@@ -0,0 +1,72 @@
+package teacmd_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/teacmd"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+func TestBatteryCmd(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-battery-status", `{"percentage":42,"status":"CHARGING"}`)
+	cmds := teacmd.New(termux.NewClient(fake), 0)
+
+	msg, ok := cmds.Battery()().(teacmd.BatteryMsg)
+	if !ok {
+		t.Fatal("Battery did not produce a BatteryMsg")
+	}
+	if msg.Err != nil || msg.Status.Percentage != 42 {
+		t.Errorf("BatteryMsg = %+v", msg)
+	}
+}
+
+func TestWaitForBatteryEventEnd(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-battery-status", `{"percentage":50,"plugged":"UNPLUGGED"}`)
+	fake.Respond("termux-battery-status", `{"percentage":15,"plugged":"UNPLUGGED"}`)
+	monitor := termux.NewBatteryMonitor(termux.NewClient(fake), termux.BatteryMonitorOptions{})
+	monitor.Check(context.Background())
+	monitor.Check(context.Background()) // Emits BatteryLow
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	monitor.Run(ctx)
+
+	msg, ok := teacmd.WaitForBatteryEvent(monitor)().(teacmd.BatteryEventMsg)
+	if !ok || msg.Event.Kind != termux.BatteryLow {
+		t.Fatalf("first message = %#v, want the buffered BatteryLow event", msg)
+	}
+	if end, ok := teacmd.WaitForBatteryEvent(monitor)().(teacmd.BatteryMonitorEndMsg); !ok || end.Monitor != monitor {
+		t.Errorf("after the monitor stopped: %#v, want BatteryMonitorEndMsg", end)
+	}
+}
+
+func TestDialogCmdTimeout(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.RespondWith("termux-dialog", termuxtest.Response{Delay: time.Hour})
+	cmds := teacmd.New(termux.NewClient(fake), 10*time.Millisecond)
+
+	msg := cmds.TextDialog("name", "Name", "")().(teacmd.DialogResultMsg)
+	if msg.ID != "name" {
+		t.Errorf("ID = %q, want name", msg.ID)
+	}
+	if !errors.Is(msg.Err, termux.ErrTimeout) {
+		t.Errorf("Err = %v, want ErrTimeout", msg.Err)
+	}
+}
+
+func TestConfirmDialogCmd(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-dialog", `{"code":-1,"text":"yes"}`)
+	cmds := teacmd.New(termux.NewClient(fake), 0)
+
+	msg := cmds.ConfirmDialog("merge", "Merge?", "Merge PR #1?")().(teacmd.DialogResultMsg)
+	if !msg.Confirmed() {
+		t.Errorf("Confirmed = false for %+v", msg)
+	}
+}
@@ -0,0 +1,62 @@
+package teacmd
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// SMS returns a command that lists text messages, delivering an SMSMsg.
+// Page through a folder by re-issuing it with a growing opts.Offset.
+func SMS(opts termux.SMSListOptions) tea.Cmd { return defaultCommands.SMS(opts) }
+
+// SMS returns a command that lists text messages.
+func (c *Commands) SMS(opts termux.SMSListOptions) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		msgs, err := c.target().ListSMSContext(ctx, opts)
+		return SMSMsg{Messages: msgs, Options: opts, Err: err}
+	}
+}
+
+// SendSMS returns a command that sends a text message and reports the
+// outcome as a DoneMsg with Op "sms".
+func SendSMS(to []string, text string) tea.Cmd { return defaultCommands.SendSMS(to, text) }
+
+// SendSMS returns a command that sends a text message.
+func (c *Commands) SendSMS(to []string, text string) tea.Cmd {
+	return c.do("sms", func(ctx context.Context, t *termux.Client) error {
+		return t.SendSMSContext(ctx, to, text)
+	})
+}
+
+// Contacts returns a command that lists the address book, delivering a
+// ContactsMsg.
+func Contacts() tea.Cmd { return defaultCommands.Contacts() }
+
+// Contacts returns a command that lists the address book.
+func (c *Commands) Contacts() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		contacts, err := c.target().ListContactsContext(ctx)
+		return ContactsMsg{Contacts: contacts, Err: err}
+	}
+}
+
+// Calls returns a command that lists call log entries, delivering a
+// CallsMsg.
+func Calls(limit, offset int) tea.Cmd { return defaultCommands.Calls(limit, offset) }
+
+// Calls returns a command that lists call log entries.
+func (c *Commands) Calls(limit, offset int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := c.newContext()
+		defer cancel()
+		calls, err := c.target().ListCallsContext(ctx, limit, offset)
+		return CallsMsg{Calls: calls, Err: err}
+	}
+}
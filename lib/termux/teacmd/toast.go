@@ -0,0 +1,47 @@
+package teacmd
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// ToastMsg delivers a toast pushed to a termux.ToastQueue, for the TUI to
+// draw in place of the Android toast it stands in for.
+type ToastMsg struct {
+	Message string
+	Expires time.Time
+}
+
+// WaitForToast returns a command that waits for the next toast pushed to
+// q. Re-issue it from Update after each ToastMsg to keep listening:
+//
+//	case teacmd.ToastMsg:
+//	    m.statusMsg = msg.Message
+//	    return m, teacmd.WaitForToast(m.toasts)
+func WaitForToast(q *termux.ToastQueue) tea.Cmd {
+	return func() tea.Msg {
+		toast := <-q.Updates()
+		return ToastMsg{Message: toast.Message, Expires: toast.Expires}
+	}
+}
+
+// DesktopToasts returns the toast queue of the default client's backend,
+// or nil when toasts are shown natively (on Termux).
+//
+// Example:
+//
+//	func (m model) Init() tea.Cmd {
+//	    if q := teacmd.DesktopToasts(); q != nil {
+//	        return teacmd.WaitForToast(q)
+//	    }
+//	    return nil
+//	}
+func DesktopToasts() *termux.ToastQueue {
+	if b, ok := termux.Default().Backend().(*termux.LinuxBackend); ok {
+		return b.Toasts
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package termux
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+)
+
+// CallType is the direction or outcome of a call in the call log.
+type CallType string
+
+const (
+	CallIncoming  CallType = "INCOMING"
+	CallOutgoing  CallType = "OUTGOING"
+	CallMissed    CallType = "MISSED"
+	CallRejected  CallType = "REJECTED"
+	CallBlocked   CallType = "BLOCKED"
+	CallVoicemail CallType = "VOICEMAIL"
+)
+
+// Call is a call log entry, as reported by termux-call-log.
+type Call struct {
+	Name     string   `json:"name"` // Contact name, or "UNKNOWN_CALLER"
+	Number   string   `json:"phone_number"`
+	Type     CallType `json:"type"`
+	Date     string   `json:"date"`     // Start as reported (e.g., "2024-05-01 09:30:00")
+	Duration string   `json:"duration"` // Length as reported (e.g., "01:05")
+	SIMID    string   `json:"sim_id"`   // Subscription the call used, if reported
+
+	// Time is Date parsed in the local time zone, and Length is Duration
+	// parsed; each is zero if it could not be parsed.
+	Time   time.Time     `json:"-"`
+	Length time.Duration `json:"-"`
+}
+
+// ListCalls returns call log entries, newest first: up to limit of them
+// (0 for termux-call-log's default of 10), skipping the offset newest.
+// Termux:API needs the call log permission; the first call asks for it.
+//
+// If not running on Termux, returns an empty list.
+//
+// Example:
+//
+//	calls, _ := termux.ListCalls(50, 0)
+//	for _, call := range calls {
+//	    if call.Type == termux.CallMissed {
+//	        fmt.Println("Missed:", call.Name, call.Time.Format(time.Kitchen))
+//	    }
+//	}
+func ListCalls(limit, offset int) ([]Call, error) {
+	return Default().ListCalls(limit, offset)
+}
+
+// ListCallsContext is like ListCalls but takes a context. The command is
+// killed if ctx is done before it completes.
+func ListCallsContext(ctx context.Context, limit, offset int) ([]Call, error) {
+	return Default().ListCallsContext(ctx, limit, offset)
+}
+
+// ListCalls returns call log entries, newest first.
+func (c *Client) ListCalls(limit, offset int) ([]Call, error) {
+	return c.ListCallsContext(context.Background(), limit, offset)
+}
+
+// ListCallsContext is like ListCalls but takes a context.
+func (c *Client) ListCallsContext(ctx context.Context, limit, offset int) ([]Call, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return []Call{}, nil
+	}
+
+	var args []string
+	if limit > 0 {
+		args = append(args, "-l", formatInt(limit))
+	}
+	if offset > 0 {
+		args = append(args, "-o", formatInt(offset))
+	}
+	output, err := c.run(ctx, "termux-call-log", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var calls []Call
+	if err := json.Unmarshal(output, &calls); err != nil {
+		return nil, err
+	}
+	for i := range calls {
+		if t, err := time.ParseInLocation(termuxTimeLayout, calls[i].Date, time.Local); err == nil {
+			calls[i].Time = t
+		}
+		if d, err := parseClock(calls[i].Duration); err == nil {
+			calls[i].Length = d
+		}
+	}
+	return calls, nil
+}
+
+// TelephonyInfo describes the phone and its SIM and network, as reported
+// by termux-telephony-deviceinfo. Identifiers Android withholds from apps
+// (DeviceID, SIMSerialNumber, SIMSubscriberID on Android 10+) are empty.
+type TelephonyInfo struct {
+	DataEnabled           bool   `json:"data_enabled"`
+	DataActivity          string `json:"data_activity"` // "none", "in", "out", "inout" or "dormant"
+	DataState             string `json:"data_state"`    // "disconnected", "connecting", "connected" or "suspended"
+	DeviceID              string `json:"device_id"`
+	DeviceSoftwareVersion string `json:"device_software_version"`
+	PhoneCount            int    `json:"phone_count"`      // Number of SIM slots
+	PhoneType             string `json:"phone_type"`       // "gsm", "cdma", "sip" or "none"
+	NetworkOperator       string `json:"network_operator"` // MCC+MNC, e.g. "310260"
+	NetworkOperatorName   string `json:"network_operator_name"`
+	NetworkCountryISO     string `json:"network_country_iso"`
+	NetworkType           string `json:"network_type"` // e.g. "lte", "nr", "umts"
+	NetworkRoaming        bool   `json:"network_roaming"`
+	SIMCountryISO         string `json:"sim_country_iso"`
+	SIMOperator           string `json:"sim_operator"`
+	SIMOperatorName       string `json:"sim_operator_name"`
+	SIMSerialNumber       string `json:"sim_serial_number"`
+	SIMSubscriberID       string `json:"sim_subscriber_id"`
+	SIMState              string `json:"sim_state"` // e.g. "ready", "absent", "pin_required"
+}
+
+// UnmarshalJSON decodes termux-telephony-deviceinfo output, which reports
+// data_enabled as the string "true" or "false".
+func (t *TelephonyInfo) UnmarshalJSON(data []byte) error {
+	type plain TelephonyInfo
+	aux := struct {
+		*plain
+		DataEnabled json.RawMessage `json:"data_enabled"`
+	}{plain: (*plain)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.DataEnabled = jsonScalar(aux.DataEnabled) == "true"
+	return nil
+}
+
+// GetTelephonyInfo returns information about the phone, its SIM and the
+// mobile network. Termux:API needs the phone permission; the first call
+// asks for it.
+//
+// If not running on Termux, returns a zero TelephonyInfo.
+//
+// Example:
+//
+//	info, _ := termux.GetTelephonyInfo()
+//	if info.NetworkRoaming {
+//	    m.status = "Roaming on " + info.NetworkOperatorName
+//	}
+func GetTelephonyInfo() (TelephonyInfo, error) {
+	return Default().GetTelephonyInfo()
+}
+
+// GetTelephonyInfoContext is like GetTelephonyInfo but takes a context.
+// The command is killed if ctx is done before it completes.
+func GetTelephonyInfoContext(ctx context.Context) (TelephonyInfo, error) {
+	return Default().GetTelephonyInfoContext(ctx)
+}
+
+// GetTelephonyInfo returns information about the phone, SIM and network.
+func (c *Client) GetTelephonyInfo() (TelephonyInfo, error) {
+	return c.GetTelephonyInfoContext(context.Background())
+}
+
+// GetTelephonyInfoContext is like GetTelephonyInfo but takes a context.
+func (c *Client) GetTelephonyInfoContext(ctx context.Context) (TelephonyInfo, error) {
+	if !c.IsTermux() {
+		return TelephonyInfo{}, c.fallbackErr()
+	}
+
+	output, err := c.run(ctx, "termux-telephony-deviceinfo")
+	if err != nil {
+		return TelephonyInfo{}, err
+	}
+
+	var info TelephonyInfo
+	if err := json.Unmarshal(output, &info); err != nil {
+		return TelephonyInfo{}, err
+	}
+	return info, nil
+}
+
+// CellUnavailable is the value Android reports for a cell measurement or
+// identifier it does not know.
+const CellUnavailable = math.MaxInt32
+
+// CellInfo is a cell tower seen by the modem, as reported by
+// termux-telephony-cellinfo. Which fields are set depends on Type; unset
+// ones are zero or CellUnavailable.
+type CellInfo struct {
+	Type       string `json:"type"`       // "gsm", "cdma", "lte", "wcdma", "tdscdma" or "nr"
+	Registered bool   `json:"registered"` // The cell serving the phone
+	ASU        int    `json:"asu"`        // Signal strength in arbitrary strength units
+	DBM        int    `json:"dbm"`        // Signal strength in dBm
+	Level      int    `json:"level"`      // Signal level from 0 (none) to 4 (great)
+
+	MCC string `json:"mcc"` // Mobile country code
+	MNC string `json:"mnc"` // Mobile network code
+
+	CID           int   `json:"cid"`            // Cell ID (gsm, wcdma, tdscdma)
+	LAC           int   `json:"lac"`            // Location area code (gsm, wcdma, tdscdma)
+	PSC           int   `json:"psc"`            // Primary scrambling code (wcdma)
+	CI            int   `json:"ci"`             // Cell identity (lte)
+	NCI           int64 `json:"nci"`            // Cell identity (nr)
+	PCI           int   `json:"pci"`            // Physical cell ID (lte, nr)
+	TAC           int   `json:"tac"`            // Tracking area code (lte, nr)
+	TimingAdvance int   `json:"timing_advance"` // (gsm, lte)
+	RSRP          int   `json:"rsrp"`           // Reference signal received power in dBm (lte)
+	RSRQ          int   `json:"rsrq"`           // Reference signal received quality in dB (lte)
+
+	BaseStationID int `json:"basestation"` // (cdma)
+	NetworkID     int `json:"network"`     // (cdma)
+	SystemID      int `json:"system"`      // (cdma)
+}
+
+// UnmarshalJSON decodes termux-telephony-cellinfo output, which reports
+// mcc and mnc as numbers for older cell types and as strings for newer
+// ones.
+func (ci *CellInfo) UnmarshalJSON(data []byte) error {
+	type plain CellInfo
+	aux := struct {
+		*plain
+		MCC json.RawMessage `json:"mcc"`
+		MNC json.RawMessage `json:"mnc"`
+	}{plain: (*plain)(ci)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	ci.MCC = jsonScalar(aux.MCC)
+	ci.MNC = jsonScalar(aux.MNC)
+	return nil
+}
+
+// ListCells returns the cell towers the modem currently sees, the serving
+// cell marked Registered. Termux:API needs the location permission; the
+// first call asks for it.
+//
+// If not running on Termux, returns an empty list.
+//
+// Example:
+//
+//	cells, _ := termux.ListCells()
+//	for _, cell := range cells {
+//	    if cell.Registered {
+//	        fmt.Printf("%s %d dBm (%d/4)\n", cell.Type, cell.DBM, cell.Level)
+//	    }
+//	}
+func ListCells() ([]CellInfo, error) {
+	return Default().ListCells()
+}
+
+// ListCellsContext is like ListCells but takes a context. The command is
+// killed if ctx is done before it completes.
+func ListCellsContext(ctx context.Context) ([]CellInfo, error) {
+	return Default().ListCellsContext(ctx)
+}
+
+// ListCells returns the cell towers the modem currently sees.
+func (c *Client) ListCells() ([]CellInfo, error) {
+	return c.ListCellsContext(context.Background())
+}
+
+// ListCellsContext is like ListCells but takes a context.
+func (c *Client) ListCellsContext(ctx context.Context) ([]CellInfo, error) {
+	if !c.IsTermux() {
+		if err := c.fallbackErr(); err != nil {
+			return nil, err
+		}
+		return []CellInfo{}, nil
+	}
+
+	output, err := c.run(ctx, "termux-telephony-cellinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	var cells []CellInfo
+	if err := json.Unmarshal(output, &cells); err != nil {
+		return nil, err
+	}
+	return cells, nil
+}
+
+// jsonScalar returns a JSON string, number or boolean as text: unquoted
+// for strings, "" for null or a missing value.
+func jsonScalar(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	if text := strings.TrimSpace(string(raw)); text != "null" {
+		return text
+	}
+	return ""
+}
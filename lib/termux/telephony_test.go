@@ -0,0 +1,162 @@
+package termux_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+// fixture returns the recorded command output in testdata/name.
+func fixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestListSMS(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-sms-list", fixture(t, "sms-list.json"))
+
+	msgs, err := termux.NewClient(fake).ListSMS(termux.SMSListOptions{Type: termux.SMSInbox, Limit: 20, Offset: 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(fake.CallsTo("termux-sms-list")[0].Args, " "), "-d -n -t inbox -l 20 -o 40"; got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages, want 2", len(msgs))
+	}
+	first := msgs[0]
+	if first.ID != 481 || first.ThreadID != 12 || first.Read || first.Type != termux.SMSInbox || first.Body != "Standup moved to 10" {
+		t.Errorf("first = %+v", first)
+	}
+	if want := time.Date(2024, 5, 1, 9, 30, 0, 0, time.Local); !first.Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", first.Time, want)
+	}
+	if first.Name() != "Alice" || msgs[1].Name() != "72975" {
+		t.Errorf("names = %q, %q; want the contact name, else the number", first.Name(), msgs[1].Name())
+	}
+}
+
+func TestSendSMS(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	client := termux.NewClient(fake)
+
+	if err := client.SendSMS([]string{"+15551234567", "+15557654321"}, "-s not a flag"); err != nil {
+		t.Fatal(err)
+	}
+	call := fake.CallsTo("termux-sms-send")[0]
+	if strings.Join(call.Args, " ") != "-n +15551234567,+15557654321" || string(call.Stdin) != "-s not a flag" {
+		t.Errorf("call = %+v", call)
+	}
+
+	if err := client.SendSMS([]string{"1,2"}, "hi"); err == nil {
+		t.Error("recipient with a comma accepted")
+	}
+	if err := client.SendSMS(nil, "hi"); err == nil {
+		t.Error("no recipients accepted")
+	}
+
+	fake.Respond("termux-sms-send", "ERROR: SMS permission not granted")
+	if err := client.SendSMS([]string{"+15551234567"}, "hi"); !errors.Is(err, termux.ErrPermissionDenied) {
+		t.Errorf("err = %v, want ErrPermissionDenied", err)
+	}
+}
+
+func TestListContacts(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-contact-list", fixture(t, "contact-list.json"))
+
+	contacts, err := termux.NewClient(fake).ListContacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 3 || contacts[2] != (termux.Contact{Name: "Bob", Number: "+15550001111"}) {
+		t.Errorf("contacts = %+v", contacts)
+	}
+}
+
+func TestListCalls(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-call-log", fixture(t, "call-log.json"))
+
+	calls, err := termux.NewClient(fake).ListCalls(50, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(fake.CallsTo("termux-call-log")[0].Args, " "); got != "-l 50 -o 10" {
+		t.Errorf("args = %q", got)
+	}
+	if len(calls) != 2 || calls[0].Type != termux.CallMissed || calls[0].Number != "+15551234567" || calls[0].SIMID != "1" {
+		t.Fatalf("calls = %+v", calls)
+	}
+	if want := time.Hour + 2*time.Minute + 5*time.Second; calls[1].Length != want {
+		t.Errorf("Length = %v, want %v", calls[1].Length, want)
+	}
+	if calls[1].Time.IsZero() {
+		t.Error("Time not parsed")
+	}
+}
+
+func TestTelephonyInfo(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-telephony-deviceinfo", fixture(t, "telephony-deviceinfo.json"))
+
+	info, err := termux.NewClient(fake).GetTelephonyInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.DataEnabled || info.PhoneCount != 2 || info.NetworkOperatorName != "T-Mobile" || info.NetworkType != "lte" || info.DeviceID != "" {
+		t.Errorf("info = %+v", info)
+	}
+}
+
+func TestListCells(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Respond("termux-telephony-cellinfo", fixture(t, "telephony-cellinfo.json"))
+
+	cells, err := termux.NewClient(fake).ListCells()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) != 2 {
+		t.Fatalf("got %d cells, want 2", len(cells))
+	}
+	lte, nr := cells[0], cells[1]
+	if !lte.Registered || lte.DBM != -100 || lte.CI != 26470402 || lte.TimingAdvance != termux.CellUnavailable {
+		t.Errorf("lte = %+v", lte)
+	}
+	// mcc/mnc are numbers for LTE and strings for NR
+	if lte.MCC != "310" || lte.MNC != "260" || nr.MCC != "310" || nr.MNC != "260" {
+		t.Errorf("codes = %s/%s, %s/%s", lte.MCC, lte.MNC, nr.MCC, nr.MNC)
+	}
+	if nr.NCI != 68719476735 || nr.Level != 4 {
+		t.Errorf("nr = %+v", nr)
+	}
+}
+
+func TestTelephonyOffTermux(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	fake.Uninstall("termux-vibrate")
+	client := termux.NewClient(fake)
+
+	if msgs, err := client.ListSMS(termux.SMSListOptions{}); err != nil || len(msgs) != 0 {
+		t.Errorf("ListSMS = %v, %v", msgs, err)
+	}
+	if err := client.SendSMS([]string{"+15551234567"}, "hi"); err != nil {
+		t.Errorf("SendSMS = %v", err)
+	}
+	if len(fake.Calls()) != 0 {
+		t.Errorf("ran %v", fake.Calls())
+	}
+}
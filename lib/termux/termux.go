@@ -3,19 +3,15 @@
 // when running in the Termux environment.
 //
 // All functions gracefully degrade to no-ops when not running on Termux,
-// making it safe to use in cross-platform applications.
+// making it safe to use in cross-platform applications. Enable strict mode
+// with SetStrict to get ErrNotTermux instead of placeholder values.
+//
+// Failures are classified into sentinel errors (ErrAPINotInstalled,
+// ErrPermissionDenied, ErrCancelled, ErrTimeout) that can be matched with
+// errors.Is.
 package termux
 
-import (
-	"os/exec"
-	"sync"
-)
-
-var (
-	// isTermuxEnv caches the result of Termux environment detection
-	isTermuxEnv     *bool
-	isTermuxEnvOnce sync.Once
-)
+import "context"
 
 // IsTermux checks if the application is running in a Termux environment.
 // It caches the result for subsequent calls.
@@ -24,18 +20,7 @@ var (
 // which is a lightweight command that should exist in all Termux installations
 // with the Termux:API package installed.
 func IsTermux() bool {
-	isTermuxEnvOnce.Do(func() {
-		result := commandAvailable("termux-vibrate")
-		isTermuxEnv = &result
-	})
-	return *isTermuxEnv
-}
-
-// commandAvailable checks if a command is available in the system PATH.
-// This is similar to the editorAvailable function from TFE's editor.go.
-func commandAvailable(name string) bool {
-	_, err := exec.LookPath(name)
-	return err == nil
+	return Default().IsTermux()
 }
 
 // Vibrate triggers phone vibration for the specified duration in milliseconds.
@@ -52,12 +37,28 @@ func commandAvailable(name string) bool {
 //
 //	termux.Vibrate(50)  // Quick haptic feedback
 func Vibrate(durationMs int) error {
-	if !IsTermux() {
-		return nil
+	return Default().Vibrate(durationMs)
+}
+
+// VibrateContext is like Vibrate but takes a context. The command is
+// killed if ctx is done before it completes.
+func VibrateContext(ctx context.Context, durationMs int) error {
+	return Default().VibrateContext(ctx, durationMs)
+}
+
+// Vibrate triggers phone vibration for the specified duration in milliseconds.
+func (c *Client) Vibrate(durationMs int) error {
+	return c.VibrateContext(context.Background(), durationMs)
+}
+
+// VibrateContext is like Vibrate but takes a context.
+func (c *Client) VibrateContext(ctx context.Context, durationMs int) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-vibrate", "-d", formatInt(durationMs))
-	return cmd.Run()
+	_, err := c.run(ctx, "termux-vibrate", "-d", formatInt(durationMs))
+	return err
 }
 
 // VibrateForce triggers forced vibration that works even when the device
@@ -65,29 +66,57 @@ func Vibrate(durationMs int) error {
 //
 // If not running on Termux, this is a no-op.
 func VibrateForce(durationMs int) error {
-	if !IsTermux() {
-		return nil
+	return Default().VibrateForce(durationMs)
+}
+
+// VibrateForceContext is like VibrateForce but takes a context. The
+// command is killed if ctx is done before it completes.
+func VibrateForceContext(ctx context.Context, durationMs int) error {
+	return Default().VibrateForceContext(ctx, durationMs)
+}
+
+// VibrateForce triggers forced vibration that works even in silent mode.
+func (c *Client) VibrateForce(durationMs int) error {
+	return c.VibrateForceContext(context.Background(), durationMs)
+}
+
+// VibrateForceContext is like VibrateForce but takes a context.
+func (c *Client) VibrateForceContext(ctx context.Context, durationMs int) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
 	}
 
-	cmd := exec.Command("termux-vibrate", "-d", formatInt(durationMs), "-f")
-	return cmd.Run()
+	_, err := c.run(ctx, "termux-vibrate", "-d", formatInt(durationMs), "-f")
+	return err
 }
 
 // Toast displays a short popup message (approximately 2 seconds).
 // Toasts are non-intrusive and useful for quick status updates.
 //
-// If not running on Termux, this is a no-op.
+// On a Linux desktop the toast is queued for in-terminal display (see
+// LinuxBackend). Otherwise, if not running on Termux, this is a no-op.
 //
 // Example:
 //
 //	termux.Toast("File saved successfully")
 func Toast(message string) error {
-	if !IsTermux() {
-		return nil
-	}
+	return Default().Toast(message)
+}
 
-	cmd := exec.Command("termux-toast", message)
-	return cmd.Run()
+// ToastContext is like Toast but takes a context. The command is killed if
+// ctx is done before it completes.
+func ToastContext(ctx context.Context, message string) error {
+	return Default().ToastContext(ctx, message)
+}
+
+// Toast displays a short popup message.
+func (c *Client) Toast(message string) error {
+	return c.ToastContext(context.Background(), message)
+}
+
+// ToastContext is like Toast but takes a context.
+func (c *Client) ToastContext(ctx context.Context, message string) error {
+	return c.Backend().Toast(ctx, message, ToastLengthNormal)
 }
 
 // ToastLong displays a longer popup message (approximately 4 seconds).
@@ -99,24 +128,65 @@ func Toast(message string) error {
 //
 //	termux.ToastLong("Processing complete - 15 files updated")
 func ToastLong(message string) error {
-	if !IsTermux() {
-		return nil
-	}
+	return Default().ToastLong(message)
+}
 
-	cmd := exec.Command("termux-toast", "-l", message)
-	return cmd.Run()
+// ToastLongContext is like ToastLong but takes a context. The command is
+// killed if ctx is done before it completes.
+func ToastLongContext(ctx context.Context, message string) error {
+	return Default().ToastLongContext(ctx, message)
+}
+
+// ToastLong displays a longer popup message.
+func (c *Client) ToastLong(message string) error {
+	return c.ToastLongContext(context.Background(), message)
+}
+
+// ToastLongContext is like ToastLong but takes a context.
+func (c *Client) ToastLongContext(ctx context.Context, message string) error {
+	return c.Backend().Toast(ctx, message, ToastLengthLong)
 }
 
 // ToastShort displays a very brief popup message.
 //
 // If not running on Termux, this is a no-op.
 func ToastShort(message string) error {
-	if !IsTermux() {
-		return nil
+	return Default().ToastShort(message)
+}
+
+// ToastShortContext is like ToastShort but takes a context. The command is
+// killed if ctx is done before it completes.
+func ToastShortContext(ctx context.Context, message string) error {
+	return Default().ToastShortContext(ctx, message)
+}
+
+// ToastShort displays a very brief popup message.
+func (c *Client) ToastShort(message string) error {
+	return c.ToastShortContext(context.Background(), message)
+}
+
+// ToastShortContext is like ToastShort but takes a context.
+func (c *Client) ToastShortContext(ctx context.Context, message string) error {
+	return c.Backend().Toast(ctx, message, ToastLengthShort)
+}
+
+// termuxToast shows a toast with termux-toast.
+func (c *Client) termuxToast(ctx context.Context, message string, length ToastLength) error {
+	if !c.IsTermux() {
+		return c.fallbackErr()
+	}
+
+	args := []string{}
+	switch length {
+	case ToastLengthLong:
+		args = append(args, "-l")
+	case ToastLengthShort:
+		args = append(args, "-s")
 	}
+	args = append(args, message)
 
-	cmd := exec.Command("termux-toast", "-s", message)
-	return cmd.Run()
+	_, err := c.run(ctx, "termux-toast", args...)
+	return err
 }
 
 // formatInt converts an integer to a string.
@@ -0,0 +1,195 @@
+// Package termuxtest provides a fake termux.Runner for testing code that
+// uses the termux package on machines without Termux installed.
+//
+// The fake records every command it is asked to run and answers with
+// canned output registered per command name:
+//
+//	fake := termuxtest.NewRunner()
+//	fake.Respond("termux-battery-status", `{"percentage": 15, "status": "DISCHARGING"}`)
+//
+//	client := termux.NewClient(fake)
+//	low, _ := client.IsBatteryLow(20) // true
+//
+//	calls := fake.Calls() // [termux-battery-status]
+package termuxtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+)
+
+// ErrNotFound is returned by LookPath for commands marked as uninstalled.
+var ErrNotFound = errors.New("termuxtest: executable file not found")
+
+// Response is the canned result returned for a command.
+type Response struct {
+	Output   string        // Data written to stdout
+	Stderr   string        // Data written to stderr (reported when ExitCode != 0)
+	ExitCode int           // Non-zero codes make Run return a *termux.ExitError
+	Err      error         // Error returned from Run (takes precedence over ExitCode)
+	Delay    time.Duration // How long Run blocks before answering
+}
+
+// Runner is a fake termux.Runner that records invocations and returns
+// canned responses. It is safe for concurrent use.
+//
+// By default every command is reported as installed, so a Client backed
+// by a new Runner behaves as if it were running on Termux. Commands with
+// no registered response succeed with empty output.
+type Runner struct {
+	mu          sync.Mutex
+	calls       []termux.Command
+	responses   map[string][]Response
+	uninstalled map[string]bool
+}
+
+// NewRunner creates a fake Runner that reports every command as installed.
+func NewRunner() *Runner {
+	return &Runner{
+		responses:   make(map[string][]Response),
+		uninstalled: make(map[string]bool),
+	}
+}
+
+// Respond registers stdout output for the named command.
+//
+// Calling Respond several times for the same command queues the responses:
+// each Run consumes one, and the last is repeated once the queue is drained.
+func (r *Runner) Respond(name, output string) {
+	r.RespondWith(name, Response{Output: output})
+}
+
+// Fail registers an error to be returned when the named command runs.
+// Like Respond, repeated calls queue up responses.
+func (r *Runner) Fail(name string, err error) {
+	r.RespondWith(name, Response{Err: err})
+}
+
+// Exit registers a non-zero exit code and stderr output for the named
+// command, as a real Termux command reporting a failure would produce.
+func (r *Runner) Exit(name string, code int, stderr string) {
+	r.RespondWith(name, Response{ExitCode: code, Stderr: stderr})
+}
+
+// RespondWith registers a full Response for the named command.
+func (r *Runner) RespondWith(name string, resp Response) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses[name] = append(r.responses[name], resp)
+}
+
+// Uninstall marks commands as missing, so LookPath fails for them.
+// Uninstalling "termux-vibrate" makes a Client behave as if it were
+// not running on Termux.
+func (r *Runner) Uninstall(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range names {
+		r.uninstalled[name] = true
+	}
+}
+
+// Run records the command and returns its next registered response.
+//
+// If the response has a Delay, Run blocks for that long, returning
+// ctx.Err() early if ctx is done first. Use a long Delay to simulate a
+// dialog the user never answers.
+//
+// For streaming commands (cmd.Stdout set) the output is written to
+// cmd.Stdout before the Delay, so a long Delay simulates a process that
+// keeps running after its last reading until it is stopped.
+func (r *Runner) Run(ctx context.Context, cmd termux.Command) ([]byte, error) {
+	r.mu.Lock()
+	cmd.Args = append([]string(nil), cmd.Args...)
+	if cmd.Stdin != nil {
+		cmd.Stdin = append([]byte(nil), cmd.Stdin...)
+	}
+	r.calls = append(r.calls, cmd)
+	resp := r.next(cmd.Name)
+	r.mu.Unlock()
+
+	output := []byte(resp.Output)
+	if cmd.Stdout != nil {
+		if _, err := cmd.Stdout.Write(output); err != nil {
+			return nil, err
+		}
+		output = nil
+	}
+
+	if resp.Delay > 0 {
+		timer := time.NewTimer(resp.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if resp.Err != nil {
+		return output, resp.Err
+	}
+	if resp.ExitCode != 0 {
+		return output, &termux.ExitError{Code: resp.ExitCode, Stderr: []byte(resp.Stderr)}
+	}
+	return output, nil
+}
+
+// next pops the next queued response for name, keeping the last one.
+// The caller must hold r.mu.
+func (r *Runner) next(name string) Response {
+	queue := r.responses[name]
+	if len(queue) == 0 {
+		return Response{}
+	}
+	resp := queue[0]
+	if len(queue) > 1 {
+		r.responses[name] = queue[1:]
+	}
+	return resp
+}
+
+// LookPath reports every command as installed unless it was uninstalled.
+func (r *Runner) LookPath(name string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.uninstalled[name] {
+		return "", ErrNotFound
+	}
+	return "/data/data/com.termux/files/usr/bin/" + name, nil
+}
+
+// Calls returns a copy of every command run so far, in order.
+func (r *Runner) Calls() []termux.Command {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]termux.Command(nil), r.calls...)
+}
+
+// CallsTo returns the commands run so far with the given name, in order.
+func (r *Runner) CallsTo(name string) []termux.Command {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []termux.Command
+	for _, cmd := range r.calls {
+		if cmd.Name == name {
+			calls = append(calls, cmd)
+		}
+	}
+	return calls
+}
+
+// Reset forgets recorded calls. Registered responses are kept.
+func (r *Runner) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
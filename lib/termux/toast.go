@@ -0,0 +1,92 @@
+package termux
+
+import (
+	"sync"
+	"time"
+)
+
+// TerminalToast is a toast waiting to be drawn inside the terminal.
+type TerminalToast struct {
+	Message string
+	Expires time.Time
+}
+
+// ToastQueue collects toasts for in-terminal display on platforms without
+// native toasts. The TUI drains it: either poll Active from View, or wait
+// on Updates (teacmd.WaitForToast wraps this as a tea.Cmd).
+//
+// Example:
+//
+//	func (m model) View() string {
+//	    for _, t := range m.toasts.Active(time.Now()) {
+//	        status = t.Message
+//	    }
+//	    ...
+//	}
+type ToastQueue struct {
+	mu      sync.Mutex
+	toasts  []TerminalToast
+	updates chan TerminalToast
+}
+
+// toastQueueBuffer is how many pushed toasts Updates holds for a slow reader.
+const toastQueueBuffer = 16
+
+// NewToastQueue creates an empty ToastQueue.
+func NewToastQueue() *ToastQueue {
+	return &ToastQueue{updates: make(chan TerminalToast, toastQueueBuffer)}
+}
+
+// Push queues a toast that stays active for d.
+func (q *ToastQueue) Push(message string, d time.Duration) {
+	now := time.Now()
+	toast := TerminalToast{Message: message, Expires: now.Add(d)}
+
+	q.mu.Lock()
+	q.toasts = append(q.prune(now), toast)
+	q.mu.Unlock()
+
+	// Never block the caller if nobody is listening
+	select {
+	case q.updates <- toast:
+	default:
+	}
+}
+
+// Active returns the toasts that have not expired at now, oldest first.
+func (q *ToastQueue) Active(now time.Time) []TerminalToast {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.toasts = q.prune(now)
+	return append([]TerminalToast(nil), q.toasts...)
+}
+
+// Updates delivers each toast as it is pushed. Toasts pushed while the
+// channel's buffer is full are still visible through Active.
+func (q *ToastQueue) Updates() <-chan TerminalToast {
+	return q.updates
+}
+
+// prune drops expired toasts. The caller must hold q.mu.
+func (q *ToastQueue) prune(now time.Time) []TerminalToast {
+	kept := q.toasts[:0]
+	for _, t := range q.toasts {
+		if t.Expires.After(now) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// toastDuration maps a ToastLength to an on-screen duration.
+func toastDuration(length ToastLength) time.Duration {
+	switch length {
+	case ToastLengthLong:
+		return 4 * time.Second
+	case ToastLengthShort:
+		return time.Second
+	default:
+		return 2 * time.Second
+	}
+}
@@ -0,0 +1,240 @@
+package termux
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// wakeUnlockTimeout bounds the termux-wake-unlock run when the last hold
+// is released.
+const wakeUnlockTimeout = 10 * time.Second
+
+// WakeLocks reference-counts the device wake lock so concurrent jobs can
+// each keep the phone awake without unlocking each other: the lock is
+// taken by the first Acquire and released with the last hold.
+//
+// Every Client has one, returned by Client.WakeLocks. Don't mix it with
+// WakeLock and WakeUnlock, which toggle the same lock directly.
+//
+// Example:
+//
+//	locks := termux.Default().WakeLocks()
+//	defer locks.ReleaseAll()
+//	defer locks.ReleaseOnPanic()
+//
+//	// Stays awake until sync returns or ctx is cancelled
+//	err := locks.Hold(ctx, "sync", func(ctx context.Context) error {
+//	    return sync(ctx)
+//	})
+type WakeLocks struct {
+	c *Client
+
+	// Logf reports holds that were garbage collected without being
+	// released. It defaults to log.Printf.
+	Logf func(format string, args ...any)
+
+	mu     sync.Mutex
+	holds  map[int]WakeHoldInfo // Unreleased holds by ID
+	nextID int
+}
+
+// WakeHoldInfo describes an unreleased hold on the wake lock.
+type WakeHoldInfo struct {
+	ID     int
+	Reason string    // Reason given to Acquire
+	Since  time.Time // When the hold was acquired
+	Caller string    // File and line that called Acquire
+}
+
+// WakeHold is one reference to the wake lock. Release it when the work
+// that needs the phone awake is done.
+type WakeHold struct {
+	locks *WakeLocks
+	id    int
+	stop  func() bool // Stops the context release
+}
+
+// WakeLocks returns the client's wake lock counter.
+func (c *Client) WakeLocks() *WakeLocks {
+	c.wakeLocksOnce.Do(func() {
+		c.wakeLocks = &WakeLocks{
+			c:     c,
+			Logf:  log.Printf,
+			holds: make(map[int]WakeHoldInfo),
+		}
+	})
+	return c.wakeLocks
+}
+
+// AcquireWakeLock takes a hold on the default client's wake lock. See
+// WakeLocks.Acquire.
+//
+// Example:
+//
+//	hold, err := termux.AcquireWakeLock(ctx, "backup")
+//	if err != nil {
+//	    return err
+//	}
+//	defer hold.Release()
+func AcquireWakeLock(ctx context.Context, reason string) (*WakeHold, error) {
+	return Default().WakeLocks().acquire(ctx, reason, 2)
+}
+
+// Acquire takes a hold on the wake lock, acquiring it with termux-wake-lock
+// if this is the only hold. The hold is released when Release is called or
+// ctx is done, whichever comes first. reason identifies the hold in Active
+// and in leak reports.
+//
+// A hold that is garbage collected without being released is logged
+// through Logf and released.
+//
+// If not running on Termux, holds are counted but no lock is taken.
+func (l *WakeLocks) Acquire(ctx context.Context, reason string) (*WakeHold, error) {
+	return l.acquire(ctx, reason, 2)
+}
+
+// acquire implements Acquire, recording the caller skip frames up.
+func (l *WakeLocks) acquire(ctx context.Context, reason string, skip int) (*WakeHold, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	info := WakeHoldInfo{Reason: reason, Since: time.Now()}
+	if _, file, line, ok := runtime.Caller(skip); ok {
+		info.Caller = filepath.Base(file) + ":" + formatInt(line)
+	}
+
+	l.mu.Lock()
+	if len(l.holds) == 0 {
+		if err := l.c.WakeLockContext(ctx); err != nil {
+			l.mu.Unlock()
+			return nil, err
+		}
+	}
+	l.nextID++
+	info.ID = l.nextID
+	l.holds[info.ID] = info
+	l.mu.Unlock()
+
+	h := &WakeHold{locks: l, id: info.ID}
+	id := info.ID
+	// The callbacks capture the ID rather than h so an abandoned hold can
+	// still be collected and reported.
+	h.stop = context.AfterFunc(ctx, func() { l.release(id) })
+	runtime.SetFinalizer(h, func(h *WakeHold) {
+		if info, held := l.get(h.id); held {
+			l.Logf("termux: wake lock %q acquired at %s was never released (held %s)",
+				info.Reason, info.Caller, time.Since(info.Since).Round(time.Second))
+			l.release(h.id)
+		}
+	})
+	return h, nil
+}
+
+// Hold keeps the phone awake while fn runs. The hold is released when fn
+// returns or ctx is done.
+func (l *WakeLocks) Hold(ctx context.Context, reason string, fn func(context.Context) error) error {
+	h, err := l.acquire(ctx, reason, 2)
+	if err != nil {
+		return err
+	}
+	defer h.Release()
+	return fn(ctx)
+}
+
+// Active returns the unreleased holds, oldest first.
+func (l *WakeLocks) Active() []WakeHoldInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	active := make([]WakeHoldInfo, 0, len(l.holds))
+	for _, info := range l.holds {
+		active = append(active, info)
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].ID < active[j].ID })
+	return active
+}
+
+// Held reports whether any hold is unreleased.
+func (l *WakeLocks) Held() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.holds) > 0
+}
+
+// ReleaseAll drops every hold and releases the wake lock. Defer it from
+// main so the phone can sleep once the program exits.
+func (l *WakeLocks) ReleaseAll() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.holds) == 0 {
+		return nil
+	}
+	clear(l.holds)
+	return l.unlock()
+}
+
+// ReleaseOnPanic releases the wake lock if the program is panicking, then
+// continues the panic. It must be deferred directly:
+//
+//	defer termux.Default().WakeLocks().ReleaseOnPanic()
+func (l *WakeLocks) ReleaseOnPanic() {
+	if r := recover(); r != nil {
+		l.ReleaseAll()
+		panic(r)
+	}
+}
+
+// ReleaseOnSignal releases the wake lock when the process receives one of
+// sigs (default: interrupt and SIGTERM), through the handler shared with
+// other OnSignal cleanups. It does not exit; see OnSignalExit for programs
+// that don't handle the signal themselves. Call the returned function to
+// stop watching.
+func (l *WakeLocks) ReleaseOnSignal(sigs ...os.Signal) (stop func()) {
+	return OnSignal(func() { l.ReleaseAll() }, sigs...)
+}
+
+// Release gives up the hold, releasing the wake lock with
+// termux-wake-unlock if it was the last one. Releasing again does nothing.
+func (h *WakeHold) Release() error {
+	h.stop()
+	runtime.SetFinalizer(h, nil)
+	return h.locks.release(h.id)
+}
+
+// get returns the hold with id if it is unreleased.
+func (l *WakeLocks) get(id int) (WakeHoldInfo, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	info, ok := l.holds[id]
+	return info, ok
+}
+
+// release drops the hold with id, unlocking if it was the last.
+func (l *WakeLocks) release(id int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.holds[id]; !ok {
+		return nil
+	}
+	delete(l.holds, id)
+	if len(l.holds) > 0 {
+		return nil
+	}
+	return l.unlock()
+}
+
+// unlock runs termux-wake-unlock. The caller must hold l.mu.
+func (l *WakeLocks) unlock() error {
+	ctx, cancel := context.WithTimeout(context.Background(), wakeUnlockTimeout)
+	defer cancel()
+	return l.c.WakeUnlockContext(ctx)
+}
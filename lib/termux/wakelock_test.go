@@ -0,0 +1,142 @@
+package termux_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GGPrompts/TUITemplate/lib/termux"
+	"github.com/GGPrompts/TUITemplate/lib/termux/termuxtest"
+)
+
+// wakeCalls returns how many times the lock and unlock commands ran.
+func wakeCalls(fake *termuxtest.Runner) (locks, unlocks int) {
+	return len(fake.CallsTo("termux-wake-lock")), len(fake.CallsTo("termux-wake-unlock"))
+}
+
+func TestWakeLocksRefCount(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	locks := termux.NewClient(fake).WakeLocks()
+	ctx := context.Background()
+
+	a, err := locks.Acquire(ctx, "upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := locks.Acquire(ctx, "index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l, u := wakeCalls(fake); l != 1 || u != 0 {
+		t.Fatalf("after two acquires: %d locks, %d unlocks", l, u)
+	}
+
+	active := locks.Active()
+	if len(active) != 2 || active[0].Reason != "upload" || !strings.HasPrefix(active[0].Caller, "wakelock_test.go:") {
+		t.Errorf("Active = %+v", active)
+	}
+
+	a.Release()
+	a.Release() // no effect
+	if _, u := wakeCalls(fake); u != 0 {
+		t.Error("unlocked while a hold remains")
+	}
+	b.Release()
+	if l, u := wakeCalls(fake); l != 1 || u != 1 || locks.Held() {
+		t.Errorf("after releasing both: %d locks, %d unlocks, held %v", l, u, locks.Held())
+	}
+}
+
+func TestWakeLocksContext(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	locks := termux.NewClient(fake).WakeLocks()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := locks.Acquire(ctx, "download"); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for locks.Held() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if _, u := wakeCalls(fake); u != 1 {
+		t.Errorf("cancelling the context ran %d unlocks, want 1", u)
+	}
+
+	if _, err := locks.Acquire(ctx, "late"); err == nil {
+		t.Error("Acquire with a done context succeeded")
+	}
+}
+
+func TestWakeLocksHold(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	locks := termux.NewClient(fake).WakeLocks()
+
+	err := locks.Hold(context.Background(), "backup", func(ctx context.Context) error {
+		if !locks.Held() {
+			t.Error("lock not held while fn runs")
+		}
+		return fmt.Errorf("disk full")
+	})
+	if err == nil || err.Error() != "disk full" {
+		t.Errorf("Hold = %v, want fn's error", err)
+	}
+	if locks.Held() {
+		t.Error("lock still held after fn returned")
+	}
+}
+
+func TestWakeLocksReleaseAll(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	locks := termux.NewClient(fake).WakeLocks()
+
+	a, _ := locks.Acquire(context.Background(), "a")
+	locks.Acquire(context.Background(), "b")
+	if err := locks.ReleaseAll(); err != nil {
+		t.Fatal(err)
+	}
+	a.Release()
+	if l, u := wakeCalls(fake); l != 1 || u != 1 {
+		t.Errorf("%d locks, %d unlocks, want 1 each", l, u)
+	}
+}
+
+func TestWakeLocksLogsLeak(t *testing.T) {
+	fake := termuxtest.NewRunner()
+	locks := termux.NewClient(fake).WakeLocks()
+	logged := make(chan string, 1)
+	locks.Logf = func(format string, args ...any) {
+		logged <- fmt.Sprintf(format, args...)
+	}
+
+	func() {
+		locks.Acquire(context.Background(), "forgotten")
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case msg := <-logged:
+			if !strings.Contains(msg, `"forgotten"`) {
+				t.Errorf("leak report = %q", msg)
+			}
+			for locks.Held() {
+				select {
+				case <-deadline:
+					t.Fatal("leaked hold was not released")
+				case <-time.After(time.Millisecond):
+				}
+			}
+			return
+		case <-deadline:
+			t.Fatal("leaked hold was never reported")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}